@@ -1,18 +1,33 @@
 package apriori
 
 import (
+	"context"
 	"errors"
+	"math"
 	"sort"
+	"strings"
+	"time"
 )
 
-const combinationStringChannelLastElement = "STOP"
-const combinationIntChannelLastElement = -1
 const minLengthNeededForNextCandidates = 3
 
-// SupportRecord containing items and their support
+// NegatedItemPrefix is prepended to an item to name its absence from a transaction, e.g. "¬wine"
+const NegatedItemPrefix = "¬"
+
+// SupportRecord containing items and their support. The records are never modified once returned, but the slices
+// returned by their getters are shared with them, so they must be copied before being modified.
 type SupportRecord struct {
-	items   []string
-	support float64
+	items          []string
+	support        float64
+	supportCount   int64
+	allConfidence  float64
+	transactionIDs []int64
+}
+
+// NewSupportRecord is a quick way to create a SupportRecord, e.g. when loading persisted results. The statistics
+// that are computed during mining, like the all-confidence, are NaN.
+func NewSupportRecord(items []string, support float64) SupportRecord {
+	return SupportRecord{items: items, support: support, allConfidence: math.NaN()}
 }
 
 // GetItems in current support record
@@ -25,12 +40,61 @@ func (sr SupportRecord) GetSupport() float64 {
 	return sr.support
 }
 
+// GetSupportCount for current support record items: the number of transactions that contain all of them,
+// 0 when unknown (records created with NewSupportRecord)
+func (sr SupportRecord) GetSupportCount() int64 {
+	return sr.supportCount
+}
+
+// GetTransactionIDs for current support record items: the indexes, in the order they were added, of the
+// transactions that contain all of them. They are only kept when Options.KeepTransactionIDs is set, and capped
+// to Options.MaxTransactionIDs. The rules of a RelationRecord are supported by the same transactions.
+func (sr SupportRecord) GetTransactionIDs() []int64 {
+	return sr.transactionIDs
+}
+
+// GetAllConfidence (h-confidence) for current support record items: the support divided by the highest support
+// of any single item in the record
+func (sr SupportRecord) GetAllConfidence() float64 {
+	return sr.allConfidence
+}
+
 // OrderedStatistic is the struct that contain base items + added items and their confidence and lift
 type OrderedStatistic struct {
 	base       []string
 	add        []string
 	confidence float64
 	lift       float64
+
+	baseSupport float64
+	addSupport  float64
+
+	supportCount int64
+	baseCount    int64
+	addCount     int64
+
+	chiSquare       float64
+	chiSquarePValue float64
+	fisherPValue    float64
+
+	bidirectional     bool    // Set when the reverse rule, add => base, was collapsed into this one.
+	reverseConfidence float64 // Confidence of the reverse rule, when bidirectional.
+}
+
+// NewOrderedStatistic is a quick way to create an OrderedStatistic, e.g. when loading persisted results. The
+// supports of the base and add and the significance statistics are NaN.
+func NewOrderedStatistic(base []string, add []string, confidence float64, lift float64) OrderedStatistic {
+	return OrderedStatistic{
+		base:            base,
+		add:             add,
+		confidence:      confidence,
+		lift:            lift,
+		baseSupport:     math.NaN(),
+		addSupport:      math.NaN(),
+		chiSquare:       math.NaN(),
+		chiSquarePValue: math.NaN(),
+		fisherPValue:    math.NaN(),
+	}
 }
 
 // GetBase will return the base items
@@ -53,12 +117,89 @@ func (os OrderedStatistic) GetLift() float64 {
 	return os.lift
 }
 
-// RelationRecord contains both the support record and the ordered statistics slice
+// GetBaseSupport will return the support of the base items, NaN when unknown
+func (os OrderedStatistic) GetBaseSupport() float64 {
+	return os.baseSupport
+}
+
+// GetAddSupport will return the support of the add items, NaN when unknown
+func (os OrderedStatistic) GetAddSupport() float64 {
+	return os.addSupport
+}
+
+// GetKulczynski will return the Kulczynski measure of the base and add, the mean of the confidences of the rule
+// and of the reverse rule. Unlike the lift it's null-invariant, i.e. it doesn't depend on the transactions that
+// contain neither. NaN when the supports of the base and add are unknown.
+func (os OrderedStatistic) GetKulczynski() float64 {
+	return (os.confidence + os.confidence*os.baseSupport/os.addSupport) / 2
+}
+
+// GetImbalanceRatio will return the imbalance ratio of the base and add, from 0 when they are equally frequent to
+// 1 when one of them almost never occurs with the other. NaN when the supports of the base and add are unknown.
+func (os OrderedStatistic) GetImbalanceRatio() float64 {
+	support := os.confidence * os.baseSupport
+	return math.Abs(os.baseSupport-os.addSupport) / (os.baseSupport + os.addSupport - support)
+}
+
+// GetSupportCount will return the number of transactions that contain both the base and the add items,
+// 0 when unknown
+func (os OrderedStatistic) GetSupportCount() int64 {
+	return os.supportCount
+}
+
+// GetBaseCount will return the number of transactions that contain the base items, 0 when unknown
+func (os OrderedStatistic) GetBaseCount() int64 {
+	return os.baseCount
+}
+
+// GetAddCount will return the number of transactions that contain the add items, 0 when unknown
+func (os OrderedStatistic) GetAddCount() int64 {
+	return os.addCount
+}
+
+// GetChiSquare will return the chi-square statistic of the base and add contingency table
+func (os OrderedStatistic) GetChiSquare() float64 {
+	return os.chiSquare
+}
+
+// GetChiSquarePValue will return the p-value of the chi-square test, with one degree of freedom
+func (os OrderedStatistic) GetChiSquarePValue() float64 {
+	return os.chiSquarePValue
+}
+
+// GetFisherPValue will return the one-sided p-value of the Fisher exact test for a positive association
+func (os OrderedStatistic) GetFisherPValue() float64 {
+	return os.fisherPValue
+}
+
+// IsBidirectional will return whether the reverse rule, add => base, was collapsed into the rule, see
+// Options.CollapseSymmetricRules
+func (os OrderedStatistic) IsBidirectional() bool {
+	return os.bidirectional
+}
+
+// GetReverseConfidence will return the confidence of the reverse rule, add => base, NaN when the rule isn't
+// bidirectional
+func (os OrderedStatistic) GetReverseConfidence() float64 {
+	if !os.bidirectional {
+		return math.NaN()
+	}
+
+	return os.reverseConfidence
+}
+
+// RelationRecord contains both the support record and the ordered statistics slice. Like the support records, it
+// is never modified once returned and shares the slices returned by its getters.
 type RelationRecord struct {
 	supportRecord    SupportRecord
 	orderedStatistic []OrderedStatistic
 }
 
+// NewRelationRecord is a quick way to create a RelationRecord from a support record and its ordered statistics
+func NewRelationRecord(supportRecord SupportRecord, orderedStatistic []OrderedStatistic) RelationRecord {
+	return RelationRecord{supportRecord: supportRecord, orderedStatistic: orderedStatistic}
+}
+
 // GetSupportRecord will return the support record
 func (r RelationRecord) GetSupportRecord() SupportRecord {
 	return r.supportRecord
@@ -69,88 +210,506 @@ func (r RelationRecord) GetOrderedStatistic() []OrderedStatistic {
 	return r.orderedStatistic
 }
 
-// Options struct contain the options that the apriori algorithm will take into account
-type Options struct {
-	minSupport    float64 // The minimum support of relations (float).
-	minConfidence float64 // The minimum confidence of relations (float).
-	minLift       float64 // The minimum lift of relations (float).
-	maxLength     int     // The maximum length of the relation (integer).
-}
-
-func (options Options) check() error {
-	// Check Options
-	if options.minSupport <= 0 {
-		return errors.New("minimum support must be > 0")
-	}
-
-	return nil
-}
-
 // Apriori is the main struct that contains the algorithm data
 type Apriori struct {
 	transactionNo       int64
 	items               []string
+	sortedItems         []string // Sorted snapshot of items, nil when outdated.
 	transactionIndexMap map[interface{}][]int64
+	negatedIndexMap     map[string][]int64
+	ancestorIndexMap    map[string][]int64
+	weights             []float64 // Weights of the transactions, nil when they all count the same.
+	totalWeight         float64
+	store               TransactionStore             // Keeps the index instead of transactionIndexMap when set.
+	storeItems          map[string]struct{}          // Items of the store, to find the new ones without a scan.
+	storeFailure        *storeFailure                // Records the store errors of a mining run, nil to panic with them.
+	quantities          map[string]map[int64]float64 // Quantities of the items by transaction, when other than 1.
+	quantitySupport     QuantitySupport              // Way the quantities count in the supports of a mining run.
+	itemNormalizer      func(string) string          // Applied to the items of the added transactions, when not nil.
+	transactionPolicy   *TransactionPolicy           // Applied to the added transactions, when not nil.
+	policyStats         PolicyStats
 }
 
-// NewOptions is a quick way to create an Options struct
-func NewOptions(minSupport float64, minConfidence float64, minLift float64, maxLength int) Options {
-	return Options{minSupport: minSupport, minConfidence: minConfidence, minLift: minLift, maxLength: maxLength}
-}
-
-// NewApriori is a quick way to create an Apriori struct and add transactions to it
+// NewApriori is a quick way to create an Apriori struct and add transactions to it. Large datasets are indexed
+// in parallel, see AddTransactionsBatch. The items repeated in a transaction count once, like in a set.
 func NewApriori(transactions [][]string) *Apriori {
 	var a Apriori
 	a.transactionIndexMap = make(map[interface{}][]int64)
-	for _, transaction := range transactions {
-		a.addTransaction(transaction)
-	}
+	a.AddTransactionsBatch(transactions, 0)
 
 	return &a
 }
 
-// Calculate Apriori results based on provided options
+// TransactionCount returns the number of transactions added to the Apriori struct
+func (a *Apriori) TransactionCount() int64 {
+	return a.transactionNo
+}
+
+// Items returns a sorted copy of the distinct items found in the transactions
+func (a *Apriori) Items() []string {
+	items := make([]string, len(a.items))
+	if a.sortedItems != nil {
+		copy(items, a.sortedItems)
+	} else {
+		copy(items, a.items)
+		sort.Strings(items)
+	}
+
+	return items
+}
+
+// ItemFrequency returns the number of transactions that contain the item
+func (a *Apriori) ItemFrequency(item string) int64 {
+	return int64(len(a.storedItemIndexes(item)))
+}
+
+// AddTransaction adds a transaction after the ones the Apriori struct was created with. The items repeated in the
+// transaction count once. It panics with the transactions rejected by the TransactionPolicy and with the errors of
+// the store, AddTransactionsBatch returns them as an error.
+func (a *Apriori) AddTransaction(transaction []string) {
+	if a.transactionIndexMap == nil {
+		a.transactionIndexMap = make(map[interface{}][]int64)
+	}
+	if err := a.addTransaction(transaction); err != nil {
+		panic(err)
+	}
+}
+
+// Calculate Apriori results based on provided options. Like the other mining methods, it doesn't modify the
+// Apriori struct, so it can be called from several goroutines at once, as long as no transactions are added or
+// removed meanwhile.
 func (a *Apriori) Calculate(options Options) []RelationRecord {
-	if err := options.check(); err != nil {
+	relationRecords, err := a.CalculateContext(context.Background(), options)
+	if err != nil {
 		panic(err)
 	}
 
+	return relationRecords
+}
+
+// CalculateContext is like Calculate but stops mining once the context is done, returning its error, and returns
+// the invalid options as an error instead of panicking
+func (a *Apriori) CalculateContext(ctx context.Context, options Options) ([]RelationRecord, error) {
+	if err := options.check(); err != nil {
+		return nil, err
+	}
+	a = a.miningView()
+	a.quantitySupport = options.QuantitySupport
+	start := time.Now()
+	if options.MetricsCollector != nil {
+		options.MetricsCollector.TransactionsIndexed(a.transactionNo)
+	}
+	ctx, span := options.startSpan(ctx, "apriori.Calculate")
+	defer span.End()
+	span.SetAttribute("transactions", a.transactionNo)
+	a.reportPolicyStats(options, span)
+
+	// A store that can't be read, or a checkpoint that can't be saved, stops the run like a done context.
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	if a.store != nil {
+		a.storeFailure = &storeFailure{cancel: cancel}
+	}
+
+	minSupport, err := a.tuneMinSupport(ctx, options)
+	if err != nil {
+		if storeErr := a.storeFailure.error(); storeErr != nil {
+			return nil, storeErr
+		}
+		return nil, err
+	}
+	options.minSupport = minSupport
+
+	options.Consequent = a.normalizeItems(options.Consequent)
+
+	// The itemsets longer than the longest rule aren't needed.
+	addLength := 1
+	if options.ConsequentLength > 0 {
+		addLength = options.ConsequentLength
+	}
+	if options.MaxAntecedentLength > 0 {
+		maxRuleLength := options.MaxAntecedentLength + addLength
+		if len(options.Consequent) > 0 {
+			maxRuleLength = options.MaxAntecedentLength + len(options.Consequent)
+		}
+		if options.maxLength == 0 || options.maxLength > maxRuleLength {
+			options.maxLength = maxRuleLength
+		}
+	}
+
+	if options.checkpoint, err = a.openCheckpoint(options, cancel); err != nil {
+		return nil, err
+	}
+
 	// Calculate supports
 	supportRecords := make(chan SupportRecord)
-	go a.generateSupportRecords(supportRecords, options.minSupport, options.maxLength)
+	go a.generateSupportRecords(ctx, supportRecords, options)
+
+	confidences := newConfidenceCache(a)
 
 	var relationRecords []RelationRecord
-	// Calculate ordered stats
+	streamedRules := 0
+	// Calculate ordered stats, while the generator goes through the levels.
+	_, rulesSpan := options.startSpan(ctx, "apriori.rules")
 	for {
-		supportRecord := <-supportRecords
+		var supportRecord SupportRecord
+		select {
+		case supportRecord = <-supportRecords:
+		case <-ctx.Done():
+			rulesSpan.End()
+			if err := options.checkpoint.error(); err != nil {
+				return nil, err
+			}
+			if err := a.storeFailure.error(); err != nil {
+				return nil, err
+			}
+			return nil, ctx.Err()
+		}
 		if supportRecord.support == -1 {
 			break
 		}
+		if options.OnItemset != nil {
+			options.OnItemset(supportRecord)
+		}
 
-		filteredOrderedStatistics := a.filterOrderedStatistics(
-			a.generateOrderedStatistics(supportRecord),
-			options.minConfidence,
-			options.minLift)
+		var orderedStatistics []OrderedStatistic
+		if len(options.Consequent) > 0 {
+			base := a.itemDifference(supportRecord.items, options.Consequent)
+			orderedStatistics = []OrderedStatistic{a.generateOrderedStatistic(base, supportRecord.items, supportRecord.support)}
+		} else {
+			orderedStatistics = a.generateOrderedStatistics(supportRecord, addLength)
+		}
 
+		filteredOrderedStatistics := a.filterOrderedStatistics(orderedStatistics, options.minConfidence, options.minLift, options.ruleFilter(), options.Logger)
+		if options.PruneRedundantRules {
+			filteredOrderedStatistics = a.pruneRedundantRules(filteredOrderedStatistics, confidences)
+		}
+		if options.PruneAncestorRedundantRules {
+			filteredOrderedStatistics = a.pruneAncestorRedundantRules(filteredOrderedStatistics, options.Taxonomy)
+		}
+		if options.MinImprovement > 0 {
+			filteredOrderedStatistics = a.pruneUnproductiveRules(filteredOrderedStatistics, options.MinImprovement, confidences)
+		}
+		if options.CollapseSymmetricRules {
+			filteredOrderedStatistics = a.collapseSymmetricRules(filteredOrderedStatistics)
+		}
 		if len(filteredOrderedStatistics) == 0 {
 			continue
 		}
 
+		// The p-value correction needs all the rules, they are passed to OnRule once corrected.
+		if options.OnRule != nil && options.MaxPValue == 0 {
+			options.OnRule(RelationRecord{supportRecord, filteredOrderedStatistics})
+			streamedRules += len(filteredOrderedStatistics)
+			continue
+		}
 		relationRecords = append(relationRecords, RelationRecord{supportRecord, filteredOrderedStatistics})
 	}
 
+	rulesSpan.End()
+
+	// The generator stops early once the context is done, the records received so far are incomplete.
+	if err := options.checkpoint.error(); err != nil {
+		return nil, err
+	}
+	if err := a.storeFailure.error(); err != nil {
+		return nil, err
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	if options.MaxPValue > 0 {
+		_, significanceSpan := options.startSpan(ctx, "apriori.significance")
+		relationRecords = a.filterSignificantRelationRecords(relationRecords, options.MaxPValue, options.PValueCorrection)
+		significanceSpan.End()
+	}
+	rules := streamedRules
+	for _, relationRecord := range relationRecords {
+		rules += len(relationRecord.orderedStatistic)
+	}
+	span.SetAttribute("rules", int64(rules))
+	if options.MetricsCollector != nil {
+		options.MetricsCollector.RulesEmitted(rules)
+		options.MetricsCollector.MiningFinished(time.Since(start))
+	}
+	if options.MaxPValue > 0 && options.OnRule != nil {
+		for _, relationRecord := range relationRecords {
+			options.OnRule(relationRecord)
+		}
+		return nil, nil
+	}
+
+	return relationRecords, nil
+}
+
+// FrequentItemsets returns the support records of the frequent itemsets based on provided options, without
+// generating any rule. The confidence, lift and other rule thresholds are ignored.
+func (a *Apriori) FrequentItemsets(options Options) []SupportRecord {
+	if err := options.check(); err != nil {
+		panic(err)
+	}
+	a = a.miningView()
+	a.quantitySupport = options.QuantitySupport
+	start := time.Now()
+	if options.MetricsCollector != nil {
+		options.MetricsCollector.TransactionsIndexed(a.transactionNo)
+	}
+	ctx, span := options.startSpan(context.Background(), "apriori.FrequentItemsets")
+	defer span.End()
+	span.SetAttribute("transactions", a.transactionNo)
+	a.reportPolicyStats(options, span)
+	// Like invalid options, the tuning and checkpoint errors cause a panic.
+	var err error
+	if options.minSupport, err = a.tuneMinSupport(ctx, options); err != nil {
+		panic(err)
+	}
+
+	options.Consequent = a.normalizeItems(options.Consequent)
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	if options.checkpoint, err = a.openCheckpoint(options, cancel); err != nil {
+		panic(err)
+	}
+
+	supportRecords := make(chan SupportRecord)
+	go a.generateSupportRecords(ctx, supportRecords, options)
+
+	var frequentItemsets []SupportRecord
+	for {
+		var supportRecord SupportRecord
+		select {
+		case supportRecord = <-supportRecords:
+		case <-ctx.Done():
+			panic(options.checkpoint.error())
+		}
+		if supportRecord.support == -1 {
+			break
+		}
+		if options.OnItemset != nil {
+			options.OnItemset(supportRecord)
+			continue
+		}
+		frequentItemsets = append(frequentItemsets, supportRecord)
+	}
+	if options.MetricsCollector != nil {
+		options.MetricsCollector.MiningFinished(time.Since(start))
+	}
+
+	return frequentItemsets
+}
+
+// GenerateRules derives the rules from previously mined frequent itemsets, for example persisted results of
+// FrequentItemsets, so different confidence and lift thresholds can be tried without mining the supports again.
+// The supports of the bases and adds are looked up in the itemsets, rules for which they are missing are skipped.
+// The transaction count is derived from the support counts of the itemsets, when they don't have any the
+// counts of the rules are 0 and their significance statistics are NaN.
+func GenerateRules(itemsets []SupportRecord, minConfidence float64, minLift float64) []RelationRecord {
+	var a Apriori
+	supports := make(map[string]float64, len(itemsets))
+	for _, itemset := range itemsets {
+		supports[itemsetKey(a.normalizeItems(itemset.items))] = itemset.support
+		if itemset.supportCount > 0 && itemset.support > 0 {
+			a.transactionNo = int64(math.Round(float64(itemset.supportCount) / itemset.support))
+		}
+	}
+	supports[itemsetKey(nil)] = 1.0
+
+	var relationRecords []RelationRecord
+	for _, itemset := range itemsets {
+		items := a.normalizeItems(itemset.items)
+		if len(items) == 0 {
+			continue
+		}
+
+		var orderedStatistics []OrderedStatistic
+		for _, base := range a.generateCandidateCombinations(items, len(items)-1) {
+			add := a.itemDifference(items, base)
+			supportForBase, baseFound := supports[itemsetKey(base)]
+			supportForAdd, addFound := supports[itemsetKey(add)]
+			if !baseFound || !addFound {
+				continue
+			}
+			orderedStatistics = append(orderedStatistics, newOrderedStatistic(base, add, itemset.support, supportForBase, supportForAdd, a.transactionNo))
+		}
+
+		filteredOrderedStatistics := a.filterOrderedStatistics(orderedStatistics, minConfidence, minLift, nil, nil)
+		if len(filteredOrderedStatistics) == 0 {
+			continue
+		}
+		relationRecords = append(relationRecords, RelationRecord{SupportRecord{items, itemset.support, itemset.supportCount, itemset.allConfidence, itemset.transactionIDs}, filteredOrderedStatistics})
+	}
+
 	return relationRecords
 }
 
-func (a *Apriori) addTransaction(transaction []string) {
+// RemoveTransactions forgets the transactions with an index lower than upTo, e.g. the ones older than a retention
+// period, without rebuilding the Apriori struct. The remaining transactions are renumbered starting with 0 and
+// the items left without any transaction are forgotten.
+func (a *Apriori) RemoveTransactions(upTo int64) {
+	if a.store != nil {
+		panic(errors.New("transactions can't be removed from a store"))
+	}
+	if upTo <= 0 {
+		return
+	}
+	if upTo > a.transactionNo {
+		upTo = a.transactionNo
+	}
+
+	var items []string
+	for _, item := range a.items {
+		indexes := a.transactionIndexMap[item]
+		kept := indexes[sort.Search(len(indexes), func(i int) bool { return indexes[i] >= upTo }):]
+		if len(kept) == 0 {
+			delete(a.transactionIndexMap, item)
+			continue
+		}
+		// The index lists may be shared with previous results, so they are copied instead of shifted in place.
+		shifted := make([]int64, len(kept))
+		for i, index := range kept {
+			shifted[i] = index - upTo
+		}
+		a.transactionIndexMap[item] = shifted
+		items = append(items, item)
+	}
+	for item, quantities := range a.quantities {
+		shifted := make(map[int64]float64, len(quantities))
+		for index, quantity := range quantities {
+			if index >= upTo {
+				shifted[index-upTo] = quantity
+			}
+		}
+		a.quantities[item] = shifted
+	}
+	a.items = items
+	a.sortedItems = nil
+	a.transactionNo -= upTo
+	a.negatedIndexMap = nil
+	a.ancestorIndexMap = nil
+
+	if a.weights != nil {
+		a.weights = a.weights[upTo:]
+		a.totalWeight = 0
+		for _, weight := range a.weights {
+			a.totalWeight += weight
+		}
+	}
+}
+
+// Clone returns a deep copy of the Apriori struct, e.g. for what-if experiments adding or removing transactions
+// without touching the original. Structs backed by a TransactionStore can't be cloned.
+func (a *Apriori) Clone() *Apriori {
+	if a.store != nil {
+		panic(errors.New("an Apriori struct backed by a store can't be cloned"))
+	}
+
+	clone := &Apriori{
+		transactionNo:       a.transactionNo,
+		items:               append([]string(nil), a.items...),
+		transactionIndexMap: make(map[interface{}][]int64, len(a.transactionIndexMap)),
+		totalWeight:         a.totalWeight,
+		itemNormalizer:      a.itemNormalizer,
+		transactionPolicy:   a.transactionPolicy,
+		policyStats:         a.policyStats,
+	}
+	for item, indexes := range a.transactionIndexMap {
+		clone.transactionIndexMap[item] = append([]int64(nil), indexes...)
+	}
+	if a.weights != nil {
+		clone.weights = append([]float64(nil), a.weights...)
+	}
+	if a.quantities != nil {
+		clone.quantities = make(map[string]map[int64]float64, len(a.quantities))
+		for item, quantities := range a.quantities {
+			clone.quantities[item] = make(map[int64]float64, len(quantities))
+			for index, quantity := range quantities {
+				clone.quantities[item][index] = quantity
+			}
+		}
+	}
+
+	return clone
+}
+
+// Reset forgets all the transactions, keeping the allocated index map and item list so the struct can be filled
+// again, e.g. by long-running services rebuilding their model periodically. Structs backed by a TransactionStore
+// can't be reset.
+func (a *Apriori) Reset() {
+	if a.store != nil {
+		panic(errors.New("an Apriori struct backed by a store can't be reset"))
+	}
+
+	// The index lists may be shared with previous results, so only the map is kept, not the lists.
+	for item := range a.transactionIndexMap {
+		delete(a.transactionIndexMap, item)
+	}
+	if a.transactionIndexMap == nil {
+		a.transactionIndexMap = make(map[interface{}][]int64)
+	}
+	a.items = a.items[:0]
+	a.sortedItems = nil
+	a.transactionNo = 0
+	a.negatedIndexMap = nil
+	a.ancestorIndexMap = nil
+	a.weights = nil
+	a.totalWeight = 0
+	a.quantities = nil
+	a.policyStats = PolicyStats{}
+}
+
+// Returns a map key for sorted items.
+func itemsetKey(items []string) string {
+	return strings.Join(items, "\x00")
+}
+
+// Adds a transaction, returning the error of the TransactionPolicy or of the store.
+func (a *Apriori) addTransaction(transaction []string) error {
+	transaction, ok, err := a.admitTransaction(a.normalizeTransaction(transaction))
+	if err != nil || !ok {
+		return err
+	}
+
+	return a.indexTransaction(transaction)
+}
+
+// Adds a transaction whose items are already normalized, returning the error of the store.
+func (a *Apriori) indexTransaction(transaction []string) error {
+	if a.store != nil {
+		transaction = a.uniqueItems(transaction)
+		if err := a.store.AddTransaction(transaction); err != nil {
+			return err
+		}
+		for _, item := range transaction {
+			if _, ok := a.storeItems[item]; !ok {
+				a.storeItems[item] = struct{}{}
+				a.addItem(item)
+			}
+		}
+		a.transactionNo++
+		return nil
+	}
+
 	for _, item := range transaction {
 		if _, ok := a.transactionIndexMap[item]; !ok {
-			a.items = append(a.items, item)
+			a.addItem(item)
 			a.transactionIndexMap[item] = []int64{}
 		}
-		a.transactionIndexMap[item] = append(a.transactionIndexMap[item], a.transactionNo)
+		// An item repeated in the transaction is indexed once, see NewAprioriWithMultiplicities to count it.
+		if indexes := a.transactionIndexMap[item]; len(indexes) == 0 || indexes[len(indexes)-1] != a.transactionNo {
+			a.transactionIndexMap[item] = append(indexes, a.transactionNo)
+		}
 	}
 	a.transactionNo++
+
+	return nil
+}
+
+// Adds an item found in the transactions.
+func (a *Apriori) addItem(item string) {
+	a.items = append(a.items, item)
+	a.sortedItems = nil
 }
 
 // Returns a support for items.
@@ -165,79 +724,329 @@ func (a *Apriori) calculateSupport(items []string) float64 {
 		return 0.0
 	}
 
+	// Calculate and return the support.
+	return a.indexesToSupport(items, a.calculateTransactionIndexes(items))
+}
+
+// Returns the support of items contained in the transactions with the given indexes, weighted when the
+// transactions have weights, see minQuantitySupport for the quantities.
+func (a *Apriori) indexesToSupport(items []string, indexes []int64) float64 {
+	if a.quantitySupport == MinQuantitySupport {
+		return a.minQuantitySupport(items, indexes)
+	}
+	if a.weights == nil {
+		return a.countToSupport(int64(len(indexes)))
+	}
+	if a.totalWeight == 0 {
+		return 0.0
+	}
+
+	weight := 0.0
+	for _, index := range indexes {
+		weight += a.weights[index]
+	}
+
+	return weight / a.totalWeight
+}
+
+// Returns the support of items contained in count transactions.
+func (a *Apriori) countToSupport(count int64) float64 {
+	// Empty transactions supports no items.
+	if a.transactionNo == 0 {
+		return 0.0
+	}
+
+	return float64(count) / float64(a.transactionNo)
+}
+
+// Returns the number of transactions that contain all the items.
+func (a *Apriori) calculateSupportCount(items []string) int64 {
+	// Empty items are supported by all transactions.
+	if len(items) == 0 {
+		return a.transactionNo
+	}
+
+	return int64(len(a.calculateTransactionIndexes(items)))
+}
+
+// Returns the indexes of the transactions that contain all the (non empty) items. The result may be shared
+// with the index, it must not be modified.
+func (a *Apriori) calculateTransactionIndexes(items []string) []int64 {
+	return a.intersectItemIndexes(items, a.itemIndexes)
+}
+
+// Returns the intersection of the transaction indexes of the items, as given by itemIndexes.
+func (a *Apriori) intersectItemIndexes(items []string, itemIndexes func(item string) []int64) []int64 {
 	// Create the transaction index intersection.
 	var sumIndexes []int64
-	for _, item := range items {
-		indexes := a.transactionIndexMap[item]
+	for i, item := range items {
+		indexes := itemIndexes(item)
 		// No support for any set that contains a not existing item.
 		if len(indexes) == 0 {
-			return 0.0
+			return nil
 		}
-		if len(sumIndexes) == 0 {
+		if i == 0 {
 			// Assign the indexes on the first time.
 			sumIndexes = indexes
 		} else {
 			// Calculate the intersection on not the first time.
 			sumIndexes = a.transactionIntersection(sumIndexes, indexes)
 		}
+		// No support once the intersection is empty.
+		if len(sumIndexes) == 0 {
+			return nil
+		}
 	}
 
-	// Calculate and return the support.
-	return float64(len(sumIndexes)) / float64(a.transactionNo)
+	return sumIndexes
 }
 
-// Returns the initial candidates.
-func (a *Apriori) initialCandidates() [][]string {
-	var initialCandidates [][]string
+// Returns the all-confidence of the items, given their support.
+func (a *Apriori) calculateAllConfidence(items []string, support float64) float64 {
+	maxItemSupport := 0.0
+	for _, item := range items {
+		if itemSupport := a.calculateSupport([]string{item}); itemSupport > maxItemSupport {
+			maxItemSupport = itemSupport
+		}
+	}
+	if maxItemSupport == 0 {
+		return 0.0
+	}
+
+	return support / maxItemSupport
+}
+
+// Returns the transaction indexes for an item, taxonomy ancestors and negated items included.
+func (a *Apriori) itemIndexes(item string) []int64 {
+	if indexes, ok := a.ancestorIndexMap[item]; ok {
+		return indexes
+	}
+	if indexes := a.storedItemIndexes(item); indexes != nil {
+		return indexes
+	}
+
+	return a.negatedIndexMap[item]
+}
+
+// Returns whether the item is found in the transactions, without scanning the items.
+func (a *Apriori) hasItem(item string) bool {
+	if a.store != nil {
+		_, ok := a.storeItems[item]
+		return ok
+	}
+	_, ok := a.transactionIndexMap[item]
+
+	return ok
+}
+
+// Returns the indexes of the transactions containing the item, from the store when there is one.
+func (a *Apriori) storedItemIndexes(item string) []int64 {
+	if a.store == nil {
+		return a.transactionIndexMap[item]
+	}
+
+	indexes, err := a.store.ItemIndexes(item)
+	if err != nil {
+		if a.storeFailure == nil {
+			panic(err)
+		}
+		a.storeFailure.fail(err)
+	}
+
+	return indexes
+}
+
+// Returns the initial candidates, without the items filtered out by the include and exclude lists
+// and with the negated items and the taxonomy ancestors when they are enabled.
+func (a *Apriori) initialCandidates(options Options) [][]string {
+	included := make(map[string]bool)
+	for _, item := range options.IncludeItems {
+		included[item] = true
+	}
+	excluded := make(map[string]bool)
+	for _, item := range options.ExcludeItems {
+		excluded[item] = true
+	}
+
+	var items []string
 	for _, item := range a.getItems() {
+		if (len(included) > 0 && !included[item]) || excluded[item] {
+			continue
+		}
+		items = append(items, item)
+	}
+
+	if options.NegatedItemsMinSupport > 0 {
+		a.materializeNegatedItems(items, options.NegatedItemsMinSupport)
+		var negatedItems []string
+		for _, item := range items {
+			if _, ok := a.negatedIndexMap[NegatedItemPrefix+item]; ok {
+				negatedItems = append(negatedItems, NegatedItemPrefix+item)
+			}
+		}
+		items = append(items, negatedItems...)
+		sort.Strings(items)
+	}
+
+	a.ancestorIndexMap = nil
+	if len(options.Taxonomy) > 0 {
+		for _, ancestor := range a.materializeAncestors(options.Taxonomy) {
+			if (len(included) == 0 || included[ancestor]) && !excluded[ancestor] {
+				items = append(items, ancestor)
+			}
+		}
+		sort.Strings(items)
+	}
+
+	var initialCandidates [][]string
+	for _, item := range items {
 		initialCandidates = append(initialCandidates, []string{item})
 	}
 
 	return initialCandidates
 }
 
-// Returns the item list that the transaction is consisted of.
+// Builds the transaction indexes of the negated items, for the items with at least the given support.
+func (a *Apriori) materializeNegatedItems(items []string, minSupport float64) {
+	a.negatedIndexMap = make(map[string][]int64)
+	for _, item := range items {
+		indexes := a.storedItemIndexes(item)
+		if a.calculateSupport([]string{item}) < minSupport {
+			continue
+		}
+
+		contains := make(map[int64]bool, len(indexes))
+		for _, index := range indexes {
+			contains[index] = true
+		}
+		negatedIndexes := []int64{}
+		for index := int64(0); index < a.transactionNo; index++ {
+			if !contains[index] {
+				negatedIndexes = append(negatedIndexes, index)
+			}
+		}
+		a.negatedIndexMap[NegatedItemPrefix+item] = negatedIndexes
+	}
+}
+
+// Returns a shallow copy of the struct for a mining run. The indexes of the negated items and of the ancestors
+// depend on the options, and the sorted snapshot of the items is taken lazily, so they are kept in the copy instead
+// of being written to a struct shared by concurrent runs.
+func (a *Apriori) miningView() *Apriori {
+	view := *a
+	view.negatedIndexMap = nil
+	view.ancestorIndexMap = nil
+
+	return &view
+}
+
+// Returns the item list that the transaction is consisted of, sorted. The list is a snapshot shared by the
+// callers, which must not modify it.
 func (a *Apriori) getItems() []string {
-	sort.Strings(a.items)
+	if a.sortedItems == nil && len(a.items) > 0 {
+		a.sortedItems = make([]string, len(a.items))
+		copy(a.sortedItems, a.items)
+		sort.Strings(a.sortedItems)
+	}
 
-	return a.items
+	return a.sortedItems
 }
 
-// Returns a generator of ordered statistics as OrderedStatistic instances.
-func (a *Apriori) generateOrderedStatistics(record SupportRecord) []OrderedStatistic {
+// Returns a generator of ordered statistics as OrderedStatistic instances, the rules whose add has addLength of the
+// items of the record. A record of exactly addLength items gets a single rule with an empty base.
+func (a *Apriori) generateOrderedStatistics(record SupportRecord, addLength int) []OrderedStatistic {
+	// The items of the record may be held by the caller, they are sorted in a copy.
 	items := record.items
-	sort.Strings(items)
+	if !sort.StringsAreSorted(items) {
+		items = make([]string, len(record.items))
+		copy(items, record.items)
+		sort.Strings(items)
+	}
 
-	var ch = make(chan []string)
-	defer close(ch)
-	go combinations(ch, items, len(items)-1)
+	n := len(items)
+	if n < addLength {
+		return nil
+	}
 
-	var orderedStatistics []OrderedStatistic
-	for combination := range ch {
-		if checkIfLastInStringChan(combination) {
-			break
+	// Every rule has a base of all the items but addLength and those items as add, all of them share a single
+	// backing array, each slice capped to its length.
+	baseLength := n - addLength
+	count := binomial(n, baseLength)
+	backing := make([]string, count*n)
+	orderedStatistics := make([]OrderedStatistic, 0, count)
+	combinations(items, baseLength, make([]string, baseLength), func(combination []string) {
+		offset := len(orderedStatistics) * n
+		base := backing[offset : offset+baseLength : offset+baseLength]
+		copy(base, combination)
+		add := backing[offset+baseLength : offset+baseLength : offset+n]
+		for _, item := range items {
+			if !a.inSlice(item, base) {
+				add = append(add, item)
+			}
 		}
-		orderedStatistics = append(orderedStatistics, a.generateOrderedStatistic(combination, items, record.support))
-	}
+		orderedStatistics = append(orderedStatistics, newOrderedStatistic(base, add, record.support, a.calculateSupport(base), a.calculateSupport(add), a.transactionNo))
+	})
 
 	return orderedStatistics
 }
 
 func (a *Apriori) generateOrderedStatistic(base []string, items []string, recordSupport float64) OrderedStatistic {
 	add := a.itemDifference(items, base)
-	supportForBase := a.calculateSupport(base)
-	confidence := recordSupport / supportForBase
-	supportForAdd := a.calculateSupport(add)
-	lift := confidence / supportForAdd
 
-	return OrderedStatistic{base, add, confidence, lift}
+	return newOrderedStatistic(base, add, recordSupport, a.calculateSupport(base), a.calculateSupport(add), a.transactionNo)
+}
+
+// Returns the ordered statistic for the rule base => add given the supports of the whole itemset, of the base
+// and of the add. The counts and the significance statistics need the number of transactions, when it's unknown
+// the counts are 0 and the significance statistics are NaN.
+func newOrderedStatistic(base []string, add []string, recordSupport float64, supportForBase float64, supportForAdd float64, transactionNo int64) OrderedStatistic {
+	orderedStatistic := OrderedStatistic{
+		base:            base,
+		add:             add,
+		confidence:      recordSupport / supportForBase,
+		baseSupport:     supportForBase,
+		addSupport:      supportForAdd,
+		chiSquare:       math.NaN(),
+		chiSquarePValue: math.NaN(),
+		fisherPValue:    math.NaN(),
+	}
+	orderedStatistic.lift = orderedStatistic.confidence / supportForAdd
+
+	if transactionNo > 0 {
+		orderedStatistic.supportCount = int64(math.Round(recordSupport * float64(transactionNo)))
+		orderedStatistic.baseCount = int64(math.Round(supportForBase * float64(transactionNo)))
+		orderedStatistic.addCount = int64(math.Round(supportForAdd * float64(transactionNo)))
+
+		// Contingency table of base and add over all transactions
+		both := orderedStatistic.supportCount
+		baseOnly := orderedStatistic.baseCount - both
+		addOnly := orderedStatistic.addCount - both
+		neither := transactionNo - both - baseOnly - addOnly
+		orderedStatistic.chiSquare, orderedStatistic.chiSquarePValue = chiSquareTest(both, baseOnly, addOnly, neither)
+		orderedStatistic.fisherPValue = fisherExactTest(both, baseOnly, addOnly, neither)
+	}
+
+	return orderedStatistic
 }
 
-// Filter OrderedStatistic objects
-func (a *Apriori) filterOrderedStatistics(orderedStatistics []OrderedStatistic, minConfidence float64, minLift float64) []OrderedStatistic {
+// Filter OrderedStatistic objects, by the thresholds and then by keep when it isn't nil
+func (a *Apriori) filterOrderedStatistics(orderedStatistics []OrderedStatistic, minConfidence float64, minLift float64, keep func(OrderedStatistic) bool, logger Logger) []OrderedStatistic {
 	var filteredOrderedStatistic []OrderedStatistic
 	for _, orderedStatistic := range orderedStatistics {
-		if orderedStatistic.confidence < minConfidence || orderedStatistic.lift < minLift {
+		reason := ""
+		switch {
+		case orderedStatistic.confidence < minConfidence:
+			reason = "confidence"
+		case orderedStatistic.lift < minLift:
+			reason = "lift"
+		case keep != nil && !keep(orderedStatistic):
+			reason = "filter"
+		}
+		if reason != "" {
+			if logger != nil {
+				logger.Debug("rule rejected", "base", orderedStatistic.base, "add", orderedStatistic.add, "reason", reason,
+					"confidence", orderedStatistic.confidence, "lift", orderedStatistic.lift)
+			}
 			continue
 		}
 		filteredOrderedStatistic = append(filteredOrderedStatistic, orderedStatistic)
@@ -247,35 +1056,208 @@ func (a *Apriori) filterOrderedStatistics(orderedStatistics []OrderedStatistic,
 }
 
 // Returns a generator of support records with given transactions.
-func (a *Apriori) generateSupportRecords(supportRecordChan chan SupportRecord, minSupport float64, maxLength int) {
-	// Process
-	candidates := a.initialCandidates()
+func (a *Apriori) generateSupportRecords(ctx context.Context, supportRecordChan chan SupportRecord, options Options) {
+	// Once the context is done the receiver may be gone, so the records are dropped instead of blocking.
+	send := func(record SupportRecord) {
+		select {
+		case supportRecordChan <- record:
+		case <-ctx.Done():
+		}
+	}
+	defer send(SupportRecord{items: []string{}, support: -1})
+	emit := func(record SupportRecord) {
+		if len(record.items) >= options.MinLength && record.support >= options.minSupportFor(len(record.items)) &&
+			!options.aboveMaxSupport(record.support) {
+			options.checkpoint.record(record)
+			send(record)
+		}
+	}
+
+	// When a consequent is set the candidates are built only from the remaining items and every counted
+	// itemset is the candidate plus the consequent, so the support is still anti-monotone over candidates.
+	consequent := options.Consequent
 	var length = 1
+	candidates := a.initialCandidates(options)
+	if records, resumedLength, resumedCandidates, ok := options.checkpoint.resume(); ok {
+		// The records found before the checkpoint are sent again, the run goes on with the saved candidates.
+		for _, record := range records {
+			send(record)
+		}
+		length, candidates = resumedLength, resumedCandidates
+	} else if len(consequent) > 0 {
+		indexes := a.calculateTransactionIndexes(consequent)
+		support := a.indexesToSupport(consequent, indexes)
+		allConfidence := a.calculateAllConfidence(consequent, support)
+		if support < options.minSupportFrom(len(consequent)) || allConfidence < options.MinAllConfidence ||
+			(options.maxLength != 0 && len(consequent) > options.maxLength) {
+			return
+		}
+		emit(a.newSupportRecord(consequent, support, allConfidence, indexes, options))
+
+		var remaining [][]string
+		for _, candidate := range candidates {
+			if !a.inSlice(candidate[0], consequent) {
+				remaining = append(remaining, candidate)
+			}
+		}
+		candidates = remaining
+	}
+
+	// The diffsets count transactions, the weighted ones, e.g. decayed, and the quantities are mined level-wise.
+	if options.Diffsets && a.weights == nil && a.quantitySupport == CountSupport {
+		a.generateDiffsetSupportRecords(ctx, candidates, options, emit)
+		return
+	}
+
+	// With the transaction reduction the items are looked up in indexes restricted to the transactions that can
+	// still support a candidate, see reduceItemIndexes.
+	itemIndexes := a.itemIndexes
+
+	// Process
 	for len(candidates) > 0 {
+		if options.maxLength != 0 && length+len(consequent) > options.maxLength {
+			break
+		}
+		if !options.checkpoint.save(length, candidates) {
+			return
+		}
+		if options.MetricsCollector != nil {
+			options.MetricsCollector.CandidatesCounted(length+len(consequent), len(candidates))
+		}
+		_, levelSpan := options.startSpan(ctx, "apriori.level")
+		levelSpan.SetAttribute("length", int64(length+len(consequent)))
+		levelSpan.SetAttribute("candidates", int64(len(candidates)))
+		options.debug("level started", "length", length+len(consequent), "candidates", len(candidates))
 		var relations [][]string
+		var relationIndexes [][]int64
 		for _, relationCandidate := range candidates {
-			support := a.calculateSupport(relationCandidate)
-			if support < minSupport {
+			if ctx.Err() != nil {
+				levelSpan.End()
+				return
+			}
+			items := a.withConsequent(relationCandidate, consequent)
+			// An item together with its ancestor is supported exactly like the item alone.
+			if len(options.Taxonomy) > 0 && a.containsAncestorPair(items, options.Taxonomy) {
+				continue
+			}
+			if options.DistinctAttributes && a.containsAttributePair(items) {
+				continue
+			}
+			indexes := a.intersectItemIndexes(items, itemIndexes)
+			support := a.indexesToSupport(items, indexes)
+			if support < options.minSupportFrom(len(items)) {
+				options.debug("candidate pruned", "items", items, "reason", "support", "support", support)
+				continue
+			}
+			// The all-confidence is anti-monotone too, so the candidate can be dropped from the next levels.
+			allConfidence := a.calculateAllConfidence(items, support)
+			if allConfidence < options.MinAllConfidence {
+				options.debug("candidate pruned", "items", items, "reason", "all-confidence", "allConfidence", allConfidence)
 				continue
 			}
 			relations = append(relations, relationCandidate)
-			supportRecordChan <- SupportRecord{relationCandidate, support}
+			relationIndexes = append(relationIndexes, indexes)
+			emit(a.newSupportRecord(items, support, allConfidence, indexes, options))
 		}
+		levelSpan.SetAttribute("frequent", int64(len(relations)))
+		options.debug("level finished", "length", length+len(consequent), "frequent", len(relations))
+		levelSpan.End()
 		length++
-		if maxLength != 0 && length > maxLength {
-			break
-		}
 		candidates = a.createNextCandidates(relations, length)
+		if options.ReduceTransactions && len(candidates) > 0 {
+			_, reduceSpan := options.startSpan(ctx, "apriori.reduceTransactions")
+			reduced := a.reduceItemIndexes(relationIndexes, length, append(candidates, consequent), itemIndexes)
+			reduceSpan.End()
+			itemIndexes = func(item string) []int64 { return reduced[item] }
+		}
+	}
+	if ctx.Err() == nil {
+		options.checkpoint.finish()
 	}
-	supportRecordChan <- SupportRecord{[]string{}, -1}
+}
+
+// Returns the indexes of the items of the itemsets, as given by itemIndexes, restricted to the transactions
+// containing at least minRelations of the relations with the given indexes. Every subset of a candidate of the
+// next level, one item shorter, is a relation, so the transactions in fewer relations than the length of the
+// candidates can't support any of them (AprioriTid).
+func (a *Apriori) reduceItemIndexes(relationIndexes [][]int64, minRelations int, itemsets [][]string, itemIndexes func(item string) []int64) map[string][]int64 {
+	counts := make([]int, a.transactionNo)
+	for _, indexes := range relationIndexes {
+		for _, index := range indexes {
+			counts[index]++
+		}
+	}
+
+	reduced := make(map[string][]int64)
+	for _, items := range itemsets {
+		for _, item := range items {
+			if _, ok := reduced[item]; ok {
+				continue
+			}
+			var kept []int64
+			for _, index := range itemIndexes(item) {
+				if counts[index] >= minRelations {
+					kept = append(kept, index)
+				}
+			}
+			reduced[item] = kept
+		}
+	}
+
+	return reduced
+}
+
+// Returns the support record of the items contained in the transactions with the given indexes, keeping
+// a copy of the indexes when the options ask for them.
+func (a *Apriori) newSupportRecord(items []string, support float64, allConfidence float64, indexes []int64, options Options) SupportRecord {
+	record := SupportRecord{items: items, support: support, supportCount: int64(len(indexes)), allConfidence: allConfidence}
+	if options.KeepTransactionIDs {
+		keep := indexes
+		if options.MaxTransactionIDs > 0 && len(keep) > options.MaxTransactionIDs {
+			keep = keep[:options.MaxTransactionIDs]
+		}
+		record.transactionIDs = make([]int64, len(keep))
+		copy(record.transactionIDs, keep)
+	}
+
+	return record
+}
+
+// Returns the sorted union of the candidate and the consequent.
+func (a *Apriori) withConsequent(candidate []string, consequent []string) []string {
+	if len(consequent) == 0 {
+		return candidate
+	}
+
+	items := make([]string, 0, len(candidate)+len(consequent))
+	items = append(items, candidate...)
+	items = append(items, consequent...)
+	sort.Strings(items)
+
+	return items
+}
+
+// Returns a sorted copy of the items without duplicates.
+func (a *Apriori) normalizeItems(items []string) []string {
+	if len(items) == 0 {
+		return nil
+	}
+
+	sorted := make([]string, len(items))
+	copy(sorted, items)
+	sort.Strings(sorted)
+
+	return a.uniqueItems(sorted)
 }
 
 func (a *Apriori) generateRelationRecords(relationRecords chan RelationRecord, supportRecord SupportRecord, minConfidence float64, minLift float64) {
 	// Calculate ordered stats
 	filteredOrderedStatistics := a.filterOrderedStatistics(
-		a.generateOrderedStatistics(supportRecord),
+		a.generateOrderedStatistics(supportRecord, 1),
 		minConfidence,
-		minLift)
+		minLift,
+		nil,
+		nil)
 
 	if len(filteredOrderedStatistics) != 0 {
 		relationRecords <- RelationRecord{supportRecord, filteredOrderedStatistics}
@@ -284,17 +1266,27 @@ func (a *Apriori) generateRelationRecords(relationRecords chan RelationRecord, s
 
 // Returns the Apriori candidates as a list.
 func (a *Apriori) createNextCandidates(prevCandidates [][]string, length int) [][]string {
-	var items []string
-	for _, candidate := range prevCandidates {
-		for _, item := range candidate {
-			items = append(items, item)
+	// Create the temporary candidates by joining the previous candidates that share all their items
+	// but the last one. These will be filtered below.
+	sorted := make([][]string, len(prevCandidates))
+	copy(sorted, prevCandidates)
+	sort.Slice(sorted, func(i, j int) bool { return itemsetKey(sorted[i]) < itemsetKey(sorted[j]) })
+
+	var tmpNextCandidates [][]string
+	prefixLength := length - 2
+	for i, first := range sorted {
+		// The sorted candidates sharing a prefix are next to each other.
+		for _, second := range sorted[i+1:] {
+			if !a.samePrefix(first, second, prefixLength) {
+				break
+			}
+			if first[prefixLength] == second[prefixLength] {
+				continue
+			}
+			candidate := make([]string, 0, length)
+			tmpNextCandidates = append(tmpNextCandidates, append(append(candidate, first...), second[prefixLength]))
 		}
 	}
-	sort.Strings(items)
-	items = a.uniqueItems(items)
-
-	// Create the temporary candidates. These will be filtered below.
-	tmpNextCandidates := a.generateCandidateCombinations(items, length)
 
 	// Return all the candidates if the length of the next candidates is 2
 	// because their subsets are the same as items.
@@ -304,17 +1296,19 @@ func (a *Apriori) createNextCandidates(prevCandidates [][]string, length int) []
 
 	// Filter candidates that all of their subsets are
 	// in the previous candidates.
+	previous := newItemsetTrie(prevCandidates)
+	subset := make([]string, length-1)
 	var nextCandidates [][]string
 	for _, candidate := range tmpNextCandidates {
-		candidateCombinations := a.generateCandidateCombinations(candidate, length-1)
-
-		allAreInPrev := 0
-		for _, candidates := range candidateCombinations {
-			if a.isSubset(candidates, prevCandidates) {
-				allAreInPrev++
+		allAreInPrev := true
+		for skipped := range candidate {
+			subset = append(append(subset[:0], candidate[:skipped]...), candidate[skipped+1:]...)
+			if !previous.contains(subset) {
+				allAreInPrev = false
+				break
 			}
 		}
-		if allAreInPrev == len(candidateCombinations) {
+		if allAreInPrev {
 			nextCandidates = append(nextCandidates, candidate)
 		}
 	}
@@ -322,22 +1316,35 @@ func (a *Apriori) createNextCandidates(prevCandidates [][]string, length int) []
 	return nextCandidates
 }
 
-func (a *Apriori) generateCandidateCombinations(items []string, length int) [][]string {
-	var tmpNextCandidates [][]string
-	if len(items) >= length {
-		var ch = make(chan []string)
-		defer close(ch)
-		go combinations(ch, items, length)
-
-		for candidate := range ch {
-			if checkIfLastInStringChan(candidate) {
-				break
-			}
-			tmpNextCandidates = append(tmpNextCandidates, candidate)
+// Returns whether the first length items of the itemsets are the same.
+func (a *Apriori) samePrefix(first []string, second []string, length int) bool {
+	for i := 0; i < length; i++ {
+		if first[i] != second[i] {
+			return false
 		}
 	}
 
-	return tmpNextCandidates
+	return true
+}
+
+// Returns the combinations of length of the items. They share a single backing array, each one capped to its
+// length so appending to it doesn't overwrite the next one.
+func (a *Apriori) generateCandidateCombinations(items []string, length int) [][]string {
+	if len(items) < length {
+		return nil
+	}
+
+	count := binomial(len(items), length)
+	backing := make([]string, count*length)
+	candidates := make([][]string, 0, count)
+	combinations(items, length, make([]string, length), func(combination []string) {
+		offset := len(candidates) * length
+		candidate := backing[offset : offset+length : offset+length]
+		copy(candidate, combination)
+		candidates = append(candidates, candidate)
+	})
+
+	return candidates
 }
 
 func (a *Apriori) isSubset(needle []string, haystack [][]string) bool {
@@ -400,95 +1407,84 @@ func (a *Apriori) transactionIntersection(first, second []int64) []int64 {
 }
 
 func (a *Apriori) itemDifference(first []string, second []string) []string {
-	var diff []string
-	// Loop two times, first to find first strings not in second,
-	// second loop to find second strings not in first
-	for i := 0; i < 2; i++ {
-		for _, firstString := range first {
-			found := false
-			for _, secondString := range second {
-				if firstString == secondString {
-					found = true
-					break
-				}
-			}
-			// String not found. We add it to return slice
-			if !found {
-				diff = append(diff, firstString)
-			}
+	// Count the strings of each slice not in the other one first, so the difference is allocated once.
+	count := 0
+	for _, item := range first {
+		if !a.inSlice(item, second) {
+			count++
 		}
-		// Swap the slices, only if it was the first loop
-		if i == 0 {
-			first, second = second, first
+	}
+	for _, item := range second {
+		if !a.inSlice(item, first) {
+			count++
 		}
 	}
+	if count == 0 {
+		return nil
+	}
 
-	return diff
-}
-
-func combinations(ch chan []string, iterable []string, r int) {
-	if r != 0 {
-		length := len(iterable)
-
-		if r > length {
-			panic("Invalid arguments")
+	diff := make([]string, 0, count)
+	for _, item := range first {
+		if !a.inSlice(item, second) {
+			diff = append(diff, item)
 		}
-
-		intCh := make(chan []int)
-		defer close(intCh)
-		go genCombinations(intCh, length, r)
-
-		for comb := range intCh {
-			if checkIfLastInIntChan(comb) {
-				break
-			}
-			result := make([]string, r)
-			for i, val := range comb {
-				result[i] = iterable[val]
-			}
-			ch <- result
+	}
+	for _, item := range second {
+		if !a.inSlice(item, first) {
+			diff = append(diff, item)
 		}
-	} else {
-		result := make([]string, r)
-		ch <- result
 	}
-	ch <- []string{combinationStringChannelLastElement}
+
+	return diff
 }
 
-func genCombinations(ch chan []int, n, r int) {
-	result := make([]int, r)
-	for i := range result {
-		result[i] = i
+// Calls visit with every combination of r of the items, in the order of their indexes. The combination is written
+// to buffer, of length r, which is overwritten by the next one, so visit has to copy it to keep it.
+func combinations(items []string, r int, buffer []string, visit func(combination []string)) {
+	n := len(items)
+	if r > n {
+		panic("Invalid arguments")
 	}
 
-	temp := make([]int, r)
-	copy(temp, result) // avoid overwriting of result
-	ch <- temp
+	// The indexes stay on the stack for the usual itemset lengths.
+	var stack [16]int
+	indexes := stack[:0]
+	if r > len(stack) {
+		indexes = make([]int, 0, r)
+	}
+	for i := 0; i < r; i++ {
+		indexes = append(indexes, i)
+		buffer[i] = items[i]
+	}
+	visit(buffer)
 
 	for {
-		for i := r - 1; i >= 0; i-- {
-			if result[i] < i+n-r {
-				result[i]++
-				for j := 1; j < r-i; j++ {
-					result[i+j] = result[i] + j
-				}
-				temp := make([]int, r)
-				copy(temp, result) // avoid overwriting of result
-				ch <- temp
-				break
-			}
+		i := r - 1
+		for i >= 0 && indexes[i] == i+n-r {
+			i--
 		}
-		if result[0] >= n-r {
-			break
+		if i < 0 {
+			return
+		}
+		indexes[i]++
+		buffer[i] = items[indexes[i]]
+		for j := i + 1; j < r; j++ {
+			indexes[j] = indexes[j-1] + 1
+			buffer[j] = items[indexes[j]]
 		}
+		visit(buffer)
 	}
-	ch <- []int{combinationIntChannelLastElement}
 }
 
-func checkIfLastInStringChan(strings []string) bool {
-	return len(strings) > 0 && strings[0] == combinationStringChannelLastElement
-}
+// Returns the number of combinations of r of n items.
+func binomial(n, r int) int {
+	if r > n-r {
+		r = n - r
+	}
+	result := 1
+	for i := 1; i <= r; i++ {
+		result = result * (n - r + i) / i
+	}
 
-func checkIfLastInIntChan(ints []int) bool {
-	return len(ints) > 0 && ints[0] == combinationIntChannelLastElement
+	return result
 }
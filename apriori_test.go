@@ -2,7 +2,11 @@ package apriori
 
 import (
 	"fmt"
+	"math"
+	"math/rand"
 	"os"
+	"strings"
+	"sync"
 	"testing"
 )
 
@@ -39,14 +43,760 @@ func TestApriori_Calculate(t *testing.T) {
 		a := NewApriori(data.in)
 		out := a.Calculate(NewOptions(0.1, 0.5, 0.0, 0))
 
-		assert(data.out == fmt.Sprint(out), "Expected output not equal to actual output")
+		assert(data.out == formatRecords(out), "Expected output not equal to actual output")
 		fmt.Printf("%+v\n", out)
 	}
 }
 
+// Renders the records with the supports, confidences and lifts only, in the layout of fmt.Sprint on the records.
+func formatRecords(records []RelationRecord) string {
+	var formatted []string
+	for _, record := range records {
+		var orderedStatistics []string
+		for _, orderedStatistic := range record.GetOrderedStatistic() {
+			orderedStatistics = append(orderedStatistics, formatOrderedStatistic(orderedStatistic))
+		}
+		supportRecord := record.GetSupportRecord()
+		formatted = append(formatted, fmt.Sprintf("{{%v %v} [%s]}",
+			supportRecord.GetItems(), supportRecord.GetSupport(), strings.Join(orderedStatistics, " ")))
+	}
+
+	return "[" + strings.Join(formatted, " ") + "]"
+}
+
+func formatSupportRecord(record SupportRecord) string {
+	return fmt.Sprintf("{%v %v %v %v}", record.GetItems(), record.GetSupport(), record.GetSupportCount(), record.GetAllConfidence())
+}
+
+func formatOrderedStatistic(statistic OrderedStatistic) string {
+	return fmt.Sprintf("{%v %v %v %v}", statistic.GetBase(), statistic.GetAdd(), statistic.GetConfidence(), statistic.GetLift())
+}
+
 func assert(b bool, s string) {
 	if !b {
 		println(s)
 		os.Exit(1)
 	}
 }
+
+func TestApriori_CalculateWithConsequent(t *testing.T) {
+	transactions := [][]string{
+		{"beer", "nuts", "cheese"},
+		{"beer", "nuts", "jam"},
+		{"beer", "butter"},
+		{"nuts", "cheese"},
+		{"beer", "nuts", "cheese", "jam"},
+		{"butter"},
+		{"beer", "nuts", "jam", "butter"},
+		{"jam"},
+	}
+
+	provider := []struct {
+		consequent []string
+		maxLength  int
+	}{
+		{[]string{"beer"}, 0},
+		{[]string{"jam"}, 3},
+		{[]string{"nuts", "beer"}, 0},
+		{[]string{"wine"}, 0},
+	}
+
+	for _, data := range provider {
+		a := NewApriori(transactions)
+		options := NewOptions(0.1, 0.5, 0.0, data.maxLength)
+		options.Consequent = data.consequent
+		out := a.Calculate(options)
+
+		// Every rule must predict exactly the consequent
+		var expected []string
+		for _, record := range NewApriori(transactions).Calculate(NewOptions(0.1, 0.0, 0.0, data.maxLength)) {
+			items := record.GetSupportRecord().GetItems()
+			base := a.itemDifference(items, a.normalizeItems(data.consequent))
+			if len(base)+len(data.consequent) != len(items) {
+				continue
+			}
+			statistic := a.generateOrderedStatistic(base, items, record.GetSupportRecord().GetSupport())
+			if statistic.GetConfidence() >= 0.5 {
+				expected = append(expected, formatOrderedStatistic(statistic))
+			}
+		}
+
+		var actual []string
+		for _, record := range out {
+			for _, statistic := range record.GetOrderedStatistic() {
+				actual = append(actual, formatOrderedStatistic(statistic))
+			}
+		}
+
+		assert(fmt.Sprint(expected) == fmt.Sprint(actual), "Expected consequent rules not equal to actual rules")
+	}
+}
+
+func TestApriori_CalculateWithIncludeExcludeItems(t *testing.T) {
+	transactions := [][]string{
+		{"beer", "nuts", "cheese", "bag"},
+		{"beer", "nuts", "jam", "bag"},
+		{"beer", "butter"},
+		{"nuts", "cheese", "bag"},
+		{"beer", "nuts", "cheese", "jam"},
+	}
+
+	provider := []struct {
+		include []string
+		exclude []string
+		out     string
+	}{
+		{nil, []string{"bag", "cheese", "jam", "butter"}, "[{{[beer] 0.8} [{[] [beer] 0.8 1}]} {{[nuts] 0.8} [{[] [nuts] 0.8 1}]} {{[beer nuts] 0.6} [{[beer] [nuts] 0.7499999999999999 0.9374999999999998} {[nuts] [beer] 0.7499999999999999 0.9374999999999998}]}]"},
+		{[]string{"beer", "jam", "bag"}, []string{"bag"}, "[{{[beer] 0.8} [{[] [beer] 0.8 1}]} {{[beer jam] 0.4} [{[jam] [beer] 1 1.25}]}]"},
+	}
+
+	for _, data := range provider {
+		options := NewOptions(0.4, 0.6, 0.0, 0)
+		options.IncludeItems = data.include
+		options.ExcludeItems = data.exclude
+		out := NewApriori(transactions).Calculate(options)
+
+		assert(data.out == formatRecords(out), "Expected output not equal to actual output")
+	}
+}
+
+func TestApriori_CalculateWithMinLength(t *testing.T) {
+	transactions := [][]string{
+		{"beer", "nuts", "cheese"},
+		{"beer", "nuts", "jam"},
+		{"beer", "butter"},
+		{"nuts", "cheese"},
+		{"beer", "nuts", "cheese", "jam"},
+		{"butter"},
+		{"beer", "nuts", "jam", "butter"},
+		{"jam"},
+	}
+
+	options := NewOptions(0.3, 0.5, 0.0, 0)
+	options.MinLength = 2
+	out := NewApriori(transactions).Calculate(options)
+
+	assert(formatRecords(out) == "[{{[beer jam] 0.375} [{[beer] [jam] 0.6 1.2} {[jam] [beer] 0.75 1.2}]} {{[beer nuts] 0.5} [{[beer] [nuts] 0.8 1.28} {[nuts] [beer] 0.8 1.28}]} {{[cheese nuts] 0.375} [{[cheese] [nuts] 1 1.6} {[nuts] [cheese] 0.6 1.5999999999999999}]} {{[jam nuts] 0.375} [{[jam] [nuts] 0.75 1.2} {[nuts] [jam] 0.6 1.2}]} {{[beer jam nuts] 0.375} [{[beer jam] [nuts] 1 1.6} {[beer nuts] [jam] 0.75 1.5} {[jam nuts] [beer] 1 1.6}]}]", "Expected output not equal to actual output")
+
+	options.MinLength = 3
+	options.maxLength = 2
+	assert(options.check() != nil, "Expected minimum length greater than maximum length to be rejected")
+}
+
+func TestApriori_CalculateWithNegatedItems(t *testing.T) {
+	transactions := [][]string{
+		{"beer", "nuts"},
+		{"beer", "wine"},
+		{"beer", "nuts"},
+		{"wine", "cheese"},
+		{"beer", "nuts", "cheese"},
+	}
+
+	provider := []struct {
+		minSupport float64
+		consequent []string
+		maxLength  int
+		out        string
+	}{
+		{0.4, []string{NegatedItemPrefix + "wine"}, 0, "[{{[beer ¬wine] 0.6} [{[beer] [¬wine] 0.7499999999999999 1.2499999999999998}]} {{[nuts ¬wine] 0.6} [{[nuts] [¬wine] 1 1.6666666666666667}]} {{[beer nuts ¬wine] 0.6} [{[beer nuts] [¬wine] 1 1.6666666666666667}]} {{[nuts ¬cheese ¬wine] 0.4} [{[nuts ¬cheese] [¬wine] 1 1.6666666666666667}]} {{[beer nuts ¬cheese ¬wine] 0.4} [{[beer nuts ¬cheese] [¬wine] 1 1.6666666666666667}]}]"},
+		// Wine is too rare to get a negated counterpart
+		{0.5, nil, 2, "[{{[beer] 0.8} [{[] [beer] 0.8 1}]} {{[beer nuts] 0.6} [{[beer] [nuts] 0.7499999999999999 1.2499999999999998} {[nuts] [beer] 1 1.25}]} {{[wine ¬nuts] 0.4} [{[wine] [¬nuts] 1 2.5} {[¬nuts] [wine] 1 2.5}]}]"},
+	}
+
+	for _, data := range provider {
+		options := NewOptions(0.4, 0.7, 0.0, data.maxLength)
+		options.NegatedItemsMinSupport = data.minSupport
+		options.Consequent = data.consequent
+		out := NewApriori(transactions).Calculate(options)
+
+		assert(data.out == formatRecords(out), "Expected output not equal to actual output")
+	}
+}
+
+func TestApriori_CalculateWithMinAllConfidence(t *testing.T) {
+	transactions := [][]string{
+		{"beer", "nuts", "cheese"},
+		{"beer", "nuts", "jam"},
+		{"beer", "butter"},
+		{"nuts", "cheese"},
+		{"beer", "nuts", "cheese", "jam"},
+		{"butter"},
+		{"beer", "nuts", "jam", "butter"},
+		{"jam"},
+	}
+
+	options := NewOptions(0.2, 0.0, 0.0, 0)
+	options.MinAllConfidence = 0.6
+	options.MinLength = 2
+	out := NewApriori(transactions).Calculate(options)
+
+	var supportRecords []string
+	for _, record := range out {
+		supportRecords = append(supportRecords, formatSupportRecord(record.GetSupportRecord()))
+	}
+
+	// {beer butter} has an all-confidence of 0.25 / 0.625 = 0.4 and is dropped together with its supersets
+	assert(fmt.Sprint(supportRecords) == "[{[beer jam] 0.375 3 0.6} {[beer nuts] 0.5 4 0.8} {[cheese nuts] 0.375 3 0.6} {[jam nuts] 0.375 3 0.6} {[beer jam nuts] 0.375 3 0.6}]", "Expected support records not equal to actual support records")
+}
+
+func TestApriori_CalculateWithRuleFilter(t *testing.T) {
+	transactions := [][]string{
+		{"beer", "nuts", "cheese"},
+		{"beer", "nuts", "jam"},
+		{"beer", "butter"},
+		{"nuts", "cheese"},
+	}
+
+	options, err := NewOptionsWith(WithMinSupport(0.25), WithRuleFilter(func(orderedStatistic OrderedStatistic) bool {
+		return len(orderedStatistic.GetAdd()) == 1 && orderedStatistic.GetAdd()[0] == "nuts"
+	}))
+	assert(err == nil, "Expected valid options")
+	out := NewApriori(transactions).Calculate(options)
+
+	var rules []string
+	for _, record := range out {
+		for _, orderedStatistic := range record.GetOrderedStatistic() {
+			rules = append(rules, RuleKey(orderedStatistic))
+		}
+	}
+	assert(fmt.Sprint(rules) == `[{} => {"nuts"} {"beer"} => {"nuts"} {"cheese"} => {"nuts"} {"jam"} => {"nuts"} {"beer","cheese"} => {"nuts"} {"beer","jam"} => {"nuts"}]`, "Unexpected rules: "+fmt.Sprint(rules))
+}
+
+func TestApriori_CalculateWithCallbacks(t *testing.T) {
+	transactions := [][]string{
+		{"beer", "nuts", "cheese"},
+		{"beer", "nuts", "jam"},
+		{"beer", "butter"},
+		{"nuts", "cheese"},
+	}
+	a := NewApriori(transactions)
+
+	for _, maxPValue := range []float64{0, 1} {
+		options := NewOptions(0.25, 0.5, 0.0, 0)
+		options.MaxPValue = maxPValue
+		expectedRules := a.Calculate(options)
+		expectedItemsets := a.FrequentItemsets(options)
+
+		var itemsets []SupportRecord
+		var rules []RelationRecord
+		options.OnItemset = func(record SupportRecord) { itemsets = append(itemsets, record) }
+		options.OnRule = func(record RelationRecord) { rules = append(rules, record) }
+		assert(a.Calculate(options) == nil, "Expected the rules to be passed to the callback instead of returned")
+		assert(formatRecords(rules) == formatRecords(expectedRules), "Expected the callback to get the rules")
+		assert(fmt.Sprint(itemsets) == fmt.Sprint(expectedItemsets), "Expected the callback to get the itemsets")
+
+		itemsets = nil
+		assert(a.FrequentItemsets(options) == nil, "Expected the itemsets to be passed to the callback instead of returned")
+		assert(fmt.Sprint(itemsets) == fmt.Sprint(expectedItemsets), "Expected the callback to get the itemsets")
+	}
+}
+
+func TestApriori_CalculateWithRuleShape(t *testing.T) {
+	transactions := [][]string{
+		{"beer", "nuts", "cheese"},
+		{"beer", "nuts", "jam"},
+		{"beer", "nuts", "cheese", "jam"},
+		{"nuts", "cheese"},
+	}
+
+	provider := []struct {
+		maxAntecedentLength int
+		consequentLength    int
+		out                 string
+	}{
+		{1, 0, `[{} => {"beer"} {} => {"cheese"} {} => {"jam"} {} => {"nuts"} {"beer"} => {"cheese"} {"cheese"} => {"beer"} {"beer"} => {"jam"} {"jam"} => {"beer"} {"beer"} => {"nuts"} {"nuts"} => {"beer"} {"cheese"} => {"jam"} {"jam"} => {"cheese"} {"cheese"} => {"nuts"} {"nuts"} => {"cheese"} {"jam"} => {"nuts"} {"nuts"} => {"jam"}]`},
+		{1, 2, `[{} => {"beer","cheese"} {} => {"beer","jam"} {} => {"beer","nuts"} {} => {"cheese","jam"} {} => {"cheese","nuts"} {} => {"jam","nuts"} {"beer"} => {"cheese","jam"} {"cheese"} => {"beer","jam"} {"jam"} => {"beer","cheese"} {"beer"} => {"cheese","nuts"} {"cheese"} => {"beer","nuts"} {"nuts"} => {"beer","cheese"} {"beer"} => {"jam","nuts"} {"jam"} => {"beer","nuts"} {"nuts"} => {"beer","jam"} {"cheese"} => {"jam","nuts"} {"jam"} => {"cheese","nuts"} {"nuts"} => {"cheese","jam"}]`},
+	}
+
+	for _, data := range provider {
+		options, err := NewOptionsWith(WithMinSupport(0.25), WithRuleShape(data.maxAntecedentLength, data.consequentLength))
+		assert(err == nil, "Expected valid options")
+		var rules []string
+		for _, record := range NewApriori(transactions).Calculate(options) {
+			for _, orderedStatistic := range record.GetOrderedStatistic() {
+				rules = append(rules, RuleKey(orderedStatistic))
+			}
+		}
+		assert(fmt.Sprint(rules) == data.out, "Unexpected rules: "+fmt.Sprint(rules))
+	}
+
+	_, err := NewOptionsWith(WithConsequent("beer"), WithRuleShape(0, 2))
+	assert(err != nil, "Expected an error for a consequent length with a consequent")
+}
+
+func TestApriori_CalculateWithNullInvariantMeasures(t *testing.T) {
+	transactions := [][]string{
+		{"beer", "nuts", "cheese"},
+		{"beer", "nuts", "jam"},
+		{"beer", "butter"},
+		{"nuts", "cheese"},
+	}
+	a := NewApriori(transactions)
+
+	beerNuts := a.generateOrderedStatistic([]string{"beer"}, []string{"beer", "nuts"}, 0.5)
+	assert(math.Abs(beerNuts.GetKulczynski()-2.0/3) < confidenceEpsilon && beerNuts.GetImbalanceRatio() == 0, "Unexpected measures of beer => nuts")
+	cheeseNuts := a.generateOrderedStatistic([]string{"cheese"}, []string{"cheese", "nuts"}, 0.5)
+	assert(math.Abs(cheeseNuts.GetKulczynski()-5.0/6) < confidenceEpsilon && math.Abs(cheeseNuts.GetImbalanceRatio()-1.0/3) < confidenceEpsilon, "Unexpected measures of cheese => nuts")
+	unknown := NewOrderedStatistic([]string{"beer"}, []string{"nuts"}, 0.5, 1)
+	assert(math.IsNaN(unknown.GetKulczynski()) && math.IsNaN(unknown.GetImbalanceRatio()), "Expected NaN measures without the supports")
+
+	options, err := NewOptionsWith(WithMinSupport(0.5), WithMinKulczynski(0.7), WithMaxImbalanceRatio(0.5))
+	assert(err == nil, "Expected valid options")
+	var rules []string
+	for _, record := range a.Calculate(options) {
+		for _, orderedStatistic := range record.GetOrderedStatistic() {
+			assert(orderedStatistic.GetKulczynski() >= 0.7 && orderedStatistic.GetImbalanceRatio() <= 0.5, "Expected the rules to pass the thresholds")
+			rules = append(rules, RuleKey(orderedStatistic))
+		}
+	}
+	assert(fmt.Sprint(rules) == `[{} => {"beer"} {} => {"cheese"} {} => {"nuts"} {"cheese"} => {"nuts"} {"nuts"} => {"cheese"}]`, "Unexpected rules: "+fmt.Sprint(rules))
+
+	_, err = NewOptionsWith(WithMaxImbalanceRatio(2))
+	assert(err != nil, "Expected an error for an imbalance ratio above 1")
+}
+
+func TestApriori_CalculateWithReduceTransactions(t *testing.T) {
+	random := rand.New(rand.NewSource(7))
+	items := []string{"beer", "nuts", "cheese", "jam", "wine", "butter", "bread"}
+	var transactions [][]string
+	for i := 0; i < 200; i++ {
+		var transaction []string
+		for _, item := range items {
+			if random.Float64() < 0.45 {
+				transaction = append(transaction, item)
+			}
+		}
+		transactions = append(transactions, transaction)
+	}
+
+	provider := []func(options *Options){
+		func(options *Options) {},
+		func(options *Options) { options.Consequent = []string{"beer"} },
+		func(options *Options) { options.NegatedItemsMinSupport = 0.5 },
+		func(options *Options) { options.Taxonomy = map[string]string{"beer": "drinks", "wine": "drinks"} },
+		func(options *Options) { options.KeepTransactionIDs = true },
+	}
+
+	for _, configure := range provider {
+		options := NewOptions(0.05, 0.0, 0.0, 0)
+		configure(&options)
+		expected := NewApriori(transactions).Calculate(options)
+		options.ReduceTransactions = true
+		out := NewApriori(transactions).Calculate(options)
+
+		assert(formatRecords(expected) == formatRecords(out), "Expected output not equal to actual output")
+		for i := range out {
+			assert(fmt.Sprint(expected[i].GetSupportRecord().GetTransactionIDs()) == fmt.Sprint(out[i].GetSupportRecord().GetTransactionIDs()), "Expected transaction IDs not equal to actual transaction IDs")
+		}
+	}
+}
+
+func TestApriori_FrequentItemsetsWithMinSupportByLength(t *testing.T) {
+	random := rand.New(rand.NewSource(9))
+	items := []string{"beer", "nuts", "cheese", "jam", "wine", "butter", "bread"}
+	var transactions [][]string
+	for i := 0; i < 200; i++ {
+		var transaction []string
+		for _, item := range items {
+			if random.Float64() < 0.45 {
+				transaction = append(transaction, item)
+			}
+		}
+		transactions = append(transactions, transaction)
+	}
+	apriori := NewApriori(transactions)
+
+	minSupports := map[int]float64{1: 0.4, 2: 0.2, 3: 0.05}
+	for _, diffsets := range []bool{false, true} {
+		options, _ := NewOptionsWith(WithMinSupport(0.3), WithMinSupportByLength(minSupports))
+		options.Diffsets = diffsets
+		var expected []string
+		for length := 1; length <= 4; length++ {
+			minSupport, ok := minSupports[length]
+			if !ok {
+				minSupport = 0.3
+			}
+			for _, record := range apriori.FrequentItemsets(NewOptions(minSupport, 0, 0, length)) {
+				if len(record.GetItems()) == length {
+					expected = append(expected, formatSupportRecord(record))
+				}
+			}
+		}
+		var out []string
+		for _, record := range apriori.FrequentItemsets(options) {
+			out = append(out, formatSupportRecord(record))
+		}
+		assert(len(out) > 0 && strings.Join(expected, " ") == strings.Join(out, " "), "Expected the itemsets of every length with its minimum support")
+	}
+}
+
+func TestApriori_FrequentItemsets(t *testing.T) {
+	transactions := [][]string{
+		{"beer", "nuts", "cheese"},
+		{"beer", "nuts", "jam"},
+		{"beer", "butter"},
+		{"nuts", "cheese"},
+		{"beer", "nuts", "cheese", "jam"},
+		{"butter"},
+		{"beer", "nuts", "jam", "butter"},
+		{"jam"},
+	}
+
+	out := NewApriori(transactions).FrequentItemsets(NewOptions(0.3, 0.0, 0.0, 0))
+
+	var supportRecords []string
+	for _, record := range out {
+		supportRecords = append(supportRecords, formatSupportRecord(record))
+	}
+
+	assert(fmt.Sprint(supportRecords) == "[{[beer] 0.625 5 1} {[butter] 0.375 3 1} {[cheese] 0.375 3 1} {[jam] 0.5 4 1} {[nuts] 0.625 5 1} {[beer jam] 0.375 3 0.6} {[beer nuts] 0.5 4 0.8} {[cheese nuts] 0.375 3 0.6} {[jam nuts] 0.375 3 0.6} {[beer jam nuts] 0.375 3 0.6}]", "Expected frequent itemsets not equal to actual frequent itemsets")
+}
+
+func TestGenerateRules(t *testing.T) {
+	transactions := [][]string{
+		{"beer", "nuts", "cheese"},
+		{"beer", "nuts", "jam"},
+		{"beer", "butter"},
+		{"nuts", "cheese"},
+		{"beer", "nuts", "cheese", "jam"},
+		{"butter"},
+		{"beer", "nuts", "jam", "butter"},
+		{"jam"},
+	}
+
+	a := NewApriori(transactions)
+	itemsets := a.FrequentItemsets(NewOptions(0.1, 0.0, 0.0, 0))
+	for _, thresholds := range [][2]float64{{0.5, 0.0}, {0.8, 1.5}} {
+		expected := a.Calculate(NewOptions(0.1, thresholds[0], thresholds[1], 0))
+		actual := GenerateRules(itemsets, thresholds[0], thresholds[1])
+
+		assert(formatRecords(expected) == formatRecords(actual), "Expected rules from itemsets not equal to calculated rules")
+	}
+
+	// Without the supports of the subsets no rule can be derived
+	assert(len(GenerateRules(itemsets[len(itemsets)-1:], 0.0, 0.0)) == 0, "Expected no rules without the subset supports")
+}
+
+func TestNewRelationRecord(t *testing.T) {
+	record := NewRelationRecord(
+		NewSupportRecord([]string{"beer", "nuts"}, 0.5),
+		[]OrderedStatistic{NewOrderedStatistic([]string{"beer"}, []string{"nuts"}, 0.8, 1.28)})
+
+	assert(formatRecords([]RelationRecord{record}) == "[{{[beer nuts] 0.5} [{[beer] [nuts] 0.8 1.28}]}]", "Expected constructed record not equal to actual record")
+	assert(math.IsNaN(record.GetSupportRecord().GetAllConfidence()), "Expected unknown all-confidence to be NaN")
+	assert(math.IsNaN(record.GetOrderedStatistic()[0].GetFisherPValue()), "Expected unknown p-value to be NaN")
+	assert(math.IsNaN(record.GetOrderedStatistic()[0].GetBaseSupport()), "Expected unknown base support to be NaN")
+}
+
+func TestApriori_Accessors(t *testing.T) {
+	a := NewApriori([][]string{
+		{"beer", "nuts", "cheese"},
+		{"beer", "nuts", "jam"},
+		{"beer", "butter"},
+	})
+
+	assert(a.TransactionCount() == 3, "Expected 3 transactions")
+	assert(fmt.Sprint(a.Items()) == "[beer butter cheese jam nuts]", "Expected sorted distinct items")
+	assert(a.ItemFrequency("beer") == 3 && a.ItemFrequency("nuts") == 2 && a.ItemFrequency("wine") == 0, "Unexpected item frequencies")
+}
+
+func TestApriori_DoesNotMutateState(t *testing.T) {
+	a := NewApriori([][]string{
+		{"nuts", "beer"},
+		{"jam", "beer", "nuts"},
+	})
+
+	a.Calculate(NewOptions(0.5, 0.0, 0.0, 0))
+	assert(fmt.Sprint(a.items) == "[nuts beer jam]", "Expected the items to keep the order they were added in")
+	a.Items()[0] = "wine"
+	assert(fmt.Sprint(a.Items()) == "[beer jam nuts]", "Expected Items to return a copy")
+
+	a.RemoveTransactions(1)
+	assert(fmt.Sprint(a.Items()) == "[beer jam nuts]", "Expected the sorted items to follow the removal")
+	a.addTransaction([]string{"cheese"})
+	assert(fmt.Sprint(a.Items()) == "[beer cheese jam nuts]", "Expected the sorted items to follow the new transactions")
+
+	items := []string{"nuts", "beer"}
+	a.generateOrderedStatistics(SupportRecord{items: items, support: 0.5}, 1)
+	assert(fmt.Sprint(items) == "[nuts beer]", "Expected the record items not to be sorted in place")
+}
+
+func TestApriori_CalculateConcurrently(t *testing.T) {
+	a := NewApriori([][]string{
+		{"beer", "nuts", "cheese"},
+		{"beer", "nuts", "jam"},
+		{"beer", "butter"},
+		{"nuts", "cheese"},
+		{"beer", "nuts", "cheese", "jam"},
+		{"butter"},
+	})
+
+	// The runs materialize different negated items and ancestors.
+	optionsList := make([]Options, 3)
+	optionsList[0] = NewOptions(0.2, 0.0, 0.0, 0)
+	optionsList[1] = NewOptions(0.2, 0.0, 0.0, 0)
+	optionsList[1].NegatedItemsMinSupport = 0.3
+	optionsList[2] = NewOptions(0.2, 0.0, 0.0, 0)
+	optionsList[2].Taxonomy = map[string]string{"beer": "drinks", "nuts": "snacks", "cheese": "snacks"}
+	var expected []string
+	for _, options := range optionsList {
+		expected = append(expected, formatRecords(a.Calculate(options)))
+	}
+
+	var wg sync.WaitGroup
+	results := make([]string, 30)
+	for i := range results {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			results[i] = formatRecords(a.Calculate(optionsList[i%len(optionsList)]))
+		}(i)
+	}
+	wg.Wait()
+
+	for i, result := range results {
+		assert(result == expected[i%len(expected)], "Expected the concurrent runs to find the same rules")
+	}
+}
+
+func TestApriori_CalculateSupportCounts(t *testing.T) {
+	transactions := [][]string{
+		{"beer", "nuts", "cheese"},
+		{"beer", "nuts", "jam"},
+		{"beer", "butter"},
+		{"nuts"},
+	}
+
+	a := NewApriori(transactions)
+	out := a.Calculate(NewOptions(0.5, 0.0, 0.0, 2))
+	record := out[len(out)-1]
+	orderedStatistic := record.GetOrderedStatistic()[0]
+
+	assert(formatSupportRecord(record.GetSupportRecord()) == "{[beer nuts] 0.5 2 0.6666666666666666}", "Unexpected support record")
+	assert(orderedStatistic.GetSupportCount() == 2 && orderedStatistic.GetBaseCount() == 3 && orderedStatistic.GetAddCount() == 3, "Unexpected rule counts")
+	assert(orderedStatistic.GetBaseSupport() == 0.75 && orderedStatistic.GetAddSupport() == 0.75, "Unexpected base and add supports")
+
+	// The counts of persisted itemsets give back the transaction count
+	rules := GenerateRules(a.FrequentItemsets(NewOptions(0.5, 0.0, 0.0, 2)), 0.0, 0.0)
+	generated := rules[len(rules)-1].GetOrderedStatistic()[0]
+	assert(fmt.Sprint(generated) == fmt.Sprint(orderedStatistic), "Expected rules from itemsets to have the same counts and statistics")
+}
+
+func TestApriori_CalculateWithTransactionIDs(t *testing.T) {
+	transactions := [][]string{
+		{"beer", "nuts", "cheese"},
+		{"beer", "nuts", "jam"},
+		{"beer", "butter"},
+		{"nuts", "cheese"},
+		{"beer", "nuts", "cheese", "jam"},
+	}
+
+	options := NewOptions(0.4, 0.0, 0.0, 0)
+	options.MinLength = 2
+	options.KeepTransactionIDs = true
+	out := NewApriori(transactions).FrequentItemsets(options)
+
+	var transactionIDs []string
+	for _, record := range out {
+		transactionIDs = append(transactionIDs, fmt.Sprint(record.GetItems(), record.GetTransactionIDs()))
+	}
+	assert(fmt.Sprint(transactionIDs) == "[[beer cheese] [0 4] [beer jam] [1 4] [beer nuts] [0 1 4] [cheese nuts] [0 3 4] [jam nuts] [1 4] [beer cheese nuts] [0 4] [beer jam nuts] [1 4]]", "Unexpected transaction IDs")
+
+	options.MaxTransactionIDs = 1
+	for _, record := range NewApriori(transactions).FrequentItemsets(options) {
+		assert(len(record.GetTransactionIDs()) == 1, "Expected transaction IDs to be capped")
+	}
+
+	options.KeepTransactionIDs = false
+	for _, record := range NewApriori(transactions).FrequentItemsets(options) {
+		assert(record.GetTransactionIDs() == nil, "Expected no transaction IDs by default")
+	}
+}
+
+func TestApriori_RemoveTransactions(t *testing.T) {
+	a := NewApriori([][]string{
+		{"beer", "nuts", "cheese"},
+		{"beer", "nuts", "jam"},
+		{"beer", "butter"},
+	})
+
+	a.RemoveTransactions(2)
+	assert(a.TransactionCount() == 1, "Expected a single transaction left")
+	assert(fmt.Sprint(a.Items()) == "[beer butter]", "Expected the items of the removed transactions to be forgotten")
+
+	a.addTransaction([]string{"beer", "jam"})
+	options := NewOptions(0.5, 0, 0, 0)
+	options.KeepTransactionIDs = true
+	var formatted []string
+	for _, record := range a.FrequentItemsets(options) {
+		formatted = append(formatted, fmt.Sprintf("%v:%v", record.GetItems(), record.GetTransactionIDs()))
+	}
+	assert(fmt.Sprint(formatted) == "[[beer]:[0 1] [butter]:[0] [jam]:[1] [beer butter]:[0] [beer jam]:[1]]", "Unexpected itemsets after removal: "+fmt.Sprint(formatted))
+
+	a.RemoveTransactions(10)
+	assert(a.TransactionCount() == 0 && len(a.Items()) == 0, "Expected all the transactions to be removed")
+}
+
+func TestApriori_Clone(t *testing.T) {
+	a := NewApriori([][]string{
+		{"beer", "nuts"},
+		{"beer", "jam"},
+	})
+	options := NewOptions(0.5, 0, 0, 0)
+	expected := formatRecords(a.Calculate(options))
+
+	clone := a.Clone()
+	clone.AddTransactionsBatch([][]string{{"nuts", "jam"}, {"wine"}}, 1)
+	clone.RemoveTransactions(1)
+	assert(clone.TransactionCount() == 3 && fmt.Sprint(clone.Items()) == "[beer jam nuts wine]", "Expected the clone to change")
+	assert(a.TransactionCount() == 2 && formatRecords(a.Calculate(options)) == expected, "Expected the original not to change")
+}
+
+func TestApriori_Reset(t *testing.T) {
+	a := NewApriori([][]string{
+		{"beer", "nuts"},
+		{"beer", "jam"},
+	})
+
+	a.Reset()
+	assert(a.TransactionCount() == 0 && len(a.Items()) == 0 && a.ItemFrequency("beer") == 0, "Expected all the transactions to be forgotten")
+
+	a.AddTransactionsBatch([][]string{{"wine", "cheese"}, {"wine"}}, 1)
+	assert(formatRecords(a.Calculate(NewOptions(0.5, 0, 0, 0))) == formatRecords(NewApriori([][]string{{"wine", "cheese"}, {"wine"}}).Calculate(NewOptions(0.5, 0, 0, 0))), "Expected the reset struct to mine like a new one")
+}
+
+func TestApriori_createNextCandidates(t *testing.T) {
+	var a Apriori
+	previous := [][]string{{"b", "d"}, {"a", "c"}, {"b", "c"}, {"a", "b"}}
+
+	result := fmt.Sprint(a.createNextCandidates(previous, 3))
+	assert(result == "[[a b c]]", "Expected only the joined candidates with frequent subsets: "+result)
+	result = fmt.Sprint(a.createNextCandidates([][]string{{"b"}, {"a"}, {"c"}}, 2))
+	assert(result == "[[a b] [a c] [b c]]", "Expected all the pairs of items: "+result)
+}
+
+func TestApriori_generateCandidateCombinations(t *testing.T) {
+	var a Apriori
+	// An item named like the former end of channel marker doesn't stop the combinations.
+	combinations := a.generateCandidateCombinations([]string{"a", "STOP", "c", "d"}, 2)
+	assert(fmt.Sprint(combinations) == "[[a STOP] [a c] [a d] [STOP c] [STOP d] [c d]]", "Expected combinations not equal to actual combinations")
+
+	// The combinations share a backing array, appending to one mustn't change the next one.
+	_ = append(combinations[0], "x")
+	assert(fmt.Sprint(combinations[1]) == "[a c]", "Expected the combinations to be independent")
+
+	assert(fmt.Sprint(a.generateCandidateCombinations([]string{"a", "b"}, 0)) == "[[]]", "Expected a single empty combination")
+	assert(a.generateCandidateCombinations([]string{"a"}, 2) == nil, "Expected no combinations longer than the items")
+}
+
+func benchmarkTransactions() [][]string {
+	random := rand.New(rand.NewSource(1))
+	var transactions [][]string
+	for i := 0; i < 2000; i++ {
+		var transaction []string
+		for item := 0; item < 20; item++ {
+			if random.Float64() < 0.3 {
+				transaction = append(transaction, fmt.Sprintf("item%02d", item))
+			}
+		}
+		transactions = append(transactions, transaction)
+	}
+
+	return transactions
+}
+
+func BenchmarkApriori_Calculate(b *testing.B) {
+	transactions := benchmarkTransactions()
+	options := NewOptions(0.02, 0.0, 0.0, 0)
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		NewApriori(transactions).Calculate(options)
+	}
+}
+
+func BenchmarkApriori_generateCandidateCombinations(b *testing.B) {
+	var a Apriori
+	items := []string{"a", "b", "c", "d", "e", "f", "g", "h"}
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		a.generateCandidateCombinations(items, 4)
+	}
+}
+
+func BenchmarkApriori_generateOrderedStatistics(b *testing.B) {
+	a := NewApriori([][]string{{"a", "b", "c", "d", "e"}, {"a", "b", "c"}})
+	record := SupportRecord{items: []string{"a", "b", "c", "d", "e"}, support: 0.5}
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		a.generateOrderedStatistics(record, 1)
+	}
+}
+
+func BenchmarkApriori_itemDifference(b *testing.B) {
+	var a Apriori
+	items := []string{"a", "b", "c", "d", "e", "f"}
+	base := []string{"a", "c", "e"}
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		a.itemDifference(items, base)
+	}
+}
+
+func TestApriori_DuplicateItems(t *testing.T) {
+	transactions := [][]string{
+		{"a", "a", "b"},
+		{"a", "b", "b"},
+	}
+	apriori := NewApriori(transactions)
+	apriori.AddTransaction([]string{"b", "b"})
+	assert(fmt.Sprint(apriori.transactionIndexMap["a"]) == "[0 1]", fmt.Sprint("Expected a to be indexed once per transaction ", apriori.transactionIndexMap["a"]))
+	assert(apriori.ItemFrequency("b") == 3, fmt.Sprint("Unexpected frequency of b ", apriori.ItemFrequency("b")))
+
+	itemsets := apriori.FrequentItemsets(NewOptions(0.5, 0, 0, 0))
+	assert(formatSupportRecord(itemsets[len(itemsets)-1]) == "{[a b] 0.6666666666666666 2 0.6666666666666666}", "Unexpected itemset "+formatSupportRecord(itemsets[len(itemsets)-1]))
+
+	batch := make([][]string, 3*minTransactionsPerWorker)
+	for i := range batch {
+		batch[i] = []string{"a", "b", "a"}
+	}
+	parallel := NewApriori(nil)
+	parallel.AddTransactionsBatch(batch, 3)
+	assert(parallel.ItemFrequency("a") == int64(len(batch)), fmt.Sprint("Expected the batches indexed in parallel to count a once ", parallel.ItemFrequency("a")))
+
+	store, err := NewAprioriFromStore(NewMemoryTransactionStore())
+	assert(err == nil, "Expected the store to be used")
+	store.AddTransaction([]string{"a", "a"})
+	assert(fmt.Sprint(store.Items()) == "[a]" && store.ItemFrequency("a") == 1, "Expected a to be stored once")
+}
+
+func TestApriori_FrequentItemsetsWithMaxSupport(t *testing.T) {
+	transactions := [][]string{
+		{"card", "wire", "night"},
+		{"card", "wire", "night"},
+		{"card"},
+		{"card", "wire"},
+		{"card", "wire"},
+		{"card", "wire"},
+		{"card", "wire"},
+		{"card", "wire"},
+		{"card", "wire"},
+		{"card", "wire"},
+	}
+	options, _ := NewOptionsWith(WithMinSupport(0.1), WithMaxSupport(0.3))
+	var itemsets []string
+	for _, record := range NewApriori(transactions).FrequentItemsets(options) {
+		itemsets = append(itemsets, fmt.Sprint(record.GetItems(), record.GetSupport()))
+	}
+	assert(fmt.Sprint(itemsets) == "[[night] 0.2 [card night] 0.2 [night wire] 0.2 [card night wire] 0.2]", fmt.Sprint("Unexpected rare itemsets ", itemsets))
+
+	options, _ = NewOptionsWith(WithMinSupport(0.1), WithMinConfidence(1), WithMaxSupport(0.3), WithDiffsets())
+	var rules []string
+	for _, record := range NewApriori(transactions).Calculate(options) {
+		for _, statistic := range record.GetOrderedStatistic() {
+			if len(statistic.GetBase()) > 0 {
+				rules = append(rules, statistic.String())
+			}
+		}
+	}
+	assert(len(rules) > 0 && strings.Contains(strings.Join(rules, " "), "{card, night} => {wire} conf=1"), fmt.Sprint("Unexpected rare rules ", rules))
+}
@@ -0,0 +1,12 @@
+module github.com/eMAGTechLabs/go-apriori/aprioribolt
+
+go 1.23.0
+
+require (
+	github.com/eMAGTechLabs/go-apriori v0.0.0
+	go.etcd.io/bbolt v1.4.0
+)
+
+require golang.org/x/sys v0.29.0 // indirect
+
+replace github.com/eMAGTechLabs/go-apriori => ../
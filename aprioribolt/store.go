@@ -0,0 +1,234 @@
+// Package aprioribolt is a TransactionStore backed by a BoltDB file, so the index of tens of millions of
+// transactions can be mined on a single machine without keeping it in memory
+package aprioribolt
+
+import (
+	"container/list"
+	"encoding/binary"
+	"sync"
+
+	bolt "go.etcd.io/bbolt"
+)
+
+var (
+	metaBucket   = []byte("meta")
+	indexBucket  = []byte("index")
+	countsBucket = []byte("counts")
+	countKey     = []byte("count")
+)
+
+// DefaultCacheIndexes is the number of transaction indexes cached in memory by the stores opened with Open, 32 MiB
+const DefaultCacheIndexes = 4 << 20
+
+// Store keeps the index of the transactions in a BoltDB file: a bucket per item holding the indexes of the
+// transactions that contain it, as big endian keys so they are iterated in order, and the number of transactions of
+// every item. The indexes of the items read most recently are cached, mining reads the same items over and over.
+// It is safe for concurrent use.
+type Store struct {
+	db *bolt.DB
+
+	mu        sync.Mutex
+	cache     map[string]*list.Element // Elements of recent, the most recently read item first.
+	recent    *list.List
+	cached    int   // Number of indexes in the cache.
+	maxCached int   // Maximum number of indexes in the cache, 0 to disable it.
+	writes    int64 // Number of AddTransactions calls, the indexes read meanwhile may be outdated.
+}
+
+// Cached indexes of an item.
+type cacheEntry struct {
+	item    string
+	indexes []int64
+}
+
+// Open opens or creates the store at the given path, caching up to DefaultCacheIndexes transaction indexes
+func Open(path string) (*Store, error) {
+	return OpenWithCache(path, DefaultCacheIndexes)
+}
+
+// OpenWithCache opens or creates the store at the given path, caching up to cacheIndexes transaction indexes of the
+// items read most recently, 0 to read them from the file every time
+func OpenWithCache(path string, cacheIndexes int) (*Store, error) {
+	db, err := bolt.Open(path, 0600, nil)
+	if err != nil {
+		return nil, err
+	}
+	err = db.Update(func(tx *bolt.Tx) error {
+		for _, name := range [][]byte{metaBucket, indexBucket, countsBucket} {
+			if _, err := tx.CreateBucketIfNotExists(name); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		db.Close()
+		return nil, err
+	}
+
+	return &Store{db: db, cache: make(map[string]*list.Element), recent: list.New(), maxCached: cacheIndexes}, nil
+}
+
+// Close closes the underlying database
+func (s *Store) Close() error {
+	return s.db.Close()
+}
+
+// AddTransaction appends a transaction to the store
+func (s *Store) AddTransaction(transaction []string) error {
+	return s.AddTransactions([][]string{transaction})
+}
+
+// AddTransactions appends the transactions to the store in a single database transaction, much faster than
+// adding them one by one
+func (s *Store) AddTransactions(transactions [][]string) error {
+	added := make(map[string]bool)
+	err := s.db.Update(func(tx *bolt.Tx) error {
+		meta := tx.Bucket(metaBucket)
+		index := tx.Bucket(indexBucket)
+		counts := tx.Bucket(countsBucket)
+		count := decodeIndex(meta.Get(countKey))
+		itemCounts := make(map[string]int64)
+		for _, transaction := range transactions {
+			for _, item := range transaction {
+				bucket, err := index.CreateBucketIfNotExists([]byte(item))
+				if err != nil {
+					return err
+				}
+				key := encodeIndex(count)
+				if bucket.Get(key) != nil {
+					continue
+				}
+				if err := bucket.Put(key, nil); err != nil {
+					return err
+				}
+				if _, ok := itemCounts[item]; !ok {
+					itemCounts[item] = decodeIndex(counts.Get([]byte(item)))
+				}
+				itemCounts[item]++
+			}
+			count++
+		}
+		for item, itemCount := range itemCounts {
+			if err := counts.Put([]byte(item), encodeIndex(itemCount)); err != nil {
+				return err
+			}
+			added[item] = true
+		}
+
+		return meta.Put(countKey, encodeIndex(count))
+	})
+	if err != nil {
+		return err
+	}
+
+	// The cached indexes of the items of the transactions are outdated.
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.writes++
+	for item := range added {
+		if element, ok := s.cache[item]; ok {
+			s.evict(element)
+		}
+	}
+
+	return nil
+}
+
+// TransactionCount returns the number of transactions in the store
+func (s *Store) TransactionCount() (int64, error) {
+	var count int64
+	err := s.db.View(func(tx *bolt.Tx) error {
+		count = decodeIndex(tx.Bucket(metaBucket).Get(countKey))
+		return nil
+	})
+
+	return count, err
+}
+
+// Items returns the distinct items of the transactions
+func (s *Store) Items() ([]string, error) {
+	var items []string
+	err := s.db.View(func(tx *bolt.Tx) error {
+		return tx.Bucket(indexBucket).ForEach(func(k, _ []byte) error {
+			items = append(items, string(k))
+			return nil
+		})
+	})
+
+	return items, err
+}
+
+// ItemIndexes returns the sorted indexes of the transactions containing the item, nil for an unknown item. The
+// indexes may be cached and must not be modified.
+func (s *Store) ItemIndexes(item string) ([]int64, error) {
+	s.mu.Lock()
+	if element, ok := s.cache[item]; ok {
+		s.recent.MoveToFront(element)
+		s.mu.Unlock()
+		return element.Value.(*cacheEntry).indexes, nil
+	}
+	writes := s.writes
+	s.mu.Unlock()
+
+	var indexes []int64
+	err := s.db.View(func(tx *bolt.Tx) error {
+		bucket := tx.Bucket(indexBucket).Bucket([]byte(item))
+		if bucket == nil {
+			return nil
+		}
+		// The stores written before the counts were kept have none, the list then grows as it's read.
+		indexes = make([]int64, 0, decodeIndex(tx.Bucket(countsBucket).Get([]byte(item))))
+		return bucket.ForEach(func(k, _ []byte) error {
+			indexes = append(indexes, decodeIndex(k))
+			return nil
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+	s.cacheIndexes(item, indexes, writes)
+
+	return indexes, nil
+}
+
+// Caches the indexes of an item read after the given number of writes, evicting the items read least recently to
+// make room.
+func (s *Store) cacheIndexes(item string, indexes []int64, writes int64) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if writes != s.writes || len(indexes) > s.maxCached {
+		return
+	}
+	if element, ok := s.cache[item]; ok {
+		s.evict(element)
+	}
+	for s.cached+len(indexes) > s.maxCached {
+		s.evict(s.recent.Back())
+	}
+	s.cache[item] = s.recent.PushFront(&cacheEntry{item, indexes})
+	s.cached += len(indexes)
+}
+
+// Removes an item from the cache.
+func (s *Store) evict(element *list.Element) {
+	entry := s.recent.Remove(element).(*cacheEntry)
+	delete(s.cache, entry.item)
+	s.cached -= len(entry.indexes)
+}
+
+func encodeIndex(index int64) []byte {
+	key := make([]byte, 8)
+	binary.BigEndian.PutUint64(key, uint64(index))
+
+	return key
+}
+
+func decodeIndex(key []byte) int64 {
+	if len(key) != 8 {
+		return 0
+	}
+
+	return int64(binary.BigEndian.Uint64(key))
+}
@@ -0,0 +1,86 @@
+package aprioribolt
+
+import (
+	"path/filepath"
+	"sync"
+	"testing"
+
+	"github.com/eMAGTechLabs/go-apriori"
+)
+
+func TestStore(t *testing.T) {
+	store, err := Open(filepath.Join(t.TempDir(), "transactions.db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer store.Close()
+
+	transactions := [][]string{
+		{"beer", "nuts", "cheese"},
+		{"beer", "nuts", "jam"},
+		{"beer", "butter"},
+		{"nuts", "cheese"},
+	}
+	if err := store.AddTransactions(transactions); err != nil {
+		t.Fatal(err)
+	}
+
+	indexes, err := store.ItemIndexes("nuts")
+	if err != nil || len(indexes) != 3 || indexes[2] != 3 {
+		t.Fatalf("unexpected indexes %v (%v)", indexes, err)
+	}
+
+	a, err := apriori.NewAprioriFromStore(store)
+	if err != nil {
+		t.Fatal(err)
+	}
+	records := a.Calculate(apriori.NewOptions(0.5, 0.5, 0, 0))
+	expected := apriori.NewApriori(transactions).Calculate(apriori.NewOptions(0.5, 0.5, 0, 0))
+	if len(records) != len(expected) || len(records) == 0 {
+		t.Fatalf("expected %d records, got %d", len(expected), len(records))
+	}
+	for i := range records {
+		if records[i].GetSupportRecord().GetSupportCount() != expected[i].GetSupportRecord().GetSupportCount() {
+			t.Fatalf("unexpected record %v", records[i].GetSupportRecord().GetItems())
+		}
+	}
+}
+
+func TestStore_cache(t *testing.T) {
+	for _, cacheIndexes := range []int{0, 2, DefaultCacheIndexes} {
+		store, err := OpenWithCache(filepath.Join(t.TempDir(), "transactions.db"), cacheIndexes)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if err := store.AddTransactions([][]string{{"beer", "nuts", "beer"}, {"beer"}}); err != nil {
+			t.Fatal(err)
+		}
+		for i := 0; i < 2; i++ {
+			if indexes, err := store.ItemIndexes("beer"); err != nil || len(indexes) != 2 {
+				t.Fatalf("unexpected indexes %v (%v) with a cache of %d", indexes, err, cacheIndexes)
+			}
+		}
+		if err := store.AddTransaction([]string{"beer", "jam"}); err != nil {
+			t.Fatal(err)
+		}
+		if indexes, err := store.ItemIndexes("beer"); err != nil || len(indexes) != 3 || indexes[2] != 2 {
+			t.Fatalf("expected the cached indexes to be refreshed, got %v (%v) with a cache of %d", indexes, err, cacheIndexes)
+		}
+
+		// Run with -race: the cache is shared by the readers.
+		var wg sync.WaitGroup
+		for _, item := range []string{"beer", "nuts", "jam", "beer"} {
+			wg.Add(1)
+			go func(item string) {
+				defer wg.Done()
+				_, _ = store.ItemIndexes(item)
+			}(item)
+		}
+		wg.Wait()
+		if store.cached > cacheIndexes {
+			t.Fatalf("expected at most %d cached indexes, got %d", cacheIndexes, store.cached)
+		}
+		store.Close()
+	}
+}
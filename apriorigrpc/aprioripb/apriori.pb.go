@@ -0,0 +1,499 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.11
+// 	protoc        (unknown)
+// source: apriori.proto
+
+package aprioripb
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type Transaction struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Items         []string               `protobuf:"bytes,1,rep,name=items,proto3" json:"items,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Transaction) Reset() {
+	*x = Transaction{}
+	mi := &file_apriori_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Transaction) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Transaction) ProtoMessage() {}
+
+func (x *Transaction) ProtoReflect() protoreflect.Message {
+	mi := &file_apriori_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Transaction.ProtoReflect.Descriptor instead.
+func (*Transaction) Descriptor() ([]byte, []int) {
+	return file_apriori_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Transaction) GetItems() []string {
+	if x != nil {
+		return x.Items
+	}
+	return nil
+}
+
+type Options struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	MinSupport    float64                `protobuf:"fixed64,1,opt,name=min_support,json=minSupport,proto3" json:"min_support,omitempty"`
+	MinConfidence float64                `protobuf:"fixed64,2,opt,name=min_confidence,json=minConfidence,proto3" json:"min_confidence,omitempty"`
+	MinLift       float64                `protobuf:"fixed64,3,opt,name=min_lift,json=minLift,proto3" json:"min_lift,omitempty"`
+	MaxLength     int32                  `protobuf:"varint,4,opt,name=max_length,json=maxLength,proto3" json:"max_length,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Options) Reset() {
+	*x = Options{}
+	mi := &file_apriori_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Options) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Options) ProtoMessage() {}
+
+func (x *Options) ProtoReflect() protoreflect.Message {
+	mi := &file_apriori_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Options.ProtoReflect.Descriptor instead.
+func (*Options) Descriptor() ([]byte, []int) {
+	return file_apriori_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *Options) GetMinSupport() float64 {
+	if x != nil {
+		return x.MinSupport
+	}
+	return 0
+}
+
+func (x *Options) GetMinConfidence() float64 {
+	if x != nil {
+		return x.MinConfidence
+	}
+	return 0
+}
+
+func (x *Options) GetMinLift() float64 {
+	if x != nil {
+		return x.MinLift
+	}
+	return 0
+}
+
+func (x *Options) GetMaxLength() int32 {
+	if x != nil {
+		return x.MaxLength
+	}
+	return 0
+}
+
+type SupportRecord struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Items         []string               `protobuf:"bytes,1,rep,name=items,proto3" json:"items,omitempty"`
+	Support       float64                `protobuf:"fixed64,2,opt,name=support,proto3" json:"support,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SupportRecord) Reset() {
+	*x = SupportRecord{}
+	mi := &file_apriori_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SupportRecord) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SupportRecord) ProtoMessage() {}
+
+func (x *SupportRecord) ProtoReflect() protoreflect.Message {
+	mi := &file_apriori_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SupportRecord.ProtoReflect.Descriptor instead.
+func (*SupportRecord) Descriptor() ([]byte, []int) {
+	return file_apriori_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *SupportRecord) GetItems() []string {
+	if x != nil {
+		return x.Items
+	}
+	return nil
+}
+
+func (x *SupportRecord) GetSupport() float64 {
+	if x != nil {
+		return x.Support
+	}
+	return 0
+}
+
+type OrderedStatistic struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Base          []string               `protobuf:"bytes,1,rep,name=base,proto3" json:"base,omitempty"`
+	Add           []string               `protobuf:"bytes,2,rep,name=add,proto3" json:"add,omitempty"`
+	Confidence    float64                `protobuf:"fixed64,3,opt,name=confidence,proto3" json:"confidence,omitempty"`
+	Lift          float64                `protobuf:"fixed64,4,opt,name=lift,proto3" json:"lift,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *OrderedStatistic) Reset() {
+	*x = OrderedStatistic{}
+	mi := &file_apriori_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *OrderedStatistic) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OrderedStatistic) ProtoMessage() {}
+
+func (x *OrderedStatistic) ProtoReflect() protoreflect.Message {
+	mi := &file_apriori_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OrderedStatistic.ProtoReflect.Descriptor instead.
+func (*OrderedStatistic) Descriptor() ([]byte, []int) {
+	return file_apriori_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *OrderedStatistic) GetBase() []string {
+	if x != nil {
+		return x.Base
+	}
+	return nil
+}
+
+func (x *OrderedStatistic) GetAdd() []string {
+	if x != nil {
+		return x.Add
+	}
+	return nil
+}
+
+func (x *OrderedStatistic) GetConfidence() float64 {
+	if x != nil {
+		return x.Confidence
+	}
+	return 0
+}
+
+func (x *OrderedStatistic) GetLift() float64 {
+	if x != nil {
+		return x.Lift
+	}
+	return 0
+}
+
+type RelationRecord struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	SupportRecord     *SupportRecord         `protobuf:"bytes,1,opt,name=support_record,json=supportRecord,proto3" json:"support_record,omitempty"`
+	OrderedStatistics []*OrderedStatistic    `protobuf:"bytes,2,rep,name=ordered_statistics,json=orderedStatistics,proto3" json:"ordered_statistics,omitempty"`
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *RelationRecord) Reset() {
+	*x = RelationRecord{}
+	mi := &file_apriori_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RelationRecord) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RelationRecord) ProtoMessage() {}
+
+func (x *RelationRecord) ProtoReflect() protoreflect.Message {
+	mi := &file_apriori_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RelationRecord.ProtoReflect.Descriptor instead.
+func (*RelationRecord) Descriptor() ([]byte, []int) {
+	return file_apriori_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *RelationRecord) GetSupportRecord() *SupportRecord {
+	if x != nil {
+		return x.SupportRecord
+	}
+	return nil
+}
+
+func (x *RelationRecord) GetOrderedStatistics() []*OrderedStatistic {
+	if x != nil {
+		return x.OrderedStatistics
+	}
+	return nil
+}
+
+type CalculateRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Transactions  []*Transaction         `protobuf:"bytes,1,rep,name=transactions,proto3" json:"transactions,omitempty"`
+	Options       *Options               `protobuf:"bytes,2,opt,name=options,proto3" json:"options,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CalculateRequest) Reset() {
+	*x = CalculateRequest{}
+	mi := &file_apriori_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CalculateRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CalculateRequest) ProtoMessage() {}
+
+func (x *CalculateRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_apriori_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CalculateRequest.ProtoReflect.Descriptor instead.
+func (*CalculateRequest) Descriptor() ([]byte, []int) {
+	return file_apriori_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *CalculateRequest) GetTransactions() []*Transaction {
+	if x != nil {
+		return x.Transactions
+	}
+	return nil
+}
+
+func (x *CalculateRequest) GetOptions() *Options {
+	if x != nil {
+		return x.Options
+	}
+	return nil
+}
+
+type CalculateResponse struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	RelationRecords []*RelationRecord      `protobuf:"bytes,1,rep,name=relation_records,json=relationRecords,proto3" json:"relation_records,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *CalculateResponse) Reset() {
+	*x = CalculateResponse{}
+	mi := &file_apriori_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CalculateResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CalculateResponse) ProtoMessage() {}
+
+func (x *CalculateResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_apriori_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CalculateResponse.ProtoReflect.Descriptor instead.
+func (*CalculateResponse) Descriptor() ([]byte, []int) {
+	return file_apriori_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *CalculateResponse) GetRelationRecords() []*RelationRecord {
+	if x != nil {
+		return x.RelationRecords
+	}
+	return nil
+}
+
+var File_apriori_proto protoreflect.FileDescriptor
+
+const file_apriori_proto_rawDesc = "" +
+	"\n" +
+	"\rapriori.proto\x12\n" +
+	"apriori.v1\"#\n" +
+	"\vTransaction\x12\x14\n" +
+	"\x05items\x18\x01 \x03(\tR\x05items\"\x8b\x01\n" +
+	"\aOptions\x12\x1f\n" +
+	"\vmin_support\x18\x01 \x01(\x01R\n" +
+	"minSupport\x12%\n" +
+	"\x0emin_confidence\x18\x02 \x01(\x01R\rminConfidence\x12\x19\n" +
+	"\bmin_lift\x18\x03 \x01(\x01R\aminLift\x12\x1d\n" +
+	"\n" +
+	"max_length\x18\x04 \x01(\x05R\tmaxLength\"?\n" +
+	"\rSupportRecord\x12\x14\n" +
+	"\x05items\x18\x01 \x03(\tR\x05items\x12\x18\n" +
+	"\asupport\x18\x02 \x01(\x01R\asupport\"l\n" +
+	"\x10OrderedStatistic\x12\x12\n" +
+	"\x04base\x18\x01 \x03(\tR\x04base\x12\x10\n" +
+	"\x03add\x18\x02 \x03(\tR\x03add\x12\x1e\n" +
+	"\n" +
+	"confidence\x18\x03 \x01(\x01R\n" +
+	"confidence\x12\x12\n" +
+	"\x04lift\x18\x04 \x01(\x01R\x04lift\"\x9f\x01\n" +
+	"\x0eRelationRecord\x12@\n" +
+	"\x0esupport_record\x18\x01 \x01(\v2\x19.apriori.v1.SupportRecordR\rsupportRecord\x12K\n" +
+	"\x12ordered_statistics\x18\x02 \x03(\v2\x1c.apriori.v1.OrderedStatisticR\x11orderedStatistics\"~\n" +
+	"\x10CalculateRequest\x12;\n" +
+	"\ftransactions\x18\x01 \x03(\v2\x17.apriori.v1.TransactionR\ftransactions\x12-\n" +
+	"\aoptions\x18\x02 \x01(\v2\x13.apriori.v1.OptionsR\aoptions\"Z\n" +
+	"\x11CalculateResponse\x12E\n" +
+	"\x10relation_records\x18\x01 \x03(\v2\x1a.apriori.v1.RelationRecordR\x0frelationRecords2S\n" +
+	"\aApriori\x12H\n" +
+	"\tCalculate\x12\x1c.apriori.v1.CalculateRequest\x1a\x1d.apriori.v1.CalculateResponseB:Z8github.com/eMAGTechLabs/go-apriori/apriorigrpc/aprioripbb\x06proto3"
+
+var (
+	file_apriori_proto_rawDescOnce sync.Once
+	file_apriori_proto_rawDescData []byte
+)
+
+func file_apriori_proto_rawDescGZIP() []byte {
+	file_apriori_proto_rawDescOnce.Do(func() {
+		file_apriori_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_apriori_proto_rawDesc), len(file_apriori_proto_rawDesc)))
+	})
+	return file_apriori_proto_rawDescData
+}
+
+var file_apriori_proto_msgTypes = make([]protoimpl.MessageInfo, 7)
+var file_apriori_proto_goTypes = []any{
+	(*Transaction)(nil),       // 0: apriori.v1.Transaction
+	(*Options)(nil),           // 1: apriori.v1.Options
+	(*SupportRecord)(nil),     // 2: apriori.v1.SupportRecord
+	(*OrderedStatistic)(nil),  // 3: apriori.v1.OrderedStatistic
+	(*RelationRecord)(nil),    // 4: apriori.v1.RelationRecord
+	(*CalculateRequest)(nil),  // 5: apriori.v1.CalculateRequest
+	(*CalculateResponse)(nil), // 6: apriori.v1.CalculateResponse
+}
+var file_apriori_proto_depIdxs = []int32{
+	2, // 0: apriori.v1.RelationRecord.support_record:type_name -> apriori.v1.SupportRecord
+	3, // 1: apriori.v1.RelationRecord.ordered_statistics:type_name -> apriori.v1.OrderedStatistic
+	0, // 2: apriori.v1.CalculateRequest.transactions:type_name -> apriori.v1.Transaction
+	1, // 3: apriori.v1.CalculateRequest.options:type_name -> apriori.v1.Options
+	4, // 4: apriori.v1.CalculateResponse.relation_records:type_name -> apriori.v1.RelationRecord
+	5, // 5: apriori.v1.Apriori.Calculate:input_type -> apriori.v1.CalculateRequest
+	6, // 6: apriori.v1.Apriori.Calculate:output_type -> apriori.v1.CalculateResponse
+	6, // [6:7] is the sub-list for method output_type
+	5, // [5:6] is the sub-list for method input_type
+	5, // [5:5] is the sub-list for extension type_name
+	5, // [5:5] is the sub-list for extension extendee
+	0, // [0:5] is the sub-list for field type_name
+}
+
+func init() { file_apriori_proto_init() }
+func file_apriori_proto_init() {
+	if File_apriori_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_apriori_proto_rawDesc), len(file_apriori_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   7,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_apriori_proto_goTypes,
+		DependencyIndexes: file_apriori_proto_depIdxs,
+		MessageInfos:      file_apriori_proto_msgTypes,
+	}.Build()
+	File_apriori_proto = out.File
+	file_apriori_proto_goTypes = nil
+	file_apriori_proto_depIdxs = nil
+}
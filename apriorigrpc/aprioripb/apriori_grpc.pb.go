@@ -0,0 +1,121 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.6.2
+// - protoc             (unknown)
+// source: apriori.proto
+
+package aprioripb
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	Apriori_Calculate_FullMethodName = "/apriori.v1.Apriori/Calculate"
+)
+
+// AprioriClient is the client API for Apriori service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type AprioriClient interface {
+	Calculate(ctx context.Context, in *CalculateRequest, opts ...grpc.CallOption) (*CalculateResponse, error)
+}
+
+type aprioriClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewAprioriClient(cc grpc.ClientConnInterface) AprioriClient {
+	return &aprioriClient{cc}
+}
+
+func (c *aprioriClient) Calculate(ctx context.Context, in *CalculateRequest, opts ...grpc.CallOption) (*CalculateResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CalculateResponse)
+	err := c.cc.Invoke(ctx, Apriori_Calculate_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// AprioriServer is the server API for Apriori service.
+// All implementations must embed UnimplementedAprioriServer
+// for forward compatibility.
+type AprioriServer interface {
+	Calculate(context.Context, *CalculateRequest) (*CalculateResponse, error)
+	mustEmbedUnimplementedAprioriServer()
+}
+
+// UnimplementedAprioriServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedAprioriServer struct{}
+
+func (UnimplementedAprioriServer) Calculate(context.Context, *CalculateRequest) (*CalculateResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method Calculate not implemented")
+}
+func (UnimplementedAprioriServer) mustEmbedUnimplementedAprioriServer() {}
+func (UnimplementedAprioriServer) testEmbeddedByValue()                 {}
+
+// UnsafeAprioriServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to AprioriServer will
+// result in compilation errors.
+type UnsafeAprioriServer interface {
+	mustEmbedUnimplementedAprioriServer()
+}
+
+func RegisterAprioriServer(s grpc.ServiceRegistrar, srv AprioriServer) {
+	// If the following call panics, it indicates UnimplementedAprioriServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&Apriori_ServiceDesc, srv)
+}
+
+func _Apriori_Calculate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CalculateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AprioriServer).Calculate(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Apriori_Calculate_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AprioriServer).Calculate(ctx, req.(*CalculateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// Apriori_ServiceDesc is the grpc.ServiceDesc for Apriori service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var Apriori_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "apriori.v1.Apriori",
+	HandlerType: (*AprioriServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Calculate",
+			Handler:    _Apriori_Calculate_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "apriori.proto",
+}
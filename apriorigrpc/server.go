@@ -0,0 +1,74 @@
+// Package apriorigrpc is a reference gRPC server for the apriori algorithm, see aprioripb/apriori.proto for the schema
+package apriorigrpc
+
+import (
+	"context"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/eMAGTechLabs/go-apriori"
+	"github.com/eMAGTechLabs/go-apriori/apriorigrpc/aprioripb"
+)
+
+// Server implements the Apriori gRPC service
+type Server struct {
+	aprioripb.UnimplementedAprioriServer
+}
+
+// NewServer is a quick way to create a Server that can be registered with aprioripb.RegisterAprioriServer
+func NewServer() *Server {
+	return &Server{}
+}
+
+// Calculate runs the algorithm over the request transactions and returns the relation records
+func (s *Server) Calculate(ctx context.Context, req *aprioripb.CalculateRequest) (*aprioripb.CalculateResponse, error) {
+	options := apriori.NewOptions(
+		req.GetOptions().GetMinSupport(),
+		req.GetOptions().GetMinConfidence(),
+		req.GetOptions().GetMinLift(),
+		int(req.GetOptions().GetMaxLength()))
+	if err := options.Validate(); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	transactions := make([][]string, 0, len(req.GetTransactions()))
+	for _, transaction := range req.GetTransactions() {
+		transactions = append(transactions, transaction.GetItems())
+	}
+
+	// Mining stops as soon as the client cancels the call or its deadline expires.
+	records, err := apriori.NewApriori(transactions).CalculateContext(ctx, options)
+	if err != nil {
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			return nil, status.FromContextError(ctxErr).Err()
+		}
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	return &aprioripb.CalculateResponse{RelationRecords: toRelationRecords(records)}, nil
+}
+
+func toRelationRecords(records []apriori.RelationRecord) []*aprioripb.RelationRecord {
+	relationRecords := make([]*aprioripb.RelationRecord, 0, len(records))
+	for _, record := range records {
+		var orderedStatistics []*aprioripb.OrderedStatistic
+		for _, statistic := range record.GetOrderedStatistic() {
+			orderedStatistics = append(orderedStatistics, &aprioripb.OrderedStatistic{
+				Base:       statistic.GetBase(),
+				Add:        statistic.GetAdd(),
+				Confidence: statistic.GetConfidence(),
+				Lift:       statistic.GetLift(),
+			})
+		}
+		relationRecords = append(relationRecords, &aprioripb.RelationRecord{
+			SupportRecord: &aprioripb.SupportRecord{
+				Items:   record.GetSupportRecord().GetItems(),
+				Support: record.GetSupportRecord().GetSupport(),
+			},
+			OrderedStatistics: orderedStatistics,
+		})
+	}
+
+	return relationRecords
+}
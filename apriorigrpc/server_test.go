@@ -0,0 +1,74 @@
+package apriorigrpc
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+
+	"github.com/eMAGTechLabs/go-apriori/apriorigrpc/aprioripb"
+)
+
+func TestServer_Calculate(t *testing.T) {
+	listener := bufconn.Listen(1 << 20)
+	server := grpc.NewServer()
+	aprioripb.RegisterAprioriServer(server, NewServer())
+	go server.Serve(listener)
+	defer server.Stop()
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return listener.DialContext(ctx) }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+	client := aprioripb.NewAprioriClient(conn)
+
+	_, err = client.Calculate(context.Background(), &aprioripb.CalculateRequest{Options: &aprioripb.Options{}})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Fatalf("expected invalid argument for zero support, got %v", err)
+	}
+
+	resp, err := client.Calculate(context.Background(), &aprioripb.CalculateRequest{
+		Transactions: []*aprioripb.Transaction{
+			{Items: []string{"beer", "nuts"}},
+			{Items: []string{"beer", "nuts", "jam"}},
+			{Items: []string{"beer"}},
+			{Items: []string{"jam"}},
+		},
+		Options: &aprioripb.Options{MinSupport: 0.5, MinConfidence: 0.6},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var found bool
+	for _, record := range resp.GetRelationRecords() {
+		for _, statistic := range record.GetOrderedStatistics() {
+			if len(statistic.GetBase()) == 1 && statistic.GetBase()[0] == "nuts" && statistic.GetAdd()[0] == "beer" {
+				found = statistic.GetConfidence() == 1 && record.GetSupportRecord().GetSupport() == 0.5
+			}
+		}
+	}
+	if !found {
+		t.Fatalf("expected rule nuts => beer, got %v", resp.GetRelationRecords())
+	}
+}
+
+func TestServer_Calculate_canceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err := NewServer().Calculate(ctx, &aprioripb.CalculateRequest{
+		Transactions: []*aprioripb.Transaction{{Items: []string{"beer", "nuts"}}},
+		Options:      &aprioripb.Options{MinSupport: 0.5},
+	})
+	if status.Code(err) != codes.Canceled {
+		t.Fatalf("expected the canceled call to stop mining, got %v", err)
+	}
+}
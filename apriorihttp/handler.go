@@ -0,0 +1,277 @@
+// Package apriorihttp exposes the apriori algorithm through an http.Handler so it can be embedded into services
+package apriorihttp
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"sort"
+	"sync"
+
+	"github.com/eMAGTechLabs/go-apriori"
+)
+
+// OptionsRequest is the body accepted by the mine endpoint
+type OptionsRequest struct {
+	MinSupport    float64 `json:"minSupport"`
+	MinConfidence float64 `json:"minConfidence"`
+	MinLift       float64 `json:"minLift"`
+	MaxLength     int     `json:"maxLength"`
+}
+
+// TransactionsRequest is the body accepted by the transactions endpoint
+type TransactionsRequest struct {
+	Transactions [][]string `json:"transactions"`
+}
+
+// ScoreRequest is the body accepted by the score endpoint
+type ScoreRequest struct {
+	Basket []string `json:"basket"`
+	Limit  int      `json:"limit"`
+}
+
+// Rule is the wire representation of an OrderedStatistic together with the support of its itemset
+type Rule struct {
+	Base       []string `json:"base"`
+	Add        []string `json:"add"`
+	Support    float64  `json:"support"`
+	Confidence float64  `json:"confidence"`
+	Lift       float64  `json:"lift"`
+}
+
+// Recommendation is an item suggested for a basket and the rule that produced it
+type Recommendation struct {
+	Item       string  `json:"item"`
+	Confidence float64 `json:"confidence"`
+	Lift       float64 `json:"lift"`
+	Rule       Rule    `json:"rule"`
+}
+
+// DefaultMaxBodyBytes is the maximum size of the request bodies of a Handler created by NewHandler
+const DefaultMaxBodyBytes = 32 << 20
+
+// Status of the mining runs canceled by the client, as used by nginx.
+const statusClientClosedRequest = 499
+
+// Handler serves the transactions, mine, rules and score endpoints
+type Handler struct {
+	// MaxBodyBytes is the maximum size of the request bodies, the larger ones are rejected with 413.
+	MaxBodyBytes int64
+
+	mu           sync.RWMutex
+	transactions [][]string
+	rules        []Rule
+	generation   int // Incremented when the transactions are dropped, so the runs started before don't keep rules.
+	mux          *http.ServeMux
+}
+
+// NewHandler is a quick way to create a Handler with all the endpoints registered
+func NewHandler() *Handler {
+	h := &Handler{MaxBodyBytes: DefaultMaxBodyBytes, mux: http.NewServeMux()}
+	h.mux.HandleFunc("/transactions", h.handleTransactions)
+	h.mux.HandleFunc("/mine", h.handleMine)
+	h.mux.HandleFunc("/rules", h.handleRules)
+	h.mux.HandleFunc("/score", h.handleScore)
+
+	return h
+}
+
+// ServeHTTP dispatches the request to the matching endpoint
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	h.mux.ServeHTTP(w, r)
+}
+
+// Upload transactions (POST) or drop all of them together with the mined rules (DELETE)
+func (h *Handler) handleTransactions(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodPost:
+		var req TransactionsRequest
+		if !h.decode(w, r, &req) {
+			return
+		}
+		h.mu.Lock()
+		h.transactions = append(h.transactions, req.Transactions...)
+		count := len(h.transactions)
+		h.mu.Unlock()
+		writeJSON(w, http.StatusOK, map[string]int{"transactions": count})
+	case http.MethodDelete:
+		h.mu.Lock()
+		h.transactions = nil
+		h.rules = nil
+		h.generation++
+		h.mu.Unlock()
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		writeError(w, http.StatusMethodNotAllowed, errors.New("method not allowed"))
+	}
+}
+
+// Run the algorithm over the uploaded transactions and keep the resulting rules
+func (h *Handler) handleMine(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		writeError(w, http.StatusMethodNotAllowed, errors.New("method not allowed"))
+		return
+	}
+
+	var req OptionsRequest
+	if !h.decode(w, r, &req) {
+		return
+	}
+	options := apriori.NewOptions(req.MinSupport, req.MinConfidence, req.MinLift, req.MaxLength)
+	if err := options.Validate(); err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	// The rules can be read meanwhile, the lock is only held to copy the transactions and to keep the rules.
+	h.mu.RLock()
+	transactions := append([][]string(nil), h.transactions...)
+	generation := h.generation
+	h.mu.RUnlock()
+	records, err := apriori.NewApriori(transactions).CalculateContext(r.Context(), options)
+	if err != nil {
+		status := http.StatusBadRequest
+		if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+			status = statusClientClosedRequest
+		}
+		writeError(w, status, err)
+		return
+	}
+	rules := toRules(records)
+
+	h.mu.Lock()
+	if h.generation == generation {
+		h.rules = rules
+	}
+	h.mu.Unlock()
+
+	writeJSON(w, http.StatusOK, rules)
+}
+
+// Return the rules from the last mining run
+func (h *Handler) handleRules(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		writeError(w, http.StatusMethodNotAllowed, errors.New("method not allowed"))
+		return
+	}
+
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	writeJSON(w, http.StatusOK, h.rules)
+}
+
+// Recommend items for a basket based on the rules from the last mining run
+func (h *Handler) handleScore(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		writeError(w, http.StatusMethodNotAllowed, errors.New("method not allowed"))
+		return
+	}
+
+	var req ScoreRequest
+	if !h.decode(w, r, &req) {
+		return
+	}
+
+	h.mu.RLock()
+	recommendations := recommend(h.rules, req.Basket, req.Limit)
+	h.mu.RUnlock()
+
+	writeJSON(w, http.StatusOK, recommendations)
+}
+
+// Decodes the JSON body of the request into v, writing the error response when it fails.
+func (h *Handler) decode(w http.ResponseWriter, r *http.Request, v interface{}) bool {
+	err := json.NewDecoder(http.MaxBytesReader(w, r.Body, h.MaxBodyBytes)).Decode(v)
+	if err == nil {
+		return true
+	}
+	var maxBytesErr *http.MaxBytesError
+	if errors.As(err, &maxBytesErr) {
+		writeError(w, http.StatusRequestEntityTooLarge, err)
+	} else {
+		writeError(w, http.StatusBadRequest, err)
+	}
+
+	return false
+}
+
+func toRules(records []apriori.RelationRecord) []Rule {
+	rules := []Rule{}
+	for _, record := range records {
+		for _, statistic := range record.GetOrderedStatistic() {
+			rules = append(rules, Rule{
+				Base:       statistic.GetBase(),
+				Add:        statistic.GetAdd(),
+				Support:    record.GetSupportRecord().GetSupport(),
+				Confidence: statistic.GetConfidence(),
+				Lift:       statistic.GetLift(),
+			})
+		}
+	}
+
+	return rules
+}
+
+// Returns the items added by the rules whose base is fully contained in the basket, best confidence first.
+func recommend(rules []Rule, basket []string, limit int) []Recommendation {
+	inBasket := make(map[string]bool)
+	for _, item := range basket {
+		inBasket[item] = true
+	}
+
+	best := make(map[string]Recommendation)
+	for _, rule := range rules {
+		if !containsAll(inBasket, rule.Base) {
+			continue
+		}
+		for _, item := range rule.Add {
+			if inBasket[item] {
+				continue
+			}
+			if current, ok := best[item]; ok && current.Confidence >= rule.Confidence {
+				continue
+			}
+			best[item] = Recommendation{Item: item, Confidence: rule.Confidence, Lift: rule.Lift, Rule: rule}
+		}
+	}
+
+	recommendations := []Recommendation{}
+	for _, recommendation := range best {
+		recommendations = append(recommendations, recommendation)
+	}
+	sort.Slice(recommendations, func(i, j int) bool {
+		if recommendations[i].Confidence != recommendations[j].Confidence {
+			return recommendations[i].Confidence > recommendations[j].Confidence
+		}
+		if recommendations[i].Lift != recommendations[j].Lift {
+			return recommendations[i].Lift > recommendations[j].Lift
+		}
+		return recommendations[i].Item < recommendations[j].Item
+	})
+	if limit > 0 && len(recommendations) > limit {
+		recommendations = recommendations[:limit]
+	}
+
+	return recommendations
+}
+
+func containsAll(set map[string]bool, items []string) bool {
+	for _, item := range items {
+		if !set[item] {
+			return false
+		}
+	}
+
+	return true
+}
+
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+func writeError(w http.ResponseWriter, status int, err error) {
+	writeJSON(w, status, map[string]string{"error": err.Error()})
+}
@@ -0,0 +1,66 @@
+package apriorihttp
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestHandler(t *testing.T) {
+	h := NewHandler()
+
+	do := func(method, path, body string) *httptest.ResponseRecorder {
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, httptest.NewRequest(method, path, strings.NewReader(body)))
+		return rec
+	}
+
+	rec := do(http.MethodPost, "/transactions", `{"transactions":[["beer","nuts"],["beer","nuts","jam"],["beer"],["jam"]]}`)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("upload: unexpected status %d", rec.Code)
+	}
+
+	if rec = do(http.MethodPost, "/mine", `{"minSupport":0}`); rec.Code != http.StatusBadRequest {
+		t.Fatalf("mine: expected bad request for zero support, got %d", rec.Code)
+	}
+
+	rec = do(http.MethodPost, "/mine", `{"minSupport":0.25,"minConfidence":0.6}`)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("mine: unexpected status %d", rec.Code)
+	}
+
+	var rules []Rule
+	rec = do(http.MethodGet, "/rules", "")
+	if err := json.Unmarshal(rec.Body.Bytes(), &rules); err != nil || len(rules) == 0 {
+		t.Fatalf("rules: expected rules, got %s", rec.Body.String())
+	}
+
+	var recommendations []Recommendation
+	rec = do(http.MethodPost, "/score", `{"basket":["nuts"],"limit":1}`)
+	if err := json.Unmarshal(rec.Body.Bytes(), &recommendations); err != nil {
+		t.Fatal(err)
+	}
+	if len(recommendations) != 1 || recommendations[0].Item != "beer" || recommendations[0].Confidence != 1 {
+		t.Fatalf("score: unexpected recommendations %+v", recommendations)
+	}
+
+	h.MaxBodyBytes = 16
+	if rec = do(http.MethodPost, "/score", `{"basket":["nuts","beer","jam"]}`); rec.Code != http.StatusRequestEntityTooLarge {
+		t.Fatalf("score: expected the large body to be rejected, got %d", rec.Code)
+	}
+	h.MaxBodyBytes = DefaultMaxBodyBytes
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	rec = httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/mine", strings.NewReader(`{"minSupport":0.25}`)).WithContext(ctx))
+	if rec.Code != statusClientClosedRequest {
+		t.Fatalf("mine: expected the canceled run to fail, got %d", rec.Code)
+	}
+	if rec = do(http.MethodGet, "/rules", ""); !strings.Contains(rec.Body.String(), `"confidence":1`) {
+		t.Fatalf("rules: expected the rules of the last successful run, got %s", rec.Body.String())
+	}
+}
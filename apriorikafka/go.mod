@@ -0,0 +1,15 @@
+module github.com/eMAGTechLabs/go-apriori/apriorikafka
+
+go 1.23.0
+
+require (
+	github.com/eMAGTechLabs/go-apriori v0.0.0
+	github.com/segmentio/kafka-go v0.4.47
+)
+
+require (
+	github.com/klauspost/compress v1.15.9 // indirect
+	github.com/pierrec/lz4/v4 v4.1.15 // indirect
+)
+
+replace github.com/eMAGTechLabs/go-apriori => ../
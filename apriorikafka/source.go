@@ -0,0 +1,77 @@
+// Package apriorikafka is a TransactionSource consuming the transactions from a Kafka topic, so a StreamMiner can
+// follow it and commit the offsets once the transactions are checkpointed
+package apriorikafka
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/segmentio/kafka-go"
+)
+
+// Reader is the part of a kafka.Reader used by the source, the reader has to belong to a consumer group so its
+// offsets can be committed
+type Reader interface {
+	FetchMessage(ctx context.Context) (kafka.Message, error)
+	CommitMessages(ctx context.Context, messages ...kafka.Message) error
+}
+
+type partition struct {
+	topic     string
+	partition int
+}
+
+// Source reads a transaction per message, a JSON array of items, e.g. ["beer","nuts"]
+type Source struct {
+	reader  Reader
+	pending map[partition]kafka.Message
+
+	// OnInvalidMessage, when not nil, is called with the messages that aren't a transaction, e.g. to log them or to
+	// send them to a dead letter topic. They are skipped either way and their offsets committed, so they can't block
+	// the consumer.
+	OnInvalidMessage func(message kafka.Message, err error)
+}
+
+// NewSource creates a Source reading from the given reader, usually a *kafka.Reader
+func NewSource(reader Reader) *Source {
+	return &Source{reader: reader, pending: make(map[partition]kafka.Message)}
+}
+
+// Next fetches the next message and returns its transaction, io.EOF once the reader is closed. The messages that
+// aren't a transaction are skipped, see OnInvalidMessage.
+func (s *Source) Next(ctx context.Context) ([]string, error) {
+	for {
+		message, err := s.reader.FetchMessage(ctx)
+		if err != nil {
+			return nil, err
+		}
+		s.pending[partition{message.Topic, message.Partition}] = message
+		var transaction []string
+		if err := json.Unmarshal(message.Value, &transaction); err != nil {
+			if s.OnInvalidMessage != nil {
+				s.OnInvalidMessage(message, fmt.Errorf("message %d of %s/%d is not a transaction: %w", message.Offset, message.Topic, message.Partition, err))
+			}
+			continue
+		}
+
+		return transaction, nil
+	}
+}
+
+// Commit commits the offsets of the messages fetched so far, the last message of each partition is enough
+func (s *Source) Commit(ctx context.Context) error {
+	if len(s.pending) == 0 {
+		return nil
+	}
+	messages := make([]kafka.Message, 0, len(s.pending))
+	for _, message := range s.pending {
+		messages = append(messages, message)
+	}
+	if err := s.reader.CommitMessages(ctx, messages...); err != nil {
+		return err
+	}
+	s.pending = make(map[partition]kafka.Message)
+
+	return nil
+}
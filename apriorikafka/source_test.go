@@ -0,0 +1,75 @@
+package apriorikafka
+
+import (
+	"context"
+	"io"
+	"testing"
+
+	"github.com/eMAGTechLabs/go-apriori"
+	"github.com/segmentio/kafka-go"
+)
+
+type fakeReader struct {
+	messages  []kafka.Message
+	committed []kafka.Message
+}
+
+func (r *fakeReader) FetchMessage(ctx context.Context) (kafka.Message, error) {
+	if len(r.messages) == 0 {
+		return kafka.Message{}, io.EOF
+	}
+	message := r.messages[0]
+	r.messages = r.messages[1:]
+	return message, nil
+}
+
+func (r *fakeReader) CommitMessages(ctx context.Context, messages ...kafka.Message) error {
+	r.committed = append(r.committed, messages...)
+	return nil
+}
+
+func TestSource(t *testing.T) {
+	reader := &fakeReader{messages: []kafka.Message{
+		{Topic: "orders", Partition: 0, Offset: 7, Value: []byte(`["beer","nuts"]`)},
+		{Topic: "orders", Partition: 1, Offset: 3, Value: []byte(`["beer"]`)},
+		{Topic: "orders", Partition: 0, Offset: 8, Value: []byte(`["beer","jam"]`)},
+	}}
+
+	stream, _ := apriori.NewStreamMiner(10)
+	if err := stream.Consume(context.Background(), NewSource(reader), 0, nil); err != nil {
+		t.Fatal(err)
+	}
+	if stream.TransactionCount() != 3 {
+		t.Fatalf("unexpected transaction count %d", stream.TransactionCount())
+	}
+
+	if len(reader.committed) != 2 {
+		t.Fatalf("expected the last message of each partition to be committed, got %v", reader.committed)
+	}
+	for _, message := range reader.committed {
+		if message.Partition == 0 && message.Offset != 8 {
+			t.Fatalf("unexpected committed offset %d", message.Offset)
+		}
+	}
+
+	reader = &fakeReader{messages: []kafka.Message{
+		{Topic: "orders", Offset: 1, Value: []byte("beer,nuts")},
+		{Topic: "orders", Offset: 2, Value: []byte(`["beer","nuts"]`)},
+		{Topic: "orders", Offset: 3, Value: []byte("{")},
+	}}
+	var invalid []int64
+	source := NewSource(reader)
+	source.OnInvalidMessage = func(message kafka.Message, err error) {
+		invalid = append(invalid, message.Offset)
+	}
+	stream, _ = apriori.NewStreamMiner(10)
+	if err := stream.Consume(context.Background(), source, 0, nil); err != nil {
+		t.Fatal(err)
+	}
+	if stream.TransactionCount() != 1 || len(invalid) != 2 {
+		t.Fatalf("expected the invalid messages to be skipped, got %d transactions and %v", stream.TransactionCount(), invalid)
+	}
+	if len(reader.committed) != 1 || reader.committed[0].Offset != 3 {
+		t.Fatalf("expected the offset of the last invalid message to be committed, got %v", reader.committed)
+	}
+}
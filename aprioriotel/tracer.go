@@ -0,0 +1,46 @@
+// Package aprioriotel traces the phases of the go-apriori mining runs with OpenTelemetry:
+//
+//	options, err := apriori.NewOptionsWith(apriori.WithTracer(aprioriotel.NewTracer(otel.Tracer("apriori"))))
+//	records, err := miner.CalculateContext(ctx, options)
+package aprioriotel
+
+import (
+	"context"
+
+	"github.com/eMAGTechLabs/go-apriori"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// Tracer is an apriori.Tracer starting OpenTelemetry spans, children of the span of the context given to
+// CalculateContext
+type Tracer struct {
+	tracer trace.Tracer
+}
+
+var _ apriori.Tracer = (*Tracer)(nil)
+
+// NewTracer creates a Tracer starting the spans with the OpenTelemetry tracer
+func NewTracer(tracer trace.Tracer) *Tracer {
+	return &Tracer{tracer: tracer}
+}
+
+// Start starts an OpenTelemetry span
+func (t *Tracer) Start(ctx context.Context, name string) (context.Context, apriori.Span) {
+	ctx, span := t.tracer.Start(ctx, name)
+
+	return ctx, otelSpan{span}
+}
+
+// Span adapting an OpenTelemetry span to apriori.Span.
+type otelSpan struct {
+	span trace.Span
+}
+
+func (s otelSpan) SetAttribute(key string, value int64) {
+	s.span.SetAttributes(attribute.Int64(key, value))
+}
+
+func (s otelSpan) End() {
+	s.span.End()
+}
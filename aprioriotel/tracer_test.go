@@ -0,0 +1,49 @@
+package aprioriotel
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/eMAGTechLabs/go-apriori"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func TestTracer(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+	tracer := provider.Tracer("test")
+
+	ctx, parent := tracer.Start(context.Background(), "job")
+	options, err := apriori.NewOptionsWith(apriori.WithMinSupport(0.5), apriori.WithTracer(NewTracer(tracer)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := apriori.NewApriori([][]string{{"beer", "nuts"}, {"beer", "nuts"}, {"beer"}}).CalculateContext(ctx, options); err != nil {
+		t.Fatal(err)
+	}
+	parent.End()
+
+	var names []string
+	var run sdktrace.ReadOnlySpan
+	for _, span := range recorder.Ended() {
+		names = append(names, span.Name())
+		if span.Name() == "apriori.Calculate" {
+			run = span
+		}
+	}
+	sort.Strings(names)
+	if result := strings.Join(names, " "); result != "apriori.Calculate apriori.level apriori.level apriori.rules job" {
+		t.Fatalf("unexpected spans %s", result)
+	}
+	if run.Parent().SpanID() != parent.SpanContext().SpanID() {
+		t.Fatal("expected the run to be a child of the span of the context")
+	}
+	for _, attribute := range run.Attributes() {
+		if attribute.Key == "transactions" && attribute.Value.AsInt64() != 3 {
+			t.Fatalf("unexpected transactions %d", attribute.Value.AsInt64())
+		}
+	}
+}
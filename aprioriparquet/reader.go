@@ -0,0 +1,138 @@
+// Package aprioriparquet reads transactions from Parquet files through Arrow, either a row per transaction with
+// a list<string> column or the long format, so data lake exports can be mined without a CSV step
+package aprioriparquet
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/apache/arrow-go/v18/arrow"
+	"github.com/apache/arrow-go/v18/arrow/array"
+	"github.com/apache/arrow-go/v18/arrow/memory"
+	"github.com/apache/arrow-go/v18/parquet"
+	"github.com/apache/arrow-go/v18/parquet/file"
+	"github.com/apache/arrow-go/v18/parquet/pqarrow"
+)
+
+// Number of rows read at a time.
+const batchSize = 64 * 1024
+
+// ReadTransactions reads a transaction per row from the list column, e.g. items: list<string>. The null items are
+// skipped, a null list is an empty transaction.
+func ReadTransactions(r parquet.ReaderAtSeeker, column string) ([][]string, error) {
+	var transactions [][]string
+	err := readColumns(r, []string{column}, func(columns []arrow.Array) error {
+		list, ok := columns[0].(array.ListLike)
+		if !ok {
+			return fmt.Errorf("column %q is a %s, not a list", column, columns[0].DataType())
+		}
+		values := list.ListValues()
+		for i := 0; i < list.Len(); i++ {
+			var transaction []string
+			if list.IsValid(i) {
+				start, end := list.ValueOffsets(i)
+				for j := int(start); j < int(end); j++ {
+					if values.IsValid(j) {
+						transaction = append(transaction, values.ValueStr(j))
+					}
+				}
+			}
+			transactions = append(transactions, transaction)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return transactions, nil
+}
+
+// ReadLongTransactions reads the transactions from long format rows, one row per order and item, e.g. order_id,
+// sku. The rows of a transaction don't need to be consecutive, the transactions are returned in the order their
+// first row was read, without repeated items, and rows with a null item only count the transaction.
+func ReadLongTransactions(r parquet.ReaderAtSeeker, groupCol, itemCol string) ([][]string, error) {
+	var transactions [][]string
+	indexes := make(map[string]int)
+	seen := make(map[[2]string]bool)
+	err := readColumns(r, []string{groupCol, itemCol}, func(columns []arrow.Array) error {
+		groups, items := columns[0], columns[1]
+		for i := 0; i < groups.Len(); i++ {
+			group := groups.ValueStr(i)
+			index, ok := indexes[group]
+			if !ok {
+				index = len(transactions)
+				indexes[group] = index
+				transactions = append(transactions, nil)
+			}
+			if items.IsNull(i) {
+				continue
+			}
+			item := items.ValueStr(i)
+			if !seen[[2]string{group, item}] {
+				seen[[2]string{group, item}] = true
+				transactions[index] = append(transactions[index], item)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return transactions, nil
+}
+
+// Reads the given top level columns batch by batch and passes them to process in the order of the names.
+func readColumns(r parquet.ReaderAtSeeker, names []string, process func(columns []arrow.Array) error) error {
+	parquetReader, err := file.NewParquetReader(r)
+	if err != nil {
+		return err
+	}
+	defer parquetReader.Close()
+	reader, err := pqarrow.NewFileReader(parquetReader, pqarrow.ArrowReadProperties{BatchSize: batchSize}, memory.DefaultAllocator)
+	if err != nil {
+		return err
+	}
+
+	// The record reader selects leaf columns, a nested column like a list has its own leaves.
+	schema := parquetReader.MetaData().Schema
+	var leaves []int
+	for _, name := range names {
+		found := false
+		for i := 0; i < schema.NumColumns(); i++ {
+			if schema.ColumnRoot(i).Name() == name {
+				leaves = append(leaves, i)
+				found = true
+			}
+		}
+		if !found {
+			return fmt.Errorf("the file has no %q column", name)
+		}
+	}
+
+	records, err := reader.GetRecordReader(context.Background(), leaves, nil)
+	if err != nil {
+		return err
+	}
+	defer records.Release()
+
+	for records.Next() {
+		record := records.Record()
+		columns := make([]arrow.Array, len(names))
+		for i, name := range names {
+			columns[i] = record.Column(record.Schema().FieldIndices(name)[0])
+		}
+		if err := process(columns); err != nil {
+			return err
+		}
+	}
+
+	// The record reader reports the end of the file as an error.
+	if err := records.Err(); err != nil && err != io.EOF {
+		return err
+	}
+
+	return nil
+}
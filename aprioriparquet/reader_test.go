@@ -0,0 +1,85 @@
+package aprioriparquet
+
+import (
+	"bytes"
+	"fmt"
+	"testing"
+
+	"github.com/apache/arrow-go/v18/arrow"
+	"github.com/apache/arrow-go/v18/arrow/array"
+	"github.com/apache/arrow-go/v18/arrow/memory"
+	"github.com/apache/arrow-go/v18/parquet/pqarrow"
+)
+
+func writeParquet(t *testing.T, schema *arrow.Schema, build func(builder *array.RecordBuilder)) *bytes.Reader {
+	builder := array.NewRecordBuilder(memory.DefaultAllocator, schema)
+	defer builder.Release()
+	build(builder)
+	record := builder.NewRecord()
+	defer record.Release()
+
+	var buffer bytes.Buffer
+	writer, err := pqarrow.NewFileWriter(schema, &buffer, nil, pqarrow.DefaultWriterProps())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := writer.Write(record); err != nil {
+		t.Fatal(err)
+	}
+	if err := writer.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	return bytes.NewReader(buffer.Bytes())
+}
+
+func TestReadTransactions(t *testing.T) {
+	schema := arrow.NewSchema([]arrow.Field{
+		{Name: "order_id", Type: arrow.PrimitiveTypes.Int64},
+		{Name: "items", Type: arrow.ListOf(arrow.BinaryTypes.String), Nullable: true},
+	}, nil)
+	r := writeParquet(t, schema, func(builder *array.RecordBuilder) {
+		builder.Field(0).(*array.Int64Builder).AppendValues([]int64{1, 2, 3}, nil)
+		items := builder.Field(1).(*array.ListBuilder)
+		values := items.ValueBuilder().(*array.StringBuilder)
+		items.Append(true)
+		values.AppendValues([]string{"beer", "nuts"}, nil)
+		items.AppendNull()
+		items.Append(true)
+		values.Append("jam")
+	})
+
+	transactions, err := ReadTransactions(r, "items")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result := fmt.Sprint(transactions); result != "[[beer nuts] [] [jam]]" {
+		t.Fatalf("unexpected transactions %s", result)
+	}
+
+	if _, err := ReadTransactions(r, "order_id"); err == nil {
+		t.Fatal("expected an error for a column that isn't a list")
+	}
+	if _, err := ReadTransactions(r, "products"); err == nil {
+		t.Fatal("expected an error for a missing column")
+	}
+}
+
+func TestReadLongTransactions(t *testing.T) {
+	schema := arrow.NewSchema([]arrow.Field{
+		{Name: "sku", Type: arrow.BinaryTypes.String, Nullable: true},
+		{Name: "order_id", Type: arrow.PrimitiveTypes.Int64},
+	}, nil)
+	r := writeParquet(t, schema, func(builder *array.RecordBuilder) {
+		builder.Field(0).(*array.StringBuilder).AppendValues([]string{"beer", "jam", "nuts", "", "beer"}, []bool{true, true, true, false, true})
+		builder.Field(1).(*array.Int64Builder).AppendValues([]int64{7, 9, 7, 11, 7}, nil)
+	})
+
+	transactions, err := ReadLongTransactions(r, "order_id", "sku")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result := fmt.Sprint(transactions); result != "[[beer nuts] [jam] []]" {
+		t.Fatalf("unexpected transactions %s", result)
+	}
+}
@@ -0,0 +1,77 @@
+// Package aprioriprometheus exports the metrics of the go-apriori mining runs to Prometheus:
+//
+//	collector, err := aprioriprometheus.NewCollector(prometheus.DefaultRegisterer)
+//	options, err := apriori.NewOptionsWith(apriori.WithMetricsCollector(collector))
+package aprioriprometheus
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/eMAGTechLabs/go-apriori"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// Collector is an apriori.MetricsCollector updating Prometheus metrics
+type Collector struct {
+	transactions prometheus.Gauge
+	candidates   *prometheus.CounterVec
+	rules        prometheus.Counter
+	duration     prometheus.Histogram
+}
+
+var _ apriori.MetricsCollector = (*Collector)(nil)
+
+// NewCollector creates a Collector and registers its metrics with the registerer:
+//   - apriori_transactions_indexed, the number of transactions mined by the last run
+//   - apriori_candidates_total, the number of candidate itemsets counted, by length
+//   - apriori_rules_emitted_total, the number of rules returned or streamed by Calculate
+//   - apriori_mining_duration_seconds, the duration of the runs
+func NewCollector(registerer prometheus.Registerer) (*Collector, error) {
+	c := &Collector{
+		transactions: prometheus.NewGauge(prometheus.GaugeOpts{
+			Name: "apriori_transactions_indexed",
+			Help: "Number of transactions mined by the last run.",
+		}),
+		candidates: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "apriori_candidates_total",
+			Help: "Number of candidate itemsets counted, by length.",
+		}, []string{"length"}),
+		rules: prometheus.NewCounter(prometheus.CounterOpts{
+			Name: "apriori_rules_emitted_total",
+			Help: "Number of rules returned or streamed by Calculate.",
+		}),
+		duration: prometheus.NewHistogram(prometheus.HistogramOpts{
+			Name:    "apriori_mining_duration_seconds",
+			Help:    "Duration of the mining runs.",
+			Buckets: prometheus.ExponentialBuckets(0.001, 4, 10),
+		}),
+	}
+	for _, collector := range []prometheus.Collector{c.transactions, c.candidates, c.rules, c.duration} {
+		if err := registerer.Register(collector); err != nil {
+			return nil, err
+		}
+	}
+
+	return c, nil
+}
+
+// TransactionsIndexed sets the number of transactions mined by the last run
+func (c *Collector) TransactionsIndexed(count int64) {
+	c.transactions.Set(float64(count))
+}
+
+// CandidatesCounted adds the candidate itemsets of a level to the ones of their length
+func (c *Collector) CandidatesCounted(length int, count int) {
+	c.candidates.WithLabelValues(strconv.Itoa(length)).Add(float64(count))
+}
+
+// RulesEmitted adds the rules of a run
+func (c *Collector) RulesEmitted(count int) {
+	c.rules.Add(float64(count))
+}
+
+// MiningFinished observes the duration of a run
+func (c *Collector) MiningFinished(duration time.Duration) {
+	c.duration.Observe(duration.Seconds())
+}
@@ -0,0 +1,43 @@
+package aprioriprometheus
+
+import (
+	"testing"
+
+	"github.com/eMAGTechLabs/go-apriori"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+func TestCollector(t *testing.T) {
+	registry := prometheus.NewRegistry()
+	collector, err := NewCollector(registry)
+	if err != nil {
+		t.Fatal(err)
+	}
+	options, err := apriori.NewOptionsWith(apriori.WithMinSupport(0.5), apriori.WithMetricsCollector(collector))
+	if err != nil {
+		t.Fatal(err)
+	}
+	records := apriori.NewApriori([][]string{{"beer", "nuts"}, {"beer", "nuts"}, {"beer"}, {"jam"}}).Calculate(options)
+
+	rules := 0
+	for _, record := range records {
+		rules += len(record.GetOrderedStatistic())
+	}
+	if value := testutil.ToFloat64(collector.transactions); value != 4 {
+		t.Fatalf("unexpected transactions indexed %v", value)
+	}
+	if value := testutil.ToFloat64(collector.candidates.WithLabelValues("2")); value != 1 {
+		t.Fatalf("unexpected candidates of length 2 %v", value)
+	}
+	if value := testutil.ToFloat64(collector.rules); value != float64(rules) {
+		t.Fatalf("unexpected rules emitted %v, expected %d", value, rules)
+	}
+	if count := testutil.CollectAndCount(registry, "apriori_mining_duration_seconds"); count != 1 {
+		t.Fatalf("unexpected duration metrics %d", count)
+	}
+
+	if _, err := NewCollector(registry); err == nil {
+		t.Fatal("expected an error when registering the metrics twice")
+	}
+}
@@ -0,0 +1,15 @@
+module github.com/eMAGTechLabs/go-apriori/aprioriroaring
+
+go 1.23.0
+
+require (
+	github.com/RoaringBitmap/roaring v1.9.4
+	github.com/eMAGTechLabs/go-apriori v0.0.0
+)
+
+require (
+	github.com/bits-and-blooms/bitset v1.12.0 // indirect
+	github.com/mschoch/smat v0.2.0 // indirect
+)
+
+replace github.com/eMAGTechLabs/go-apriori => ../
@@ -0,0 +1,116 @@
+// Package aprioriroaring is a TransactionStore keeping the index of the transactions as compressed roaring
+// bitmaps, several times smaller in memory than the index lists for dense items. Together with the diffsets of
+// the options it keeps the memory of deep mining low:
+//
+//	store := aprioriroaring.NewStore()
+//	err := store.AddTransactions(transactions)
+//	miner, err := apriori.NewAprioriFromStore(store)
+//	options, err := apriori.NewOptionsWith(apriori.WithMinSupport(0.01), apriori.WithDiffsets())
+package aprioriroaring
+
+import (
+	"sync"
+
+	"github.com/RoaringBitmap/roaring/roaring64"
+	"github.com/eMAGTechLabs/go-apriori"
+)
+
+// Store keeps a roaring bitmap per item of the indexes of the transactions that contain it. It is safe for
+// concurrent use.
+type Store struct {
+	mu            sync.RWMutex
+	transactionNo int64
+	items         []string
+	bitmaps       map[string]*roaring64.Bitmap
+}
+
+var _ apriori.TransactionStore = (*Store)(nil)
+
+// NewStore creates an empty Store
+func NewStore() *Store {
+	return &Store{bitmaps: make(map[string]*roaring64.Bitmap)}
+}
+
+// AddTransaction appends a transaction to the store
+func (s *Store) AddTransaction(transaction []string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.addTransaction(transaction)
+	return nil
+}
+
+// AddTransactions appends the transactions to the store, compressing the bitmaps once at the end
+func (s *Store) AddTransactions(transactions [][]string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, transaction := range transactions {
+		s.addTransaction(transaction)
+	}
+	for _, bitmap := range s.bitmaps {
+		bitmap.RunOptimize()
+	}
+	return nil
+}
+
+func (s *Store) addTransaction(transaction []string) {
+	for _, item := range transaction {
+		bitmap, ok := s.bitmaps[item]
+		if !ok {
+			bitmap = roaring64.New()
+			s.bitmaps[item] = bitmap
+			s.items = append(s.items, item)
+		}
+		bitmap.Add(uint64(s.transactionNo))
+	}
+	s.transactionNo++
+}
+
+// TransactionCount returns the number of transactions in the store
+func (s *Store) TransactionCount() (int64, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return s.transactionNo, nil
+}
+
+// Items returns the distinct items of the transactions
+func (s *Store) Items() ([]string, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return append([]string{}, s.items...), nil
+}
+
+// ItemIndexes returns the sorted indexes of the transactions containing the item, nil for an unknown item. The
+// list is decompressed from the bitmap on every call.
+func (s *Store) ItemIndexes(item string) ([]int64, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	bitmap, ok := s.bitmaps[item]
+	if !ok {
+		return nil, nil
+	}
+	indexes := make([]int64, 0, bitmap.GetCardinality())
+	iterator := bitmap.Iterator()
+	for iterator.HasNext() {
+		indexes = append(indexes, int64(iterator.Next()))
+	}
+
+	return indexes, nil
+}
+
+// SizeInBytes returns the size of the bitmaps in memory, without the item names
+func (s *Store) SizeInBytes() uint64 {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var size uint64
+	for _, bitmap := range s.bitmaps {
+		size += bitmap.GetSizeInBytes()
+	}
+
+	return size
+}
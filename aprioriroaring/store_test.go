@@ -0,0 +1,51 @@
+package aprioriroaring
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/eMAGTechLabs/go-apriori"
+)
+
+func TestStore(t *testing.T) {
+	transactions := [][]string{
+		{"beer", "nuts", "cheese"},
+		{"beer", "nuts", "jam"},
+		{"beer", "butter"},
+		{"nuts", "cheese"},
+	}
+	store := NewStore()
+	if err := store.AddTransactions(transactions); err != nil {
+		t.Fatal(err)
+	}
+
+	indexes, err := store.ItemIndexes("nuts")
+	if err != nil || fmt.Sprint(indexes) != "[0 1 3]" {
+		t.Fatalf("unexpected indexes %v (%v)", indexes, err)
+	}
+	if indexes, _ := store.ItemIndexes("wine"); indexes != nil {
+		t.Fatalf("unexpected indexes of an unknown item %v", indexes)
+	}
+	if store.SizeInBytes() == 0 {
+		t.Fatal("expected the size of the bitmaps")
+	}
+
+	a, err := apriori.NewAprioriFromStore(store)
+	if err != nil {
+		t.Fatal(err)
+	}
+	options, err := apriori.NewOptionsWith(apriori.WithMinSupport(0.25), apriori.WithDiffsets())
+	if err != nil {
+		t.Fatal(err)
+	}
+	records := a.Calculate(options)
+	expected := apriori.NewApriori(transactions).Calculate(apriori.NewOptions(0.25, 0, 0, 0))
+	if len(records) != len(expected) || len(records) == 0 {
+		t.Fatalf("expected %d records, got %d", len(expected), len(records))
+	}
+	for i := range records {
+		if records[i].String() != expected[i].String() {
+			t.Fatalf("unexpected record %v, expected %v", records[i], expected[i])
+		}
+	}
+}
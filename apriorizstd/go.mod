@@ -0,0 +1,10 @@
+module github.com/eMAGTechLabs/go-apriori/apriorizstd
+
+go 1.23.0
+
+require (
+	github.com/eMAGTechLabs/go-apriori v0.0.0
+	github.com/klauspost/compress v1.17.11
+)
+
+replace github.com/eMAGTechLabs/go-apriori => ../
@@ -0,0 +1,22 @@
+// Package apriorizstd makes the file loaders of go-apriori read zstd compressed files, it only needs to be
+// imported:
+//
+//	import _ "github.com/eMAGTechLabs/go-apriori/apriorizstd"
+package apriorizstd
+
+import (
+	"io"
+
+	"github.com/eMAGTechLabs/go-apriori"
+	"github.com/klauspost/compress/zstd"
+)
+
+// Magic bytes of a zstd frame.
+var magic = []byte{0x28, 0xb5, 0x2f, 0xfd}
+
+func init() {
+	apriori.RegisterDecompressor(magic, func(r io.Reader) (io.Reader, error) {
+		// A single decoding goroutine is enough for line by line reading, and doesn't outlive the reader.
+		return zstd.NewReader(r, zstd.WithDecoderConcurrency(1))
+	})
+}
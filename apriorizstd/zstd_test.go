@@ -0,0 +1,28 @@
+package apriorizstd
+
+import (
+	"bytes"
+	"fmt"
+	"testing"
+
+	"github.com/eMAGTechLabs/go-apriori"
+	"github.com/klauspost/compress/zstd"
+)
+
+func TestDecompress(t *testing.T) {
+	var compressed bytes.Buffer
+	writer, err := zstd.NewWriter(&compressed)
+	if err != nil {
+		t.Fatal(err)
+	}
+	writer.Write([]byte("1 2\n2 3\n"))
+	writer.Close()
+
+	transactions, err := apriori.ReadSPMF(&compressed)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result := fmt.Sprint(transactions); result != "[[1 2] [2 3]]" {
+		t.Fatalf("unexpected transactions %s", result)
+	}
+}
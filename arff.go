@@ -0,0 +1,132 @@
+package apriori
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// ReadARFF reads the transactions of a Weka ARFF file, in the dense or the sparse ("{index value, ...}") format.
+// Every attribute is an item: in basket data, e.g. the attributes declared as {t} or {f,t}, the values t and 1 mean
+// the item was bought, while f, 0 and the missing value ? mean it wasn't. Any other value, e.g. of a nominal
+// attribute like outlook {sunny,rainy}, becomes an "attribute=value" item.
+func ReadARFF(r io.Reader) ([][]string, error) {
+	r, err := Decompress(r)
+	if err != nil {
+		return nil, err
+	}
+	scanner := newLineScanner(r)
+	var attributes []string
+	var transactions [][]string
+	inData := false
+	lineNo := 0
+	for scanner.Scan() {
+		lineNo++
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "%") {
+			continue
+		}
+
+		if !inData {
+			keyword := strings.ToLower(strings.Fields(line)[0])
+			switch keyword {
+			case "@attribute":
+				name, _ := splitARFFToken(strings.TrimSpace(line[len(keyword):]))
+				if name == "" {
+					return nil, fmt.Errorf("line %d: attribute without name", lineNo)
+				}
+				attributes = append(attributes, name)
+			case "@data":
+				inData = true
+			}
+			continue
+		}
+
+		var transaction []string
+		addValue := func(attribute int, value string) error {
+			if attribute < 0 || attribute >= len(attributes) {
+				return fmt.Errorf("line %d: there is no attribute %d", lineNo, attribute)
+			}
+			switch value {
+			case "t", "1":
+				transaction = append(transaction, attributes[attribute])
+			case "f", "0", "?", "":
+			default:
+				transaction = append(transaction, attributes[attribute]+"="+value)
+			}
+			return nil
+		}
+
+		if strings.HasPrefix(line, "{") {
+			for _, pair := range splitARFFValues(strings.TrimSuffix(strings.TrimPrefix(line, "{"), "}")) {
+				indexToken, value := splitARFFToken(pair)
+				attribute, err := strconv.Atoi(indexToken)
+				if err != nil {
+					return nil, fmt.Errorf("line %d: invalid sparse value %q", lineNo, pair)
+				}
+				value, _ = splitARFFToken(value)
+				if err := addValue(attribute, value); err != nil {
+					return nil, err
+				}
+			}
+		} else {
+			values := splitARFFValues(line)
+			if len(values) != len(attributes) {
+				return nil, fmt.Errorf("line %d: %d values for %d attributes", lineNo, len(values), len(attributes))
+			}
+			for attribute, value := range values {
+				value, _ = splitARFFToken(value)
+				if err := addValue(attribute, value); err != nil {
+					return nil, err
+				}
+			}
+		}
+		transactions = append(transactions, transaction)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	return transactions, nil
+}
+
+// Returns the first, optionally quoted, token of s and the rest of s.
+func splitARFFToken(s string) (string, string) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return "", ""
+	}
+	if quote := s[0]; quote == '\'' || quote == '"' {
+		if end := strings.IndexByte(s[1:], quote); end != -1 {
+			return s[1 : end+1], strings.TrimSpace(s[end+2:])
+		}
+	}
+	if end := strings.IndexAny(s, " \t"); end != -1 {
+		return s[:end], strings.TrimSpace(s[end:])
+	}
+
+	return s, ""
+}
+
+// Splits the values of a data line on the commas outside quotes.
+func splitARFFValues(line string) []string {
+	var values []string
+	var quote byte
+	start := 0
+	for i := 0; i < len(line); i++ {
+		switch c := line[i]; {
+		case quote != 0:
+			if c == quote {
+				quote = 0
+			}
+		case c == '\'' || c == '"':
+			quote = c
+		case c == ',':
+			values = append(values, strings.TrimSpace(line[start:i]))
+			start = i + 1
+		}
+	}
+
+	return append(values, strings.TrimSpace(line[start:]))
+}
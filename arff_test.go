@@ -0,0 +1,46 @@
+package apriori
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestReadARFF(t *testing.T) {
+	dense := `% supermarket sample
+@relation supermarket
+@attribute beer {t}
+@attribute 'bread and cake' {f,t}
+@attribute outlook {sunny,rainy}
+
+@data
+t,t,sunny
+?,f,?
+t,?,'rainy'
+`
+	transactions, err := ReadARFF(strings.NewReader(dense))
+	assert(err == nil, "Expected the dense ARFF to be read")
+	result := fmt.Sprint(transactions)
+	assert(result == "[[beer bread and cake outlook=sunny] [] [beer outlook=rainy]]", "Unexpected transactions: "+result)
+
+	sparse := "@RELATION baskets\n@ATTRIBUTE beer {0,1}\n@ATTRIBUTE nuts {0,1}\n@ATTRIBUTE jam {0,1}\n@DATA\n{0 1, 2 1}\n{1 1}\n"
+	transactions, err = ReadARFF(strings.NewReader(sparse))
+	assert(err == nil, "Expected the sparse ARFF to be read")
+	result = fmt.Sprint(transactions)
+	assert(result == "[[beer jam] [nuts]]", "Unexpected transactions: "+result)
+
+	// A wide basket, longer than the default buffer of a scanner.
+	var header, values []string
+	for i := 0; i < 20000; i++ {
+		header = append(header, fmt.Sprintf("@attribute item%d {0,1}", i))
+		values = append(values, fmt.Sprintf("%d 1", i))
+	}
+	wide := "@relation wide\n" + strings.Join(header, "\n") + "\n@data\n{" + strings.Join(values, ", ") + "}\n"
+	transactions, err = ReadARFF(strings.NewReader(wide))
+	assert(err == nil && len(transactions) == 1 && len(transactions[0]) == 20000, fmt.Sprint("Expected the long line to be read ", err))
+
+	_, err = ReadARFF(strings.NewReader("@attribute beer {t}\n@data\nt,t\n"))
+	assert(err != nil, "Expected an error for too many values")
+	_, err = ReadARFF(strings.NewReader("@attribute beer {t}\n@data\n{3 t}\n"))
+	assert(err != nil, "Expected an error for an unknown sparse attribute")
+}
@@ -0,0 +1,135 @@
+package apriori
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// Columns of the rules written by write() of R's arules package.
+var arulesColumns = []string{"rules", "support", "confidence", "coverage", "lift", "count"}
+
+// WriteArulesCSV writes the rules like write(rules, sep = ",") of R's arules package, so that they can be read with
+// read.csv and compared with the rules mined by arules: the rules column holds the labels of the rules, e.g.
+// "{beer,nuts} => {jam}", followed by the support, confidence, coverage (support of the antecedent), lift and count.
+// The count is 0 when the number of transactions is unknown.
+func WriteArulesCSV(w io.Writer, records []RelationRecord) error {
+	writer := csv.NewWriter(w)
+	if err := writer.Write(arulesColumns); err != nil {
+		return err
+	}
+	format := func(value float64) string {
+		return strconv.FormatFloat(value, 'g', -1, 64)
+	}
+	for _, record := range records {
+		support := record.supportRecord.support
+		for _, orderedStatistic := range record.orderedStatistic {
+			if len(orderedStatistic.base) == 0 {
+				continue
+			}
+			coverage := orderedStatistic.baseSupport
+			if math.IsNaN(coverage) {
+				coverage = support / orderedStatistic.confidence
+			}
+			err := writer.Write([]string{
+				arulesLabel(orderedStatistic.base) + " => " + arulesLabel(orderedStatistic.add),
+				format(support),
+				format(orderedStatistic.confidence),
+				format(coverage),
+				format(orderedStatistic.lift),
+				strconv.FormatInt(orderedStatistic.supportCount, 10),
+			})
+			if err != nil {
+				return err
+			}
+		}
+	}
+	writer.Flush()
+
+	return writer.Error()
+}
+
+// Returns the arules label of the items, e.g. {beer,nuts}.
+func arulesLabel(items []string) string {
+	return "{" + strings.Join(items, ",") + "}"
+}
+
+// Reads the rules of a CSV file written by arules, with a header. The rules with an empty antecedent, e.g.
+// "{} => {beer}", are the supports of the single items and are skipped.
+func parseArulesRules(r io.Reader) ([]mlxtendRule, error) {
+	reader := csv.NewReader(r)
+	header, err := reader.Read()
+	if err == io.EOF {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	columns := make(map[string]int, len(header))
+	for i, column := range header {
+		columns[strings.TrimSpace(column)] = i
+	}
+	for _, column := range []string{"rules", "confidence"} {
+		if _, ok := columns[column]; !ok {
+			return nil, fmt.Errorf("missing column %q", column)
+		}
+	}
+
+	var rules []mlxtendRule
+	for line := 2; ; line++ {
+		row, err := reader.Read()
+		if err == io.EOF {
+			return rules, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		antecedents, consequents, ok := parseArulesRule(row[columns["rules"]])
+		if !ok {
+			return nil, fmt.Errorf("line %d: invalid rule %q", line, row[columns["rules"]])
+		}
+		if len(antecedents) == 0 {
+			continue
+		}
+		rule := mlxtendRule{Antecedents: antecedents, Consequents: consequents}
+		statistics := []struct {
+			column string
+			value  *float64
+		}{
+			{"confidence", &rule.Confidence},
+			{"support", &rule.Support},
+			{"lift", &rule.Lift},
+			{"coverage", &rule.AntecedentSupport},
+		}
+		for _, statistic := range statistics {
+			*statistic.value = math.NaN()
+			i, ok := columns[statistic.column]
+			if !ok || strings.TrimSpace(row[i]) == "" || strings.TrimSpace(row[i]) == "NA" {
+				continue
+			}
+			if *statistic.value, err = strconv.ParseFloat(strings.TrimSpace(row[i]), 64); err != nil {
+				return nil, fmt.Errorf("line %d: invalid %s: %w", line, statistic.column, err)
+			}
+		}
+		rule.ConsequentSupport = rule.Confidence / rule.Lift
+		rules = append(rules, rule)
+	}
+}
+
+// Returns the antecedents and the consequents of an arules label, e.g. "{beer,nuts} => {jam}".
+func parseArulesRule(label string) (antecedents []string, consequents []string, ok bool) {
+	parts := strings.Split(label, "=>")
+	if len(parts) != 2 {
+		return nil, nil, false
+	}
+	lhs, rhs := strings.TrimSpace(parts[0]), strings.TrimSpace(parts[1])
+	if !strings.HasPrefix(lhs, "{") || !strings.HasSuffix(lhs, "}") || !strings.HasPrefix(rhs, "{") || !strings.HasSuffix(rhs, "}") {
+		return nil, nil, false
+	}
+	consequents = parseTransaction(rhs[1 : len(rhs)-1])
+
+	return parseTransaction(lhs[1 : len(lhs)-1]), consequents, len(consequents) > 0
+}
@@ -0,0 +1,51 @@
+package apriori
+
+import (
+	"bytes"
+	"math"
+	"strings"
+	"testing"
+)
+
+func TestWriteArulesCSV(t *testing.T) {
+	transactions := [][]string{
+		{"beer", "nuts"},
+		{"beer", "nuts"},
+		{"beer"},
+		{"jam"},
+	}
+	records := NewApriori(transactions).Calculate(NewOptions(0.5, 0, 0, 0))
+
+	var buffer bytes.Buffer
+	assert(WriteArulesCSV(&buffer, records) == nil, "Expected the CSV to be written")
+	expected := "rules,support,confidence,coverage,lift,count\n" +
+		"{beer} => {nuts},0.5,0.6666666666666666,0.75,1.3333333333333333,2\n" +
+		"{nuts} => {beer},0.5,1,0.5,1.3333333333333333,2\n"
+	assert(buffer.String() == expected, "Unexpected CSV: "+buffer.String())
+
+	rules, err := ParseRules(&buffer, RulesArules)
+	assert(err == nil, "Expected the CSV to be parsed")
+	assert(rules.Intersect(NewRuleSet(records)).Len() == 2, "Expected the mined rules")
+	rule := rules.GetRecords()[0].GetOrderedStatistic()[0]
+	assert(rule.GetBaseSupport() == 0.75 && rule.GetAddSupport() == 0.5, "Expected the supports of the base and add")
+}
+
+func TestParseRules_Arules(t *testing.T) {
+	// As written by write(rules, file, sep = ",") with the row names.
+	csv := `"","rules","support","confidence","coverage","lift","count"` + "\n" +
+		`"1","{} => {milk}",0.6,0.6,1,1,6` + "\n" +
+		`"2","{bread,butter} => {jam}",0.1,0.5,0.2,2,1` + "\n" +
+		`"3","{age=young} => {income=low}",0.3,0.75,0.4,NA,3` + "\n"
+	rules, err := ParseRules(strings.NewReader(csv), RulesArules)
+	assert(err == nil, "Expected the CSV to be parsed")
+	assert(rules.Len() == 2, "Expected the rule with an empty antecedent to be skipped")
+	rule := rules.GetRecords()[0].GetOrderedStatistic()[0]
+	assert(rule.String() == "{bread, butter} => {jam} conf=0.5 lift=2", "Unexpected rule: "+rule.String())
+	assert(rule.GetAddSupport() == 0.25, "Expected the support of the add to follow from the lift")
+	assert(math.IsNaN(rules.GetRecords()[1].GetOrderedStatistic()[0].GetLift()), "Expected an unknown lift")
+
+	_, err = ParseRules(strings.NewReader("rules,support\n{a} => {b},0.5\n"), RulesArules)
+	assert(err != nil && strings.Contains(err.Error(), "confidence"), "Expected a missing column error")
+	_, err = ParseRules(strings.NewReader("rules,confidence\na -> b,0.5\n"), RulesArules)
+	assert(err != nil && strings.Contains(err.Error(), "line 2"), "Expected an invalid rule error")
+}
@@ -0,0 +1,63 @@
+package apriori
+
+import (
+	"sort"
+	"strings"
+)
+
+// Separator between a numeric attribute and its interval in the items of Discretizer.Transform.
+const intervalSeparator = "∈"
+
+// EncodeRecords returns a transaction of "attribute=value" items for every structured record, e.g.
+// {"country": "RO", "device": "mobile"} becomes ["country=RO", "device=mobile"], so that rules between the
+// dimensions can be mined. The items are sorted by attribute and the empty values are left out.
+func EncodeRecords(records []map[string]string) [][]string {
+	transactions := make([][]string, len(records))
+	for i, record := range records {
+		attributes := make([]string, 0, len(record))
+		for attribute, value := range record {
+			if value != "" {
+				attributes = append(attributes, attribute)
+			}
+		}
+		sort.Strings(attributes)
+
+		transactions[i] = make([]string, len(attributes))
+		for j, attribute := range attributes {
+			transactions[i][j] = attribute + attributeSeparator + record[attribute]
+		}
+	}
+
+	return transactions
+}
+
+// ParseAttributeItem returns the attribute and the value of an "attribute=value" item, ok being false for the
+// items without an attribute. The interval items of the Discretizer, e.g. "age∈[30,40)", have the interval as value.
+func ParseAttributeItem(item string) (attribute string, value string, ok bool) {
+	i := strings.Index(item, attributeSeparator)
+	if j := strings.Index(item, intervalSeparator); j > 0 && (i < 0 || j < i) {
+		return item[:j], item[j+len(intervalSeparator):], true
+	}
+	if i <= 0 {
+		return "", "", false
+	}
+
+	return item[:i], item[i+len(attributeSeparator):], true
+}
+
+// Returns whether the items contain two values of the same attribute.
+func (a *Apriori) containsAttributePair(items []string) bool {
+	attributes := make(map[string]bool, len(items))
+	for _, item := range items {
+		attribute, _, ok := ParseAttributeItem(item)
+		if !ok {
+			continue
+		}
+		if attributes[attribute] {
+			return true
+		}
+		attributes[attribute] = true
+	}
+
+	return false
+}
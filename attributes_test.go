@@ -0,0 +1,48 @@
+package apriori
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestEncodeRecords(t *testing.T) {
+	transactions := EncodeRecords([]map[string]string{
+		{"country": "RO", "device": "mobile", "plan": "pro"},
+		{"country": "RO", "device": "desktop", "plan": ""},
+	})
+	assert(fmt.Sprint(transactions) == "[[country=RO device=mobile plan=pro] [country=RO device=desktop]]", "Unexpected transactions: "+fmt.Sprint(transactions))
+
+	for item, expected := range map[string]string{
+		"country=RO":    "country RO true",
+		"age∈[30,40)":   "age [30,40) true",
+		"formula=a=b":   "formula a=b true",
+		"beer":          "  false",
+		"=RO":           "  false",
+		"range=x∈[1,2)": "range x∈[1,2) true",
+	} {
+		attribute, value, ok := ParseAttributeItem(item)
+		assert(fmt.Sprint(attribute, " ", value, " ", ok) == expected, "Unexpected attribute of "+item)
+	}
+}
+
+func TestApriori_CalculateWithDistinctAttributes(t *testing.T) {
+	// The users have several interests.
+	transactions := [][]string{
+		{"country=RO", "interest=sports", "interest=music"},
+		{"country=RO", "interest=sports", "interest=music"},
+		{"country=DE", "interest=music"},
+	}
+	options := NewOptions(0.5, 0, 0, 0)
+	assert(len(NewApriori(transactions).FrequentItemsets(options)) == 7, "Expected the itemsets of the interests")
+
+	options.DistinctAttributes = true
+	var itemsets []string
+	for _, record := range NewApriori(transactions).FrequentItemsets(options) {
+		itemsets = append(itemsets, fmt.Sprint(record.GetItems()))
+	}
+	expected := "[[country=RO] [interest=music] [interest=sports] [country=RO interest=music] [country=RO interest=sports]]"
+	assert(fmt.Sprint(itemsets) == expected, "Unexpected itemsets: "+fmt.Sprint(itemsets))
+
+	options.Diffsets = true
+	assert(len(NewApriori(transactions).FrequentItemsets(options)) == 5, "Expected the same itemsets with diffsets")
+}
@@ -0,0 +1,131 @@
+// Package bench runs the frequent itemset backends on the same dataset and reports their runtime, memory and
+// results, verifying they all find the same itemsets
+package bench
+
+import (
+	"bytes"
+	"fmt"
+	"runtime"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/eMAGTechLabs/go-apriori"
+)
+
+// Backend is a way of mining the frequent itemsets of transactions
+type Backend struct {
+	Name string
+	Mine func(transactions [][]string, options apriori.Options) ([]apriori.SupportRecord, error)
+}
+
+// Result is the outcome of a backend on a dataset
+type Result struct {
+	Backend     string
+	Duration    time.Duration
+	Bytes       uint64 // Allocated while mining, including the index.
+	Allocations uint64
+	Itemsets    int
+	Matches     bool // Whether the backend found the same itemsets and counts as the first one.
+	Err         error
+}
+
+// String formats the result as a report line
+func (r Result) String() string {
+	if r.Err != nil {
+		return fmt.Sprintf("%-12s error: %v", r.Backend, r.Err)
+	}
+
+	return fmt.Sprintf("%-12s %12v %12d B %10d allocs %8d itemsets  matches: %v", r.Backend, r.Duration, r.Bytes, r.Allocations, r.Itemsets, r.Matches)
+}
+
+// DefaultBackends returns the level-wise Apriori, the partitioned (SON) mining in 4 chunks, the dynamic itemset
+// counting (DIC) in blocks of a tenth of the transactions and the sampling (Toivonen) of half the transactions.
+// The partitioned and DIC backends read the transactions as comma separated lines, so their items must not
+// contain commas.
+func DefaultBackends() []Backend {
+	return []Backend{
+		{"apriori", func(transactions [][]string, options apriori.Options) ([]apriori.SupportRecord, error) {
+			return apriori.NewApriori(transactions).FrequentItemsets(options), nil
+		}},
+		{"partitioned", func(transactions [][]string, options apriori.Options) ([]apriori.SupportRecord, error) {
+			return apriori.PartitionedFrequentItemsets(lines(transactions), len(transactions)/4+1, options)
+		}},
+		{"dic", func(transactions [][]string, options apriori.Options) ([]apriori.SupportRecord, error) {
+			return apriori.DICFrequentItemsets(lines(transactions), len(transactions)/10+1, options)
+		}},
+		{"sampled", func(transactions [][]string, options apriori.Options) ([]apriori.SupportRecord, error) {
+			records, _, err := apriori.NewApriori(transactions).SampledFrequentItemsets(len(transactions)/2+1, 0.8, 1, options)
+			return records, err
+		}},
+	}
+}
+
+// Returns a reader of the transactions as comma separated lines.
+func lines(transactions [][]string) *bytes.Reader {
+	var buffer bytes.Buffer
+	for _, transaction := range transactions {
+		buffer.WriteString(strings.Join(transaction, ",") + "\n")
+	}
+
+	return bytes.NewReader(buffer.Bytes())
+}
+
+// Run mines the transactions with every backend, one after the other, and compares their itemsets with the ones
+// of the first backend
+func Run(transactions [][]string, options apriori.Options, backends []Backend) []Result {
+	results := make([]Result, len(backends))
+	var reference map[string]int64
+	for i, backend := range backends {
+		results[i].Backend = backend.Name
+
+		var before, after runtime.MemStats
+		runtime.GC()
+		runtime.ReadMemStats(&before)
+		start := time.Now()
+		records, err := backend.Mine(transactions, options)
+		results[i].Duration = time.Since(start)
+		runtime.ReadMemStats(&after)
+
+		if err != nil {
+			results[i].Err = err
+			continue
+		}
+		results[i].Bytes = after.TotalAlloc - before.TotalAlloc
+		results[i].Allocations = after.Mallocs - before.Mallocs
+		results[i].Itemsets = len(records)
+
+		counts := supportCounts(records)
+		if reference == nil {
+			reference = counts
+		}
+		results[i].Matches = equal(reference, counts)
+	}
+
+	return results
+}
+
+// Returns the support counts of the itemsets by their sorted items.
+func supportCounts(records []apriori.SupportRecord) map[string]int64 {
+	counts := make(map[string]int64, len(records))
+	for _, record := range records {
+		items := append([]string{}, record.GetItems()...)
+		sort.Strings(items)
+		counts[strings.Join(items, "\x00")] = record.GetSupportCount()
+	}
+
+	return counts
+}
+
+func equal(a, b map[string]int64) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for key, count := range a {
+		if other, ok := b[key]; !ok || other != count {
+			return false
+		}
+	}
+
+	return true
+}
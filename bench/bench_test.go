@@ -0,0 +1,45 @@
+package bench
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/eMAGTechLabs/go-apriori"
+	"github.com/eMAGTechLabs/go-apriori/datagen"
+)
+
+func TestRun(t *testing.T) {
+	config := datagen.NewConfig(5, 2, 500)
+	config.Items = 50
+	config.Patterns = 20
+	transactions, err := datagen.Generate(config)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	backends := append(DefaultBackends(), Backend{"broken", func([][]string, apriori.Options) ([]apriori.SupportRecord, error) {
+		return nil, errors.New("out of memory")
+	}}, Backend{"empty", func([][]string, apriori.Options) ([]apriori.SupportRecord, error) {
+		return nil, nil
+	}})
+	results := Run(transactions, apriori.NewOptions(0.05, 0, 0, 0), backends)
+	if len(results) != len(backends) {
+		t.Fatalf("unexpected number of results %d", len(results))
+	}
+	for _, result := range results[:3] {
+		if result.Err != nil || !result.Matches || result.Itemsets == 0 {
+			t.Fatalf("unexpected result %v", result)
+		}
+	}
+	// The sampling may miss itemsets, which is what the comparison is for.
+	if results[3].Err != nil {
+		t.Fatal(results[3].Err)
+	}
+	if results[4].Err == nil || !strings.Contains(results[4].String(), "out of memory") {
+		t.Fatalf("expected the error to be reported, got %v", results[4])
+	}
+	if results[5].Matches {
+		t.Fatal("expected a backend missing the itemsets not to match")
+	}
+}
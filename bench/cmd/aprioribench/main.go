@@ -0,0 +1,50 @@
+// Command aprioribench compares the frequent itemset backends on a synthetic (Quest) dataset or an SPMF file:
+//
+//	aprioribench -t 10 -i 4 -d 100000 -support 0.01
+//	aprioribench -file T10I4D100K.dat -support 0.01
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"os"
+
+	"github.com/eMAGTechLabs/go-apriori"
+	"github.com/eMAGTechLabs/go-apriori/bench"
+	"github.com/eMAGTechLabs/go-apriori/datagen"
+)
+
+func main() {
+	file := flag.String("file", "", "SPMF transaction file, a synthetic dataset is generated when empty")
+	avgTransactionSize := flag.Float64("t", 10, "average transaction size of the synthetic dataset")
+	avgPatternSize := flag.Float64("i", 4, "average pattern size of the synthetic dataset")
+	transactionNo := flag.Int("d", 10000, "number of transactions of the synthetic dataset")
+	seed := flag.Int64("seed", 1, "seed of the synthetic dataset")
+	minSupport := flag.Float64("support", 0.01, "minimum support")
+	maxLength := flag.Int("length", 0, "maximum itemset length, 0 for none")
+	flag.Parse()
+
+	var transactions [][]string
+	var err error
+	if *file != "" {
+		var f *os.File
+		if f, err = os.Open(*file); err != nil {
+			log.Fatal(err)
+		}
+		transactions, err = apriori.ReadSPMF(f)
+		f.Close()
+	} else {
+		config := datagen.NewConfig(*avgTransactionSize, *avgPatternSize, *transactionNo)
+		config.Seed = *seed
+		transactions, err = datagen.Generate(config)
+	}
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	fmt.Printf("%d transactions, min support %v\n", len(transactions), *minSupport)
+	for _, result := range bench.Run(transactions, apriori.NewOptions(*minSupport, 0, 0, *maxLength), bench.DefaultBackends()) {
+		fmt.Println(result)
+	}
+}
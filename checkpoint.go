@@ -0,0 +1,170 @@
+package apriori
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// Version of the checkpoint files, the files of other versions aren't resumed from.
+const checkpointVersion = 1
+
+// checkpointFile is the mining state saved before every level of candidates.
+type checkpointFile struct {
+	Version      int                `json:"version"`
+	Fingerprint  string             `json:"fingerprint"`
+	Transactions int64              `json:"transactions"`
+	Length       int                `json:"length"`
+	Candidates   [][]string         `json:"candidates"`
+	Records      []checkpointRecord `json:"records"`
+}
+
+// checkpointRecord is a support record emitted before the checkpoint.
+type checkpointRecord struct {
+	Items          []string `json:"items"`
+	Support        float64  `json:"support"`
+	SupportCount   int64    `json:"supportCount"`
+	AllConfidence  float64  `json:"allConfidence"`
+	TransactionIDs []int64  `json:"transactionIDs,omitempty"`
+}
+
+// checkpoint saves the state of a mining run to its file and holds the state to resume from. Its methods do nothing
+// on a nil checkpoint, i.e. when checkpointing isn't enabled.
+type checkpoint struct {
+	path    string
+	state   checkpointFile
+	resumed bool
+	cancel  func() // Stops the run when the state can't be saved.
+	err     error
+}
+
+// Opens the checkpoint of the options, resuming from its file when there is one. A file written by a run with other
+// transactions or options is rejected.
+func (a *Apriori) openCheckpoint(options Options, cancel func()) (*checkpoint, error) {
+	if options.CheckpointPath == "" {
+		return nil, nil
+	}
+
+	c := &checkpoint{
+		path:   options.CheckpointPath,
+		state:  checkpointFile{Version: checkpointVersion, Fingerprint: options.fingerprint(), Transactions: a.transactionNo},
+		cancel: cancel,
+	}
+	data, err := os.ReadFile(c.path)
+	if errors.Is(err, os.ErrNotExist) {
+		return c, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var saved checkpointFile
+	if err := json.Unmarshal(data, &saved); err != nil {
+		return nil, fmt.Errorf("invalid checkpoint %s: %w", c.path, err)
+	}
+	if saved.Version != checkpointVersion {
+		return nil, fmt.Errorf("unsupported checkpoint version %d", saved.Version)
+	}
+	if saved.Fingerprint != c.state.Fingerprint || saved.Transactions != c.state.Transactions {
+		return nil, fmt.Errorf("checkpoint %s was saved by a run with other transactions or options", c.path)
+	}
+	c.state = saved
+	c.resumed = true
+
+	return c, nil
+}
+
+// Returns the records emitted before the checkpoint, the length of the candidates and the candidates to resume
+// from, ok being false when there is nothing to resume.
+func (c *checkpoint) resume() (records []SupportRecord, length int, candidates [][]string, ok bool) {
+	if c == nil || !c.resumed {
+		return nil, 0, nil, false
+	}
+	for _, record := range c.state.Records {
+		records = append(records, SupportRecord{record.Items, record.Support, record.SupportCount, record.AllConfidence, record.TransactionIDs})
+	}
+
+	return records, c.state.Length, c.state.Candidates, true
+}
+
+// Adds an emitted record to the state.
+func (c *checkpoint) record(record SupportRecord) {
+	if c == nil {
+		return
+	}
+	c.state.Records = append(c.state.Records, checkpointRecord{record.items, record.support, record.supportCount, record.allConfidence, record.transactionIDs})
+}
+
+// Saves the state before counting the candidates of the given length. The file is replaced atomically, so a crash
+// while saving leaves the previous checkpoint. When it fails the run is stopped and false is returned.
+func (c *checkpoint) save(length int, candidates [][]string) bool {
+	if c == nil {
+		return true
+	}
+	c.state.Length = length
+	c.state.Candidates = candidates
+
+	c.err = c.write()
+	if c.err != nil {
+		c.cancel()
+		return false
+	}
+
+	return true
+}
+
+// Writes the state to a temporary file renamed to the checkpoint file.
+func (c *checkpoint) write() error {
+	data, err := json.Marshal(c.state)
+	if err != nil {
+		return err
+	}
+	file, err := os.CreateTemp(filepath.Dir(c.path), filepath.Base(c.path)+".*.tmp")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(file.Name())
+	if _, err := file.Write(data); err != nil {
+		file.Close()
+		return err
+	}
+	if err := file.Sync(); err != nil {
+		file.Close()
+		return err
+	}
+	if err := file.Close(); err != nil {
+		return err
+	}
+
+	return os.Rename(file.Name(), c.path)
+}
+
+// Removes the checkpoint file once all the itemsets are found, so the next run starts over.
+func (c *checkpoint) finish() {
+	if c == nil {
+		return
+	}
+	if err := os.Remove(c.path); err != nil && !errors.Is(err, os.ErrNotExist) {
+		c.err = err
+		c.cancel()
+	}
+}
+
+// Returns the error that stopped the run, if any.
+func (c *checkpoint) error() error {
+	if c == nil {
+		return nil
+	}
+
+	return c.err
+}
+
+// Returns a fingerprint of the options deciding which itemsets are found, so that a checkpoint isn't resumed by a
+// run finding other ones.
+func (options Options) fingerprint() string {
+	return fmt.Sprintf("%v %v %v %v %v %v %v %v %v %v %v %v %v",
+		options.minSupport, options.maxLength, options.MinLength, options.MaxSupport, options.Consequent, options.NegatedItemsMinSupport,
+		options.MinAllConfidence, options.KeepTransactionIDs, options.MaxTransactionIDs, options.IncludeItems,
+		options.ExcludeItems, options.Taxonomy, options.MinSupportByLength)
+}
@@ -0,0 +1,66 @@
+package apriori
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestApriori_CalculateWithCheckpoint(t *testing.T) {
+	transactions := [][]string{
+		{"beer", "nuts", "cheese"},
+		{"beer", "nuts", "jam"},
+		{"beer", "nuts", "cheese"},
+		{"nuts", "cheese"},
+	}
+	a := NewApriori(transactions)
+	path := filepath.Join(t.TempDir(), "mining.checkpoint")
+	options, _ := NewOptionsWith(WithMinSupport(0.5), WithCheckpoint(path))
+	uncheckpointed := options
+	uncheckpointed.CheckpointPath = ""
+	expected := formatRecords(a.Calculate(uncheckpointed))
+
+	// The run is interrupted while counting the pairs, after the checkpoint of their level.
+	ctx, cancel := context.WithCancel(context.Background())
+	interrupted := options
+	interrupted.OnItemset = func(record SupportRecord) {
+		if len(record.items) == 2 {
+			cancel()
+		}
+	}
+	_, err := a.CalculateContext(ctx, interrupted)
+	assert(err == context.Canceled, "Expected the run to be interrupted")
+	data, err := os.ReadFile(path)
+	assert(err == nil, "Expected a checkpoint")
+	var saved checkpointFile
+	assert(json.Unmarshal(data, &saved) == nil, "Expected a valid checkpoint")
+	assert(saved.Length == 2 && len(saved.Candidates) == 3 && len(saved.Records) == 3, "Unexpected checkpoint: "+string(data))
+
+	// The resumed run finds the same results, and removes the checkpoint.
+	assert(formatRecords(a.Calculate(options)) == expected, "Expected the results of an uninterrupted run")
+	_, err = os.Stat(path)
+	assert(os.IsNotExist(err), "Expected the checkpoint to be removed")
+
+	// The saved candidates are used, none being left only the single items are found.
+	saved.Candidates = nil
+	data, _ = json.Marshal(saved)
+	os.WriteFile(path, data, 0o644)
+	assert(len(a.FrequentItemsets(options)) == 3, "Expected the single items of the checkpoint")
+
+	// A checkpoint of other options isn't resumed from.
+	os.WriteFile(path, data, 0o644)
+	other, _ := NewOptionsWith(WithMinSupport(0.25), WithCheckpoint(path))
+	_, err = a.CalculateContext(context.Background(), other)
+	assert(err != nil, "Expected an error for a checkpoint of other options")
+	os.Remove(path)
+
+	// A checkpoint that can't be saved stops the run.
+	unsaved, _ := NewOptionsWith(WithMinSupport(0.5), WithCheckpoint(filepath.Join(path, "missing", "checkpoint")))
+	_, err = a.CalculateContext(context.Background(), unsaved)
+	assert(err != nil && err != context.Canceled, "Expected an error saving the checkpoint")
+
+	_, err = NewOptionsWith(WithMinSupport(0.5), WithCheckpoint(path), WithDiffsets())
+	assert(err != nil, "Expected diffsets to be rejected")
+}
@@ -0,0 +1,206 @@
+package apriori
+
+import (
+	"context"
+	"errors"
+	"sort"
+)
+
+// Prefix of the items that carry the class labels while mining, it keeps them apart from the basket items.
+const classItemPrefix = "\x01class="
+
+// ClassRule is a class association rule: a basket containing the base items belongs to the class
+type ClassRule struct {
+	base       []string
+	class      string
+	support    float64
+	confidence float64
+}
+
+// GetBase will return the base items of the class rule
+func (cr ClassRule) GetBase() []string {
+	return cr.base
+}
+
+// GetClass will return the class predicted by the rule
+func (cr ClassRule) GetClass() string {
+	return cr.class
+}
+
+// GetSupport will return the support of the base items together with the class
+func (cr ClassRule) GetSupport() float64 {
+	return cr.support
+}
+
+// GetConfidence will return the confidence of the class rule
+func (cr ClassRule) GetConfidence() float64 {
+	return cr.confidence
+}
+
+// Classifier is a CBA (Classification Based on Associations) classifier: an ordered list of class rules and
+// a default class for the baskets that none of them matches
+type Classifier struct {
+	rules        []ClassRule
+	defaultClass string
+}
+
+// TrainClassifier mines the class association rules of the labeled transactions, orders them by confidence,
+// support and base length and keeps the ones selected by the CBA database coverage, along with a default class.
+// The consequent of the options is replaced by each class in turn, every other option applies to the mining.
+func TrainClassifier(transactions [][]string, labels []string, options Options) (*Classifier, error) {
+	if len(transactions) != len(labels) {
+		return nil, errors.New("every transaction must have a label")
+	}
+	if len(transactions) == 0 {
+		return nil, errors.New("at least one transaction is needed")
+	}
+	// The options are checked with a consequent, like the ones of every class, so the conflicting ones are rejected.
+	options.Consequent = []string{classItemPrefix + labels[0]}
+	if err := options.check(); err != nil {
+		return nil, err
+	}
+
+	labeled := make([][]string, len(transactions))
+	for i, transaction := range transactions {
+		labeled[i] = append(append([]string{}, transaction...), classItemPrefix+labels[i])
+	}
+	a := NewApriori(labeled)
+
+	// The rules are needed here, not by the callbacks.
+	options.OnItemset, options.OnRule = nil, nil
+	var rules []ClassRule
+	for _, class := range a.normalizeItems(labels) {
+		options.Consequent = []string{classItemPrefix + class}
+		records, err := a.CalculateContext(context.Background(), options)
+		if err != nil {
+			return nil, err
+		}
+		for _, record := range records {
+			for _, orderedStatistic := range record.orderedStatistic {
+				if len(orderedStatistic.base) == 0 {
+					continue
+				}
+				rules = append(rules, ClassRule{
+					base:       orderedStatistic.base,
+					class:      class,
+					support:    record.supportRecord.support,
+					confidence: orderedStatistic.confidence,
+				})
+			}
+		}
+	}
+	sort.SliceStable(rules, func(i, j int) bool {
+		if rules[i].confidence != rules[j].confidence {
+			return rules[i].confidence > rules[j].confidence
+		}
+		if rules[i].support != rules[j].support {
+			return rules[i].support > rules[j].support
+		}
+		return len(rules[i].base) < len(rules[j].base)
+	})
+
+	return newClassifier(rules, transactions, labels), nil
+}
+
+// Selects the rules with the CBA database coverage: a rule is kept when it correctly classifies at least one of
+// the transactions not covered yet, and the list is cut where the rules plus the default class make the fewest
+// errors on the training transactions.
+func newClassifier(rules []ClassRule, transactions [][]string, labels []string) *Classifier {
+	var a Apriori
+	covered := make([]bool, len(transactions))
+	bestClassifier := &Classifier{defaultClass: majorityClass(labels, covered)}
+	bestErrors := countErrors(labels, covered, bestClassifier.defaultClass)
+
+	var selected []ClassRule
+	ruleErrors := 0
+	for _, rule := range rules {
+		var matches []int
+		correct := false
+		for i, transaction := range transactions {
+			if covered[i] || !a.isSubset(rule.base, [][]string{transaction}) {
+				continue
+			}
+			matches = append(matches, i)
+			correct = correct || labels[i] == rule.class
+		}
+		if !correct {
+			continue
+		}
+
+		for _, i := range matches {
+			covered[i] = true
+			if labels[i] != rule.class {
+				ruleErrors++
+			}
+		}
+		selected = append(selected, rule)
+
+		defaultClass := majorityClass(labels, covered)
+		if total := ruleErrors + countErrors(labels, covered, defaultClass); total < bestErrors {
+			bestErrors = total
+			bestClassifier = &Classifier{rules: append([]ClassRule{}, selected...), defaultClass: defaultClass}
+		}
+	}
+
+	return bestClassifier
+}
+
+// Returns the most frequent label among the transactions not covered yet, or among all of them when every
+// transaction is covered. Ties are broken alphabetically.
+func majorityClass(labels []string, covered []bool) string {
+	counts := make(map[string]int)
+	for i, label := range labels {
+		if !covered[i] {
+			counts[label]++
+		}
+	}
+	if len(counts) == 0 {
+		for _, label := range labels {
+			counts[label]++
+		}
+	}
+
+	// "" may be a label, found tells whether the majority is set.
+	majority, found := "", false
+	for label, count := range counts {
+		if !found || count > counts[majority] || (count == counts[majority] && label < majority) {
+			majority, found = label, true
+		}
+	}
+
+	return majority
+}
+
+// Returns the number of transactions not covered yet that don't belong to the default class.
+func countErrors(labels []string, covered []bool, defaultClass string) int {
+	count := 0
+	for i, label := range labels {
+		if !covered[i] && label != defaultClass {
+			count++
+		}
+	}
+
+	return count
+}
+
+// Predict returns the class of the first rule whose base is contained in the basket, or the default class
+func (c *Classifier) Predict(basket []string) string {
+	var a Apriori
+	for _, rule := range c.rules {
+		if a.isSubset(rule.base, [][]string{basket}) {
+			return rule.class
+		}
+	}
+
+	return c.defaultClass
+}
+
+// Rules returns the ordered class rules used by the classifier
+func (c *Classifier) Rules() []ClassRule {
+	return c.rules
+}
+
+// DefaultClass returns the class predicted for the baskets that no rule matches
+func (c *Classifier) DefaultClass() string {
+	return c.defaultClass
+}
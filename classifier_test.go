@@ -0,0 +1,56 @@
+package apriori
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestTrainClassifier(t *testing.T) {
+	transactions := [][]string{
+		{"beer", "nuts"},
+		{"beer", "chips"},
+		{"beer", "nuts", "chips"},
+		{"juice", "candy"},
+		{"juice", "chips"},
+		{"candy"},
+		{"bread"},
+	}
+	labels := []string{"adult", "adult", "adult", "kid", "kid", "kid", "adult"}
+
+	classifier, err := TrainClassifier(transactions, labels, NewOptions(0.2, 0.8, 0.0, 0))
+	assert(err == nil, "Expected the classifier to be trained")
+
+	var rules []string
+	for _, rule := range classifier.Rules() {
+		rules = append(rules, fmt.Sprintf("%v => %v", rule.GetBase(), rule.GetClass()))
+	}
+	assert(fmt.Sprint(rules) == "[[beer] => adult [candy] => kid [juice] => kid]", "Unexpected class rules")
+	assert(classifier.DefaultClass() == "adult", "Unexpected default class")
+
+	provider := []struct {
+		basket []string
+		class  string
+	}{
+		{[]string{"beer", "candy"}, "adult"},
+		{[]string{"juice"}, "kid"},
+		{[]string{"water"}, "adult"},
+	}
+	for _, data := range provider {
+		assert(classifier.Predict(data.basket) == data.class, "Unexpected predicted class")
+	}
+
+	_, err = TrainClassifier(transactions, labels[1:], NewOptions(0.2, 0.8, 0.0, 0))
+	assert(err != nil, "Expected an error for missing labels")
+
+	for i := 0; i < 10; i++ {
+		// The map of the counts is iterated in a random order.
+		assert(majorityClass([]string{"", "", "adult", "kid"}, make([]bool, 4)) == "", "Expected the empty label to be the majority")
+	}
+
+	for _, opt := range []Option{WithDiffsets(), WithRuleShape(0, 2)} {
+		options := NewOptions(0.2, 0.8, 0.0, 0)
+		opt(&options)
+		_, err = TrainClassifier(transactions, labels, options)
+		assert(err != nil, "Expected an error for options conflicting with the classes")
+	}
+}
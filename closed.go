@@ -0,0 +1,119 @@
+package apriori
+
+import "math"
+
+// ClosedItemsets returns the closed itemsets among previously mined frequent itemsets, the ones without a superset
+// of the same support. They keep the supports of all the frequent itemsets, each one having the support of its
+// smallest closed superset, with a lot fewer itemsets when the items are correlated. The itemsets must be all the
+// frequent ones, as returned by FrequentItemsets without a minimum length, the order is kept.
+func ClosedItemsets(itemsets []SupportRecord) []SupportRecord {
+	closed, _ := closedAndGenerators(itemsets)
+
+	return closed
+}
+
+// Generators returns the generators (free itemsets) among previously mined frequent itemsets, the minimal ones of
+// their closure: those without a subset of the same support. The single items contained in all the transactions
+// aren't generators, as they have the support of the empty itemset. The itemsets must be all the frequent ones, as
+// returned by FrequentItemsets without a minimum length, the order is kept.
+func Generators(itemsets []SupportRecord) []SupportRecord {
+	_, generators := closedAndGenerators(itemsets)
+
+	return generators
+}
+
+// GenerateBasisRules derives the min-max basis of the rules from previously mined frequent itemsets: a rule
+// generator => closed itemset \ generator for every generator and every closed itemset strictly containing it, with
+// a confidence of at least minConfidence. The rules of confidence 1, to the closure of the generator, form the exact
+// basis and the other ones the approximate basis; all the rules of the itemsets can be derived from them, with their
+// supports and confidences. The rules are grouped by closed itemset, the order of the itemsets is kept and the
+// supports of the adds are looked up in the itemsets.
+func GenerateBasisRules(itemsets []SupportRecord, minConfidence float64) []RelationRecord {
+	var a Apriori
+	supports := make(map[string]float64, len(itemsets))
+	for _, itemset := range itemsets {
+		supports[itemsetKey(a.normalizeItems(itemset.items))] = itemset.support
+		if itemset.supportCount > 0 && itemset.support > 0 {
+			a.transactionNo = int64(math.Round(float64(itemset.supportCount) / itemset.support))
+		}
+	}
+	closed, generators := closedAndGenerators(itemsets)
+
+	var relationRecords []RelationRecord
+	for _, itemset := range closed {
+		items := a.normalizeItems(itemset.items)
+		set := make(map[string]bool, len(items))
+		for _, item := range items {
+			set[item] = true
+		}
+
+		var orderedStatistics []OrderedStatistic
+		for _, generator := range generators {
+			base := a.normalizeItems(generator.items)
+			if len(base) >= len(items) || !containsAll(set, base) {
+				continue
+			}
+			add := a.itemDifference(items, base)
+			supportForAdd, ok := supports[itemsetKey(add)]
+			if !ok {
+				continue
+			}
+			orderedStatistic := newOrderedStatistic(base, add, itemset.support, generator.support, supportForAdd, a.transactionNo)
+			if orderedStatistic.confidence < minConfidence {
+				continue
+			}
+			orderedStatistics = append(orderedStatistics, orderedStatistic)
+		}
+		if len(orderedStatistics) == 0 {
+			continue
+		}
+		relationRecords = append(relationRecords, RelationRecord{SupportRecord{items, itemset.support, itemset.supportCount, itemset.allConfidence, itemset.transactionIDs}, orderedStatistics})
+	}
+
+	return relationRecords
+}
+
+// Returns the closed itemsets and the generators of the frequent itemsets. By the anti-monotony of the supports it's
+// enough to compare every itemset with its subsets one item shorter.
+func closedAndGenerators(itemsets []SupportRecord) (closed []SupportRecord, generators []SupportRecord) {
+	var a Apriori
+	indexes := make(map[string]int, len(itemsets))
+	for i, itemset := range itemsets {
+		indexes[itemsetKey(a.normalizeItems(itemset.items))] = i
+	}
+
+	notClosed := make([]bool, len(itemsets))
+	notGenerator := make([]bool, len(itemsets))
+	for i, itemset := range itemsets {
+		items := a.normalizeItems(itemset.items)
+		if len(items) == 1 && sameSupport(itemset.support, 1) {
+			notGenerator[i] = true
+		}
+		if len(items) < 2 {
+			continue
+		}
+		for _, subset := range a.generateCandidateCombinations(items, len(items)-1) {
+			j, ok := indexes[itemsetKey(subset)]
+			if ok && sameSupport(itemsets[j].support, itemset.support) {
+				notClosed[j] = true
+				notGenerator[i] = true
+			}
+		}
+	}
+
+	for i, itemset := range itemsets {
+		if !notClosed[i] {
+			closed = append(closed, itemset)
+		}
+		if !notGenerator[i] {
+			generators = append(generators, itemset)
+		}
+	}
+
+	return closed, generators
+}
+
+// Returns whether two supports are equal, they may be computed from differently ordered sums of weights.
+func sameSupport(first float64, second float64) bool {
+	return math.Abs(first-second) <= confidenceEpsilon
+}
@@ -0,0 +1,46 @@
+package apriori
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestClosedItemsetsAndGenerators(t *testing.T) {
+	transactions := [][]string{
+		{"a", "b", "c"},
+		{"a", "b"},
+		{"a", "c"},
+		{"a"},
+	}
+	itemsets := NewApriori(transactions).FrequentItemsets(NewOptions(0.1, 0.0, 0.0, 0))
+
+	var closed []string
+	for _, record := range ClosedItemsets(itemsets) {
+		closed = append(closed, fmt.Sprint(record.GetItems()))
+	}
+	assert(fmt.Sprint(closed) == "[[a] [a b] [a c] [a b c]]", fmt.Sprint("Unexpected closed itemsets ", closed))
+
+	var generators []string
+	for _, record := range Generators(itemsets) {
+		generators = append(generators, fmt.Sprint(record.GetItems()))
+	}
+	assert(fmt.Sprint(generators) == "[[b] [c] [b c]]", fmt.Sprint("Unexpected generators ", generators))
+}
+
+func TestGenerateBasisRules(t *testing.T) {
+	transactions := [][]string{
+		{"a", "b", "c"},
+		{"a", "b"},
+		{"a", "c"},
+		{"a"},
+	}
+	itemsets := NewApriori(transactions).FrequentItemsets(NewOptions(0.1, 0.0, 0.0, 0))
+
+	result := formatRecords(GenerateBasisRules(itemsets, 0.0))
+	expected := "[{{[a b] 0.5} [{[b] [a] 1 1}]} {{[a c] 0.5} [{[c] [a] 1 1}]} {{[a b c] 0.25} [{[b] [a c] 0.5 1} {[c] [a b] 0.5 1} {[b c] [a] 1 1}]}]"
+	assert(result == expected, fmt.Sprint("Unexpected basis rules ", result))
+
+	result = formatRecords(GenerateBasisRules(itemsets, 0.9))
+	expected = "[{{[a b] 0.5} [{[b] [a] 1 1}]} {{[a c] 0.5} [{[c] [a] 1 1}]} {{[a b c] 0.25} [{[b c] [a] 1 1}]}]"
+	assert(result == expected, fmt.Sprint("Unexpected exact basis rules ", result))
+}
@@ -0,0 +1,64 @@
+package apriori
+
+import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"io"
+	"sync"
+)
+
+type decompressor struct {
+	magic []byte
+	open  func(r io.Reader) (io.Reader, error)
+}
+
+var (
+	decompressorsMu sync.RWMutex
+	decompressors   = []decompressor{
+		{[]byte{0x1f, 0x8b}, func(r io.Reader) (io.Reader, error) { return gzip.NewReader(r) }},
+	}
+)
+
+// RegisterDecompressor makes the file loaders decompress the data starting with the magic bytes, e.g. the
+// apriorizstd package registers zstd. Gzip is supported out of the box.
+func RegisterDecompressor(magic []byte, open func(r io.Reader) (io.Reader, error)) {
+	decompressorsMu.Lock()
+	defer decompressorsMu.Unlock()
+
+	decompressors = append(decompressors, decompressor{append([]byte{}, magic...), open})
+}
+
+// Decompress returns a reader of the decompressed data when the data is compressed with a registered format, the
+// data as it is otherwise. The format is detected from the magic bytes, not the file name, so the loaders
+// (ReadSPMF, ReadSPMFRules, ReadARFF, NewAprioriFromLongCSV and PartitionedFrequentItemsets) read gzip files, and
+// zstd ones once apriorizstd is imported, transparently.
+func Decompress(r io.Reader) (io.Reader, error) {
+	decompressorsMu.RLock()
+	defer decompressorsMu.RUnlock()
+
+	buffered := bufio.NewReader(r)
+	for _, d := range decompressors {
+		magic, err := buffered.Peek(len(d.magic))
+		if err != nil && err != io.EOF {
+			return nil, err
+		}
+		if bytes.Equal(magic, d.magic) {
+			return d.open(buffered)
+		}
+	}
+
+	return buffered, nil
+}
+
+// Maximum length of a line read by the loaders, wide baskets of sparse formats can take far more than the default
+// 64 KB of a bufio.Scanner.
+const maxLineLength = 1 << 30
+
+// Returns a scanner of the lines of the data, see maxLineLength.
+func newLineScanner(r io.Reader) *bufio.Scanner {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), maxLineLength)
+
+	return scanner
+}
@@ -0,0 +1,36 @@
+package apriori
+
+import (
+	"bytes"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"strings"
+	"testing"
+)
+
+func TestDecompress(t *testing.T) {
+	var compressed bytes.Buffer
+	writer := gzip.NewWriter(&compressed)
+	writer.Write([]byte("beer, nuts\nbeer, jam\nbeer\n"))
+	writer.Close()
+
+	records, err := PartitionedFrequentItemsets(bytes.NewReader(compressed.Bytes()), 2, NewOptions(0.5, 0, 0, 0))
+	assert(err == nil, "Expected the gzip file to be mined")
+	result := fmt.Sprint(len(records), records[0].GetItems(), records[0].GetSupportCount())
+	assert(result == "1 [beer] 3", "Unexpected itemsets: "+result)
+
+	transactions, err := ReadSPMF(strings.NewReader("1 2\n"))
+	assert(err == nil && fmt.Sprint(transactions) == "[[1 2]]", "Expected uncompressed data to be read as it is")
+
+	RegisterDecompressor([]byte("UPPER:"), func(r io.Reader) (io.Reader, error) {
+		data, err := io.ReadAll(r)
+		return strings.NewReader(strings.ToUpper(strings.TrimPrefix(string(data), "UPPER:"))), err
+	})
+	decompressed, _ := Decompress(strings.NewReader("UPPER:beer"))
+	data, _ := io.ReadAll(decompressed)
+	assert(string(data) == "BEER", "Expected the registered decompressor to be used: "+string(data))
+
+	_, err = Decompress(bytes.NewReader([]byte{0x1f, 0x8b, 0}))
+	assert(err != nil, "Expected an error for a truncated gzip header")
+}
@@ -0,0 +1,98 @@
+package apriori
+
+import "math"
+
+// RuleCondition is a condition on a rule. Conditions are combined with All and Any into composite filters,
+// e.g. Any(LiftAtLeast(1.2), ConvictionAtLeast(1.5)), and used as Options.RuleFilter. The minimum confidence and
+// lift of the options still apply to all the rules, they can be set to 0 to leave the filtering to the conditions.
+type RuleCondition func(OrderedStatistic) bool
+
+// All returns a condition met when all the conditions are met
+func All(conditions ...RuleCondition) RuleCondition {
+	return func(orderedStatistic OrderedStatistic) bool {
+		for _, condition := range conditions {
+			if !condition(orderedStatistic) {
+				return false
+			}
+		}
+		return true
+	}
+}
+
+// Any returns a condition met when at least one of the conditions is met
+func Any(conditions ...RuleCondition) RuleCondition {
+	return func(orderedStatistic OrderedStatistic) bool {
+		for _, condition := range conditions {
+			if condition(orderedStatistic) {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+// Not returns a condition met when the condition isn't
+func Not(condition RuleCondition) RuleCondition {
+	return func(orderedStatistic OrderedStatistic) bool {
+		return !condition(orderedStatistic)
+	}
+}
+
+// ConfidenceAtLeast returns a condition met by the rules with at least this confidence
+func ConfidenceAtLeast(minConfidence float64) RuleCondition {
+	return func(orderedStatistic OrderedStatistic) bool {
+		return orderedStatistic.confidence >= minConfidence
+	}
+}
+
+// LiftAtLeast returns a condition met by the rules with at least this lift
+func LiftAtLeast(minLift float64) RuleCondition {
+	return func(orderedStatistic OrderedStatistic) bool {
+		return orderedStatistic.lift >= minLift
+	}
+}
+
+// ConvictionAtLeast returns a condition met by the rules with at least this conviction
+func ConvictionAtLeast(minConviction float64) RuleCondition {
+	return func(orderedStatistic OrderedStatistic) bool {
+		return orderedStatistic.GetConviction() >= minConviction
+	}
+}
+
+// KulczynskiAtLeast returns a condition met by the rules with at least this Kulczynski measure
+func KulczynskiAtLeast(minKulczynski float64) RuleCondition {
+	return func(orderedStatistic OrderedStatistic) bool {
+		return orderedStatistic.GetKulczynski() >= minKulczynski
+	}
+}
+
+// ImbalanceRatioAtMost returns a condition met by the rules with at most this imbalance ratio
+func ImbalanceRatioAtMost(maxImbalanceRatio float64) RuleCondition {
+	return func(orderedStatistic OrderedStatistic) bool {
+		return orderedStatistic.GetImbalanceRatio() <= maxImbalanceRatio
+	}
+}
+
+// JMeasureAtLeast returns a condition met by the rules with at least this J-measure
+func JMeasureAtLeast(minJMeasure float64) RuleCondition {
+	return func(orderedStatistic OrderedStatistic) bool {
+		return orderedStatistic.GetJMeasure() >= minJMeasure
+	}
+}
+
+// GiniIndexAtLeast returns a condition met by the rules with at least this Gini index gain
+func GiniIndexAtLeast(minGiniIndex float64) RuleCondition {
+	return func(orderedStatistic OrderedStatistic) bool {
+		return orderedStatistic.GetGiniIndex() >= minGiniIndex
+	}
+}
+
+// GetConviction will return the conviction of the rule, how much more often the base would occur without the add
+// if they were independent, +Inf for the rules with a confidence of 1. NaN when the support of the add is unknown.
+func (os OrderedStatistic) GetConviction() float64 {
+	if os.confidence >= 1 {
+		return math.Inf(1)
+	}
+
+	return (1 - os.addSupport) / (1 - os.confidence)
+}
@@ -0,0 +1,49 @@
+package apriori
+
+import (
+	"fmt"
+	"math"
+	"testing"
+)
+
+func TestRuleCondition(t *testing.T) {
+	transactions := [][]string{
+		{"beer", "nuts", "cheese"},
+		{"beer", "nuts", "jam"},
+		{"beer", "butter"},
+		{"nuts", "cheese"},
+	}
+	a := NewApriori(transactions)
+
+	beerNuts := a.generateOrderedStatistic([]string{"beer"}, []string{"beer", "nuts"}, 0.5)
+	assert(math.Abs(beerNuts.GetConviction()-0.75) < confidenceEpsilon, fmt.Sprintf("Unexpected conviction %v", beerNuts.GetConviction()))
+	cheeseNuts := a.generateOrderedStatistic([]string{"cheese"}, []string{"cheese", "nuts"}, 0.5)
+	assert(math.IsInf(cheeseNuts.GetConviction(), 1), "Expected an infinite conviction for a confidence of 1")
+
+	provider := []struct {
+		condition RuleCondition
+		beerNuts  bool
+		cheeseNut bool
+	}{
+		{LiftAtLeast(1.2), false, true},
+		{Any(LiftAtLeast(1.5), ConvictionAtLeast(0.7)), true, true},
+		{Any(LiftAtLeast(1.5), ConvictionAtLeast(0.8)), false, true},
+		{All(ConfidenceAtLeast(0.5), KulczynskiAtLeast(0.7)), false, true},
+		{Not(ImbalanceRatioAtMost(0.2)), false, true},
+		{All(), true, true},
+		{Any(), false, false},
+	}
+	for i, data := range provider {
+		assert(data.condition(beerNuts) == data.beerNuts && data.condition(cheeseNuts) == data.cheeseNut, fmt.Sprintf("Unexpected outcome of condition %d", i))
+	}
+
+	options, err := NewOptionsWith(WithMinSupport(0.5), WithRuleFilter(Any(LiftAtLeast(1.2), ConvictionAtLeast(1.5))))
+	assert(err == nil, "Expected valid options")
+	var rules []string
+	for _, record := range a.Calculate(options) {
+		for _, orderedStatistic := range record.GetOrderedStatistic() {
+			rules = append(rules, RuleKey(orderedStatistic))
+		}
+	}
+	assert(fmt.Sprint(rules) == `[{"cheese"} => {"nuts"} {"nuts"} => {"cheese"}]`, "Unexpected rules: "+fmt.Sprint(rules))
+}
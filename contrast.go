@@ -0,0 +1,123 @@
+package apriori
+
+import (
+	"errors"
+	"math"
+	"sort"
+)
+
+// ContrastRecord contains an itemset and how its support differs between two datasets
+type ContrastRecord struct {
+	items      []string
+	supportA   float64
+	supportB   float64
+	countA     int64
+	countB     int64
+	growthRate float64
+	chiSquare  float64
+	pValue     float64
+}
+
+// GetItems will return the items of the contrast record
+func (cr ContrastRecord) GetItems() []string {
+	return cr.items
+}
+
+// GetSupportA will return the support of the items in the first dataset
+func (cr ContrastRecord) GetSupportA() float64 {
+	return cr.supportA
+}
+
+// GetSupportB will return the support of the items in the second dataset
+func (cr ContrastRecord) GetSupportB() float64 {
+	return cr.supportB
+}
+
+// GetSupportCountA will return the number of transactions of the first dataset that contain the items
+func (cr ContrastRecord) GetSupportCountA() int64 {
+	return cr.countA
+}
+
+// GetSupportCountB will return the number of transactions of the second dataset that contain the items
+func (cr ContrastRecord) GetSupportCountB() int64 {
+	return cr.countB
+}
+
+// GetGrowthRate will return the support in the second dataset divided by the support in the first one, +Inf for
+// the itemsets that only appear in the second dataset
+func (cr ContrastRecord) GetGrowthRate() float64 {
+	return cr.growthRate
+}
+
+// GetChiSquare will return the chi-square statistic of the itemset presence against the dataset
+func (cr ContrastRecord) GetChiSquare() float64 {
+	return cr.chiSquare
+}
+
+// GetPValue will return the p-value of the chi-square statistic, small values mean the supports really differ
+func (cr ContrastRecord) GetPValue() float64 {
+	return cr.pValue
+}
+
+// CompareDatasets finds the itemsets frequent in any of the two datasets, e.g. last month (a) and this month (b),
+// and returns how their supports differ, most significant difference first. When MaxPValue is set only the
+// itemsets whose p-value passes it, after the PValueCorrection, are returned. The rule thresholds are ignored.
+func CompareDatasets(a, b *Apriori, options Options) ([]ContrastRecord, error) {
+	if a == nil || b == nil {
+		return nil, errors.New("both datasets are needed")
+	}
+	if err := options.check(); err != nil {
+		return nil, err
+	}
+	if a.transactionNo == 0 || b.transactionNo == 0 {
+		return nil, errors.New("both datasets must have transactions")
+	}
+
+	// The itemsets are needed here, not by the callback.
+	options.OnItemset = nil
+	seen := make(map[string]bool)
+	var itemsets [][]string
+	for _, itemset := range append(a.FrequentItemsets(options), b.FrequentItemsets(options)...) {
+		if key := itemsetKey(itemset.items); !seen[key] {
+			seen[key] = true
+			itemsets = append(itemsets, itemset.items)
+		}
+	}
+
+	var records []ContrastRecord
+	var pValues []float64
+	for _, items := range itemsets {
+		countA, countB := a.calculateSupportCount(items), b.calculateSupportCount(items)
+		record := ContrastRecord{
+			items:    items,
+			supportA: a.countToSupport(countA),
+			supportB: b.countToSupport(countB),
+			countA:   countA,
+			countB:   countB,
+		}
+		record.growthRate = record.supportB / record.supportA
+		if record.supportA == 0 {
+			record.growthRate = math.Inf(1)
+		}
+		record.chiSquare, record.pValue = chiSquareTest(countA, a.transactionNo-countA, countB, b.transactionNo-countB)
+		records = append(records, record)
+		pValues = append(pValues, record.pValue)
+	}
+
+	if options.MaxPValue > 0 {
+		threshold := a.pValueThreshold(pValues, options.MaxPValue, options.PValueCorrection)
+		var significant []ContrastRecord
+		for _, record := range records {
+			if record.pValue <= threshold {
+				significant = append(significant, record)
+			}
+		}
+		records = significant
+	}
+
+	sort.SliceStable(records, func(i, j int) bool {
+		return records[i].pValue < records[j].pValue
+	})
+
+	return records, nil
+}
@@ -0,0 +1,37 @@
+package apriori
+
+import (
+	"fmt"
+	"math"
+	"testing"
+)
+
+func TestCompareDatasets(t *testing.T) {
+	lastMonth := NewApriori([][]string{
+		{"beer", "nuts"}, {"beer", "nuts"}, {"beer"}, {"beer"}, {"jam"}, {"jam"}, {"jam"}, {"jam"}, {"jam"}, {"jam"},
+	})
+	thisMonth := NewApriori([][]string{
+		{"beer", "nuts"}, {"beer", "nuts"}, {"beer", "nuts"}, {"beer", "nuts"}, {"beer"}, {"beer"}, {"beer"}, {"beer"}, {"beer"}, {"jam"},
+	})
+
+	options := NewOptions(0.3, 0, 0, 0)
+	records, err := CompareDatasets(lastMonth, thisMonth, options)
+	assert(err == nil, "Expected the datasets to be compared")
+
+	var formatted []string
+	for _, record := range records {
+		formatted = append(formatted, fmt.Sprintf("%v:%d:%d:%.2f:%.4f", record.GetItems(), record.GetSupportCountA(), record.GetSupportCountB(), record.GetGrowthRate(), record.GetPValue()))
+	}
+	result := fmt.Sprint(formatted)
+	assert(result == "[[beer]:4:9:2.25:0.0191 [jam]:6:1:0.17:0.0191 [nuts]:2:4:2.00:0.3291 [beer nuts]:2:4:2.00:0.3291]", "Unexpected contrast records: "+result)
+
+	options.MaxPValue = 0.05
+	records, err = CompareDatasets(lastMonth, thisMonth, options)
+	assert(err == nil && len(records) == 2, "Expected only the significant differences")
+
+	records, _ = CompareDatasets(NewApriori([][]string{{"jam"}}), NewApriori([][]string{{"beer"}}), NewOptions(0.5, 0, 0, 0))
+	assert(math.IsInf(records[0].GetGrowthRate(), 1) || math.IsInf(records[1].GetGrowthRate(), 1), "Expected an infinite growth rate for a new itemset")
+
+	_, err = CompareDatasets(lastMonth, nil, options)
+	assert(err != nil, "Expected an error for a missing dataset")
+}
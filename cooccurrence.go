@@ -0,0 +1,106 @@
+package apriori
+
+import "sort"
+
+// CooccurrenceEdge is an edge of the co-occurrence graph, to an item bought together with the one it's listed under.
+// The fields are exported so that the graph can be encoded, e.g. as JSON.
+type CooccurrenceEdge struct {
+	Item         string  `json:"item"`
+	Support      float64 `json:"support"`
+	SupportCount int64   `json:"supportCount"`
+	Lift         float64 `json:"lift"`
+	Jaccard      float64 `json:"jaccard"` // Share of the transactions containing either item that contain both.
+}
+
+// CooccurrenceGraph returns the graph of the items bought together, e.g. for quick item similarity graphs, straight
+// from the supports of the pairs of items without mining longer itemsets or generating rules. The items and the pairs
+// with a support of at least minSupport, and at least one transaction, are kept. Every pair is an edge listed under
+// both of its items, the edges of an item being sorted by decreasing lift, then by item.
+func (a *Apriori) CooccurrenceGraph(minSupport float64) map[string][]CooccurrenceEdge {
+	var items []string
+	supports := make(map[string]float64)
+	for _, item := range a.Items() {
+		indexes := a.storedItemIndexes(item)
+		if support := a.indexesToSupport([]string{item}, indexes); len(indexes) > 0 && support >= minSupport {
+			items = append(items, item)
+			supports[item] = support
+		}
+	}
+
+	graph := make(map[string][]CooccurrenceEdge)
+	for i, first := range items {
+		firstIndexes := a.storedItemIndexes(first)
+		for _, second := range items[i+1:] {
+			indexes := a.transactionIntersection(firstIndexes, a.storedItemIndexes(second))
+			support := a.indexesToSupport([]string{first, second}, indexes)
+			if len(indexes) == 0 || support < minSupport {
+				continue
+			}
+			edge := newCooccurrenceEdge(second, support, int64(len(indexes)), supports[first], supports[second])
+			graph[first] = append(graph[first], edge)
+			edge.Item = first
+			graph[second] = append(graph[second], edge)
+		}
+	}
+	for _, edges := range graph {
+		sort.SliceStable(edges, func(i, j int) bool {
+			if edges[i].Lift != edges[j].Lift {
+				return edges[i].Lift > edges[j].Lift
+			}
+			return edges[i].Item < edges[j].Item
+		})
+	}
+
+	return graph
+}
+
+// SimilarItems returns the n items most similar to the item, e.g. for "customers also bought" recommendations,
+// intersecting its transactions with the ones of every other item on demand instead of mining. The items are ranked by
+// decreasing Jaccard similarity, which unlike the lift doesn't favor the rare items, then by decreasing lift and by
+// item. All the items bought together with it are returned when n <= 0, none when it's unknown.
+func (a *Apriori) SimilarItems(item string, n int) []CooccurrenceEdge {
+	itemIndexes := a.storedItemIndexes(item)
+	if len(itemIndexes) == 0 {
+		return nil
+	}
+	itemSupport := a.indexesToSupport([]string{item}, itemIndexes)
+
+	var similar []CooccurrenceEdge
+	for _, other := range a.Items() {
+		if other == item {
+			continue
+		}
+		otherIndexes := a.storedItemIndexes(other)
+		indexes := a.transactionIntersection(itemIndexes, otherIndexes)
+		if len(indexes) == 0 {
+			continue
+		}
+		support := a.indexesToSupport([]string{item, other}, indexes)
+		similar = append(similar, newCooccurrenceEdge(other, support, int64(len(indexes)), itemSupport, a.indexesToSupport([]string{other}, otherIndexes)))
+	}
+	sort.SliceStable(similar, func(i, j int) bool {
+		if similar[i].Jaccard != similar[j].Jaccard {
+			return similar[i].Jaccard > similar[j].Jaccard
+		}
+		if similar[i].Lift != similar[j].Lift {
+			return similar[i].Lift > similar[j].Lift
+		}
+		return similar[i].Item < similar[j].Item
+	})
+	if n > 0 && len(similar) > n {
+		similar = similar[:n]
+	}
+
+	return similar
+}
+
+// Returns the edge to the item of a pair with the given support, count and supports of its items.
+func newCooccurrenceEdge(item string, support float64, count int64, firstSupport float64, secondSupport float64) CooccurrenceEdge {
+	return CooccurrenceEdge{
+		Item:         item,
+		Support:      support,
+		SupportCount: count,
+		Lift:         support / (firstSupport * secondSupport),
+		Jaccard:      support / (firstSupport + secondSupport - support),
+	}
+}
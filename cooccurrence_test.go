@@ -0,0 +1,44 @@
+package apriori
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestApriori_CooccurrenceGraph(t *testing.T) {
+	transactions := [][]string{
+		{"beer", "nuts"},
+		{"beer", "nuts", "jam"},
+		{"beer"},
+		{"jam", "bread"},
+	}
+	graph := NewApriori(transactions).CooccurrenceGraph(0.25)
+
+	assert(fmt.Sprint(graph["beer"]) == "[{nuts 0.5 2 1.3333333333333333 0.6666666666666666} {jam 0.25 1 0.6666666666666666 0.25}]", fmt.Sprint("Unexpected edges of beer ", graph["beer"]))
+	assert(fmt.Sprint(graph["jam"]) == "[{bread 0.25 1 2 0.5} {nuts 0.25 1 1 0.3333333333333333} {beer 0.25 1 0.6666666666666666 0.25}]", fmt.Sprint("Unexpected edges of jam ", graph["jam"]))
+	assert(len(graph) == 4, fmt.Sprint("Unexpected graph ", graph))
+
+	graph = NewApriori(transactions).CooccurrenceGraph(0.5)
+	assert(fmt.Sprint(graph) == "map[beer:[{nuts 0.5 2 1.3333333333333333 0.6666666666666666}] nuts:[{beer 0.5 2 1.3333333333333333 0.6666666666666666}]]", fmt.Sprint("Unexpected graph ", graph))
+}
+
+func TestApriori_SimilarItems(t *testing.T) {
+	transactions := [][]string{
+		{"beer", "nuts"},
+		{"beer", "nuts", "jam"},
+		{"beer"},
+		{"jam", "bread"},
+		{"caviar", "jam"},
+	}
+	apriori := NewApriori(transactions)
+
+	var items []string
+	for _, edge := range apriori.SimilarItems("jam", 0) {
+		items = append(items, edge.Item)
+	}
+	assert(fmt.Sprint(items) == "[bread caviar nuts beer]", fmt.Sprint("Unexpected similar items ", items))
+
+	similar := apriori.SimilarItems("beer", 1)
+	assert(fmt.Sprint(similar) == "[{nuts 0.4 2 1.6666666666666667 0.6666666666666667}]", fmt.Sprint("Unexpected most similar item ", similar))
+	assert(apriori.SimilarItems("wine", 3) == nil, "Expected no similar items for an unknown item")
+}
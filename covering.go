@@ -0,0 +1,64 @@
+package apriori
+
+// SelectCoveringRules picks a small subset of the rules covering the most transactions, for compact recommendation
+// rule sets: a rule covers the transactions containing both its base and add, and the rule covering the most
+// transactions not covered yet is picked until maxRules are picked, when > 0, or no rule covers any new transaction
+// (sequential covering). The ties go to the most confident rule. The statistics of the single items are left out.
+func SelectCoveringRules(records []RelationRecord, transactions [][]string, maxRules int) []Rule {
+	sets := make([]map[string]bool, len(transactions))
+	for i, transaction := range transactions {
+		sets[i] = make(map[string]bool, len(transaction))
+		for _, item := range transaction {
+			sets[i][item] = true
+		}
+	}
+
+	var rules []Rule
+	var covers [][]int
+	for _, record := range records {
+		for _, orderedStatistic := range record.orderedStatistic {
+			if len(orderedStatistic.base) == 0 {
+				continue
+			}
+			var covered []int
+			for i, set := range sets {
+				if containsAll(set, orderedStatistic.base) && containsAll(set, orderedStatistic.add) {
+					covered = append(covered, i)
+				}
+			}
+			rules = append(rules, Rule{orderedStatistic, record.supportRecord.support})
+			covers = append(covers, covered)
+		}
+	}
+
+	var selected []Rule
+	covered := make([]bool, len(transactions))
+	picked := make([]bool, len(rules))
+	for maxRules <= 0 || len(selected) < maxRules {
+		best, bestGain := -1, 0
+		for i, rule := range rules {
+			if picked[i] {
+				continue
+			}
+			gain := 0
+			for _, index := range covers[i] {
+				if !covered[index] {
+					gain++
+				}
+			}
+			if gain > bestGain || (gain == bestGain && gain > 0 && rule.orderedStatistic.confidence > rules[best].orderedStatistic.confidence) {
+				best, bestGain = i, gain
+			}
+		}
+		if best < 0 {
+			break
+		}
+		picked[best] = true
+		for _, index := range covers[best] {
+			covered[index] = true
+		}
+		selected = append(selected, rules[best])
+	}
+
+	return selected
+}
@@ -0,0 +1,32 @@
+package apriori
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestSelectCoveringRules(t *testing.T) {
+	transactions := [][]string{
+		{"beer", "nuts", "cheese"},
+		{"beer", "nuts", "jam"},
+		{"beer", "nuts"},
+		{"bread", "butter"},
+		{"bread", "butter", "jam"},
+		{"nuts", "cheese"},
+	}
+	records := NewApriori(transactions).Calculate(NewOptions(0.3, 0.5, 0, 0))
+
+	format := func(rules []Rule) string {
+		var formatted []string
+		for _, rule := range rules {
+			formatted = append(formatted, RuleKey(rule.GetOrderedStatistic()))
+		}
+		return fmt.Sprint(formatted)
+	}
+	// {beer} => {nuts} and {nuts} => {beer} cover the same transactions, the first one is more confident.
+	selected := format(SelectCoveringRules(records, transactions, 0))
+	assert(selected == `[{"beer"} => {"nuts"} {"bread"} => {"butter"} {"cheese"} => {"nuts"}]`, "Unexpected rules: "+selected)
+	selected = format(SelectCoveringRules(records, transactions, 1))
+	assert(selected == `[{"beer"} => {"nuts"}]`, "Unexpected rules: "+selected)
+	assert(len(SelectCoveringRules(nil, transactions, 0)) == 0, "Expected no rule")
+}
@@ -0,0 +1,103 @@
+package apriori
+
+import (
+	"errors"
+	"math/rand"
+	"sort"
+)
+
+// RuleStability contains a rule and how stable it is across the folds of a cross-validation
+type RuleStability struct {
+	rule           OrderedStatistic
+	stability      float64
+	meanConfidence float64
+	meanLift       float64
+}
+
+// GetRule will return the rule as found in the last training fold it appeared in
+func (rs RuleStability) GetRule() OrderedStatistic {
+	return rs.rule
+}
+
+// GetStability will return the fraction of the training folds the rule was found in
+func (rs RuleStability) GetStability() float64 {
+	return rs.stability
+}
+
+// GetMeanConfidence will return the mean confidence of the rule over the training folds it was found in
+func (rs RuleStability) GetMeanConfidence() float64 {
+	return rs.meanConfidence
+}
+
+// GetMeanLift will return the mean lift of the rule over the training folds it was found in
+func (rs RuleStability) GetMeanLift() float64 {
+	return rs.meanLift
+}
+
+// CrossValidateRules splits the transactions in k folds, shuffled with the seed, mines every training set (all
+// the folds but one) with the options and returns the rules found in at least minStability of them, the most
+// stable first. The rules found in most training sets don't depend on a few transactions, so they are the robust
+// ones to select. The statistics of the single items, with an empty base, are left out.
+func CrossValidateRules(transactions [][]string, k int, minStability float64, seed int64, options Options) ([]RuleStability, error) {
+	if err := options.check(); err != nil {
+		return nil, err
+	}
+	if k < 2 || k > len(transactions) {
+		return nil, errors.New("number of folds must be between 2 and the number of transactions")
+	}
+	if minStability < 0 || minStability > 1 {
+		return nil, errors.New("minimum stability must be between 0 and 1")
+	}
+	options.OnItemset, options.OnRule = nil, nil
+
+	folds := rand.New(rand.NewSource(seed)).Perm(len(transactions))
+	type foundRule struct {
+		rule          OrderedStatistic
+		found         int
+		sumConfidence float64
+		sumLift       float64
+	}
+	rules := make(map[string]*foundRule)
+	for fold := 0; fold < k; fold++ {
+		var training [][]string
+		for i, transaction := range transactions {
+			if folds[i]%k != fold {
+				training = append(training, transaction)
+			}
+		}
+		for _, record := range NewApriori(training).Calculate(options) {
+			for _, orderedStatistic := range record.orderedStatistic {
+				if len(orderedStatistic.base) == 0 {
+					continue
+				}
+				key := RuleKey(orderedStatistic)
+				found, ok := rules[key]
+				if !ok {
+					found = &foundRule{}
+					rules[key] = found
+				}
+				found.rule = orderedStatistic
+				found.found++
+				found.sumConfidence += orderedStatistic.confidence
+				found.sumLift += orderedStatistic.lift
+			}
+		}
+	}
+
+	var stabilities []RuleStability
+	for _, found := range rules {
+		stability := float64(found.found) / float64(k)
+		if stability < minStability {
+			continue
+		}
+		stabilities = append(stabilities, RuleStability{found.rule, stability, found.sumConfidence / float64(found.found), found.sumLift / float64(found.found)})
+	}
+	sort.Slice(stabilities, func(i, j int) bool {
+		if stabilities[i].stability != stabilities[j].stability {
+			return stabilities[i].stability > stabilities[j].stability
+		}
+		return RuleKey(stabilities[i].rule) < RuleKey(stabilities[j].rule)
+	})
+
+	return stabilities, nil
+}
@@ -0,0 +1,34 @@
+package apriori
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestCrossValidateRules(t *testing.T) {
+	var transactions [][]string
+	for i := 0; i < 8; i++ {
+		transactions = append(transactions, []string{"beer", "nuts"})
+	}
+	transactions = append(transactions, []string{"wine", "cheese"}, []string{"wine", "cheese"}, []string{"beer"}, []string{"jam"})
+
+	options := NewOptions(0.15, 0.5, 0.0, 0)
+	stabilities, err := CrossValidateRules(transactions, 4, 0, 1, options)
+	assert(err == nil, "Expected the cross-validation to succeed")
+	var formatted []string
+	for _, stability := range stabilities {
+		formatted = append(formatted, fmt.Sprintf("%s %.2f", RuleKey(stability.GetRule()), stability.GetStability()))
+		assert(stability.GetMeanConfidence() >= 0.5 && stability.GetMeanLift() > 0, "Expected the mean statistics of the folds")
+	}
+	// The rules between wine and cheese depend on the folds their 2 transactions fall in.
+	assert(len(formatted) > 2 && fmt.Sprint(formatted[:2]) == `[{"beer"} => {"nuts"} 1.00 {"nuts"} => {"beer"} 1.00]`, "Unexpected stabilities: "+fmt.Sprint(formatted))
+	for _, stability := range stabilities[2:] {
+		assert(stability.GetStability() < 1, "Expected the rules between wine and cheese to be unstable")
+	}
+
+	stabilities, err = CrossValidateRules(transactions, 4, 1, 1, options)
+	assert(err == nil && len(stabilities) == 2, "Expected only the stable rules")
+
+	_, err = CrossValidateRules(transactions, 1, 0, 1, options)
+	assert(err != nil, "Expected an error for a single fold")
+}
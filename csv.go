@@ -0,0 +1,63 @@
+package apriori
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+)
+
+// NewAprioriFromLongCSV builds an Apriori struct from a long format CSV, one row per order and item as in most raw
+// exports, e.g. "order_id,sku". The first row is the header naming the columns, the other columns are ignored.
+// As with NewAprioriFromSQL the rows of an order don't need to be consecutive, and rows with an empty item only
+// count the order.
+func NewAprioriFromLongCSV(r io.Reader, groupCol, itemCol string) (*Apriori, error) {
+	r, err := Decompress(r)
+	if err != nil {
+		return nil, err
+	}
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.ReuseRecord = true
+
+	header, err := reader.Read()
+	if err == io.EOF {
+		return nil, fmt.Errorf("the CSV must have the %q and %q columns", groupCol, itemCol)
+	}
+	if err != nil {
+		return nil, err
+	}
+	groupIndex, itemIndex := -1, -1
+	for i, column := range header {
+		switch column {
+		case groupCol:
+			groupIndex = i
+		case itemCol:
+			itemIndex = i
+		}
+	}
+	if groupIndex == -1 || itemIndex == -1 {
+		return nil, fmt.Errorf("the CSV must have the %q and %q columns", groupCol, itemCol)
+	}
+
+	index := newLongFormatIndex()
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		if groupIndex >= len(record) {
+			line, _ := reader.FieldPos(0)
+			return nil, fmt.Errorf("line %d: the %q column is missing", line, groupCol)
+		}
+		item := ""
+		if itemIndex < len(record) {
+			item = record[itemIndex]
+		}
+		index.add(record[groupIndex], item, item != "")
+	}
+
+	return index.apriori(), nil
+}
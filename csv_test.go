@@ -0,0 +1,22 @@
+package apriori
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestNewAprioriFromLongCSV(t *testing.T) {
+	data := "order_id,sku,quantity\n1,beer,2\n2,jam,1\n1,nuts,1\n3,,\n1,beer,1\n2,\"nuts, salted\",3\n"
+	a, err := NewAprioriFromLongCSV(strings.NewReader(data), "order_id", "sku")
+	assert(err == nil, "Expected the CSV to be read")
+	assert(a.TransactionCount() == 3, "Expected the empty order to be counted")
+	result := fmt.Sprint(a.Items())
+	assert(result == "[beer jam nuts nuts, salted]", "Unexpected items: "+result)
+	assert(a.ItemFrequency("beer") == 1, "Expected the repeated rows to count once")
+
+	_, err = NewAprioriFromLongCSV(strings.NewReader(data), "order_id", "product")
+	assert(err != nil, "Expected an error for a missing column")
+	_, err = NewAprioriFromLongCSV(strings.NewReader("order_id,sku\n1\n"), "sku", "order_id")
+	assert(err != nil, "Expected an error for a short row")
+}
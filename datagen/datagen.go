@@ -0,0 +1,188 @@
+// Package datagen generates synthetic transaction datasets like the IBM Quest generator, e.g. the T10I4D100K
+// benchmark, to benchmark the miners and try mining configurations on data with known characteristics
+package datagen
+
+import (
+	"errors"
+	"math"
+	"math/rand"
+	"sort"
+	"strconv"
+)
+
+// Config holds the parameters of a dataset, named after the Quest generator ones
+type Config struct {
+	Transactions       int     // Number of transactions (D)
+	AvgTransactionSize float64 // Average size of the transactions (T)
+	AvgPatternSize     float64 // Average size of the potentially frequent itemsets (I)
+	Patterns           int     // Number of potentially frequent itemsets (L)
+	Items              int     // Number of items (N)
+	Correlation        float64 // Average fraction of the items of a pattern taken from the previous one
+	Seed               int64   // Makes the dataset reproducible
+}
+
+// NewConfig returns the configuration of the TxIyDz datasets, e.g. NewConfig(10, 4, 100000) for T10I4D100K,
+// with the 2000 patterns, 1000 items and 0.5 correlation of the original benchmarks
+func NewConfig(avgTransactionSize float64, avgPatternSize float64, transactions int) Config {
+	return Config{
+		Transactions:       transactions,
+		AvgTransactionSize: avgTransactionSize,
+		AvgPatternSize:     avgPatternSize,
+		Patterns:           2000,
+		Items:              1000,
+		Correlation:        0.5,
+	}
+}
+
+func (c Config) check() error {
+	if c.Transactions < 0 {
+		return errors.New("number of transactions must be >= 0")
+	}
+	if c.AvgTransactionSize < 1 || c.AvgPatternSize < 1 {
+		return errors.New("average transaction and pattern sizes must be at least 1")
+	}
+	if c.Patterns < 1 || c.Items < 1 {
+		return errors.New("number of patterns and items must be at least 1")
+	}
+	if c.Correlation < 0 || c.Correlation > 1 {
+		return errors.New("correlation must be between 0 and 1")
+	}
+
+	return nil
+}
+
+type pattern struct {
+	items      []int
+	weight     float64 // Cumulated, for picking the patterns by weight.
+	corruption float64 // Probability of dropping each item when the pattern is added to a transaction.
+}
+
+// Generate returns the transactions of the dataset, the items being the numbers 0 to Items-1 as strings
+func Generate(config Config) ([][]string, error) {
+	if err := config.check(); err != nil {
+		return nil, err
+	}
+	random := rand.New(rand.NewSource(config.Seed))
+	patterns := generatePatterns(config, random)
+	// The transactions are made of the pattern items only, which may not be all the items.
+	covered := make(map[int]bool)
+	for _, p := range patterns {
+		for _, item := range p.items {
+			covered[item] = true
+		}
+	}
+
+	transactions := make([][]string, 0, config.Transactions)
+	var carried []int // A pattern that didn't fit is carried to the next transaction.
+	for len(transactions) < config.Transactions {
+		size := poisson(random, config.AvgTransactionSize)
+		size = int(math.Min(math.Max(float64(size), 1), float64(len(covered))))
+		items := make(map[int]bool, size)
+		for _, item := range carried {
+			items[item] = true
+		}
+		carried = nil
+		for len(items) < size {
+			p := pickPattern(patterns, random)
+			var picked []int
+			for _, item := range p.items {
+				if random.Float64() >= p.corruption {
+					picked = append(picked, item)
+				}
+			}
+			if len(picked) == 0 {
+				picked = []int{p.items[random.Intn(len(p.items))]}
+			}
+			// As in Quest, a pattern that doesn't fit is added anyway half the times, and carried otherwise.
+			if len(items)+len(picked) > size && len(items) > 0 && random.Intn(2) == 0 {
+				carried = picked
+				break
+			}
+			for _, item := range picked {
+				items[item] = true
+			}
+		}
+		transactions = append(transactions, format(items))
+	}
+
+	return transactions, nil
+}
+
+// Generates the potentially frequent itemsets: each one takes part of its items from the previous one, and gets
+// an exponentially distributed weight and a corruption level.
+func generatePatterns(config Config, random *rand.Rand) []pattern {
+	patterns := make([]pattern, config.Patterns)
+	totalWeight := 0.0
+	var previous []int
+	for i := range patterns {
+		size := poisson(random, config.AvgPatternSize-1) + 1
+		if size > config.Items {
+			size = config.Items
+		}
+		items := make(map[int]bool, size)
+		if len(previous) > 0 {
+			fromPrevious := int(math.Min(random.ExpFloat64()*config.Correlation, 1) * float64(size))
+			for _, j := range random.Perm(len(previous)) {
+				if len(items) >= fromPrevious {
+					break
+				}
+				items[previous[j]] = true
+			}
+		}
+		for len(items) < size {
+			items[random.Intn(config.Items)] = true
+		}
+
+		patterns[i].items = make([]int, 0, len(items))
+		for item := range items {
+			patterns[i].items = append(patterns[i].items, item)
+		}
+		sort.Ints(patterns[i].items)
+		previous = patterns[i].items
+
+		totalWeight += random.ExpFloat64()
+		patterns[i].weight = totalWeight
+		patterns[i].corruption = math.Min(math.Max(0.5+random.NormFloat64()*math.Sqrt(0.1), 0), 1)
+	}
+	for i := range patterns {
+		patterns[i].weight /= totalWeight
+	}
+
+	return patterns
+}
+
+func pickPattern(patterns []pattern, random *rand.Rand) pattern {
+	r := random.Float64()
+	i := sort.Search(len(patterns), func(i int) bool { return patterns[i].weight >= r })
+	if i == len(patterns) {
+		i--
+	}
+
+	return patterns[i]
+}
+
+// Returns a Poisson distributed number with the given mean (Knuth's algorithm).
+func poisson(random *rand.Rand, mean float64) int {
+	limit := math.Exp(-mean)
+	n, p := 0, random.Float64()
+	for p > limit {
+		n++
+		p *= random.Float64()
+	}
+
+	return n
+}
+
+func format(items map[int]bool) []string {
+	sorted := make([]int, 0, len(items))
+	for item := range items {
+		sorted = append(sorted, item)
+	}
+	sort.Ints(sorted)
+	transaction := make([]string, len(sorted))
+	for i, item := range sorted {
+		transaction[i] = strconv.Itoa(item)
+	}
+
+	return transaction
+}
@@ -0,0 +1,54 @@
+package datagen
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/eMAGTechLabs/go-apriori"
+)
+
+func TestGenerate(t *testing.T) {
+	config := NewConfig(10, 4, 2000)
+	config.Seed = 42
+	transactions, err := Generate(config)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(transactions) != 2000 {
+		t.Fatalf("unexpected number of transactions %d", len(transactions))
+	}
+
+	total := 0
+	for _, transaction := range transactions {
+		total += len(transaction)
+	}
+	if average := float64(total) / float64(len(transactions)); average < 8 || average > 12 {
+		t.Fatalf("unexpected average transaction size %v", average)
+	}
+
+	again, _ := Generate(config)
+	if fmt.Sprint(again) != fmt.Sprint(transactions) {
+		t.Fatal("expected the same seed to generate the same dataset")
+	}
+
+	// The patterns make some itemsets much more frequent than by chance.
+	itemsets := apriori.NewApriori(transactions).FrequentItemsets(apriori.NewOptions(0.01, 0, 0, 0))
+	found := false
+	for _, itemset := range itemsets {
+		if len(itemset.GetItems()) > 1 {
+			found = true
+		}
+	}
+	if !found {
+		t.Fatal("expected frequent itemsets of several items")
+	}
+
+	config.Correlation = 2
+	if _, err := Generate(config); err == nil {
+		t.Fatal("expected an error for a correlation above 1")
+	}
+	// A single pattern can't fill the transactions larger than itself.
+	if _, err := Generate(Config{Transactions: 10, AvgTransactionSize: 5, AvgPatternSize: 3, Patterns: 1, Items: 50}); err != nil {
+		t.Fatal(err)
+	}
+}
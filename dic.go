@@ -0,0 +1,262 @@
+package apriori
+
+import (
+	"bufio"
+	"errors"
+	"io"
+	"sort"
+	"strings"
+)
+
+// Counter of an itemset tracked by DICFrequentItemsets.
+type dicCounter struct {
+	items     []string
+	count     int64
+	remaining int64 // Transactions left to count before the count is complete.
+	suspected bool  // Whether the count reached the minimum support, i.e. the itemset is frequent so far.
+}
+
+// Prefix tree of the tracked itemsets, so the ones contained in a transaction are found by walking its items.
+type dicNode struct {
+	children map[string]*dicNode
+	counter  *dicCounter
+}
+
+func (n *dicNode) insert(counter *dicCounter) {
+	node := n
+	for _, item := range counter.items {
+		if node.children == nil {
+			node.children = make(map[string]*dicNode)
+		}
+		child, ok := node.children[item]
+		if !ok {
+			child = &dicNode{}
+			node.children[item] = child
+		}
+		node = child
+	}
+	node.counter = counter
+}
+
+// Increments the counts still being counted of the tracked itemsets contained in the sorted items, starting with
+// the item at index start.
+func (n *dicNode) count(items []string, start int) {
+	for i := start; i < len(items); i++ {
+		child := n.children[items[i]]
+		if child == nil {
+			continue
+		}
+		if child.counter != nil && child.counter.remaining > 0 {
+			child.counter.count++
+		}
+		child.count(items, i+1)
+	}
+}
+
+// DICFrequentItemsets finds the frequent itemsets of a dataset read from disk with Dynamic Itemset Counting: the
+// transactions are read in blocks of blockSize and, at the end of every block, the itemsets whose subsets all
+// reached the minimum support so far start being counted, instead of waiting for the end of a pass over all the
+// transactions like Apriori. Every itemset is counted over all the transactions, wrapping around to the start of
+// the reader, so the result is exact and takes fewer passes, the fewer the smaller the blocks. The reader holds
+// one transaction per line, with comma separated items, may be compressed (see Decompress) and is read once more
+// up front to count the transactions. The consequent, negated items, taxonomy, transaction IDs and minimum supports
+// by length options are not supported.
+func DICFrequentItemsets(r io.ReadSeeker, blockSize int, options Options) ([]SupportRecord, error) {
+	if err := options.check(); err != nil {
+		return nil, err
+	}
+	if blockSize < 1 {
+		return nil, errors.New("block size must be at least 1")
+	}
+	if len(options.Consequent) > 0 || options.NegatedItemsMinSupport > 0 || len(options.Taxonomy) > 0 || options.KeepTransactionIDs ||
+		len(options.MinSupportByLength) > 0 {
+		return nil, errors.New("DIC doesn't support the consequent, negated items, taxonomy, transaction IDs and minimum supports by length options")
+	}
+
+	var a Apriori
+	transactions := &cyclicTransactions{r: r}
+	for {
+		_, wrapped, err := transactions.next()
+		if err != nil {
+			return nil, err
+		}
+		if wrapped {
+			break
+		}
+		a.transactionNo++
+	}
+	if a.transactionNo == 0 {
+		return nil, nil
+	}
+	if err := transactions.rewind(); err != nil {
+		return nil, err
+	}
+
+	included := make(map[string]bool)
+	for _, item := range options.IncludeItems {
+		included[item] = true
+	}
+	excluded := make(map[string]bool)
+	for _, item := range options.ExcludeItems {
+		excluded[item] = true
+	}
+
+	// The single items are tracked when first seen, their count being 0 over the transactions read before.
+	root := &dicNode{}
+	counters := make(map[string]*dicCounter)
+	var counting, suspectedItems []*dicCounter
+	track := func(items []string, remaining int64) *dicCounter {
+		counter := &dicCounter{items: items, remaining: remaining}
+		counters[itemsetKey(items)] = counter
+		root.insert(counter)
+		counting = append(counting, counter)
+		return counter
+	}
+	isSuspected := func(items []string) bool {
+		counter := counters[itemsetKey(items)]
+		return counter != nil && counter.suspected
+	}
+
+	var read int64
+	for len(counting) > 0 || read < a.transactionNo {
+		for i := 0; i < blockSize && (len(counting) > 0 || read < a.transactionNo); i++ {
+			transaction, _, err := transactions.next()
+			if err != nil {
+				return nil, err
+			}
+			var items []string
+			for _, item := range a.normalizeItems(transaction) {
+				if (len(included) > 0 && !included[item]) || excluded[item] {
+					continue
+				}
+				items = append(items, item)
+				if read < a.transactionNo && counters[itemsetKey([]string{item})] == nil {
+					track([]string{item}, a.transactionNo-read)
+				}
+			}
+			root.count(items, 0)
+			for _, counter := range counting {
+				counter.remaining--
+			}
+			read++
+
+			var stillCounting []*dicCounter
+			for _, counter := range counting {
+				if counter.remaining > 0 {
+					stillCounting = append(stillCounting, counter)
+				}
+			}
+			counting = stillCounting
+		}
+
+		// The itemsets that became frequent so far extend the candidates counted from the next block on.
+		var newlySuspected []*dicCounter
+		for _, counter := range counters {
+			if !counter.suspected && a.countToSupport(counter.count) >= options.minSupport {
+				counter.suspected = true
+				newlySuspected = append(newlySuspected, counter)
+				if len(counter.items) == 1 {
+					suspectedItems = append(suspectedItems, counter)
+				}
+			}
+		}
+		sort.Slice(newlySuspected, func(i, j int) bool {
+			return itemsetKey(newlySuspected[i].items) < itemsetKey(newlySuspected[j].items)
+		})
+		for _, counter := range newlySuspected {
+			if options.maxLength != 0 && len(counter.items) >= options.maxLength {
+				continue
+			}
+			for _, item := range suspectedItems {
+				if a.inSlice(item.items[0], counter.items) {
+					continue
+				}
+				candidate := a.normalizeItems(append(append([]string{}, counter.items...), item.items[0]))
+				if _, ok := counters[itemsetKey(candidate)]; ok {
+					continue
+				}
+				frequentSubsets := true
+				for _, subset := range a.generateCandidateCombinations(candidate, len(candidate)-1) {
+					if !isSuspected(subset) {
+						frequentSubsets = false
+						break
+					}
+				}
+				if frequentSubsets {
+					track(candidate, a.transactionNo)
+				}
+			}
+		}
+	}
+
+	var records []SupportRecord
+	for _, counter := range counters {
+		support := a.countToSupport(counter.count)
+		if support < options.minSupport || len(counter.items) < options.MinLength || options.aboveMaxSupport(support) {
+			continue
+		}
+		maxItemSupport := 0.0
+		for _, item := range counter.items {
+			if itemSupport := a.countToSupport(counters[itemsetKey([]string{item})].count); itemSupport > maxItemSupport {
+				maxItemSupport = itemSupport
+			}
+		}
+		allConfidence := support / maxItemSupport
+		if allConfidence < options.MinAllConfidence {
+			continue
+		}
+		records = append(records, SupportRecord{items: counter.items, support: support, supportCount: counter.count, allConfidence: allConfidence})
+	}
+	sort.Slice(records, func(i, j int) bool {
+		if len(records[i].items) != len(records[j].items) {
+			return len(records[i].items) < len(records[j].items)
+		}
+		return itemsetKey(records[i].items) < itemsetKey(records[j].items)
+	})
+
+	return records, nil
+}
+
+// Transactions of a reader, read over and over: once the end is reached the reader is rewound.
+type cyclicTransactions struct {
+	r      io.ReadSeeker
+	reader *bufio.Reader
+}
+
+// Returns the next transaction and whether the reader was rewound to read it.
+func (c *cyclicTransactions) next() ([]string, bool, error) {
+	wrapped := false
+	for {
+		if c.reader == nil {
+			r, err := Decompress(c.r)
+			if err != nil {
+				return nil, false, err
+			}
+			c.reader = bufio.NewReader(r)
+		}
+		line, err := c.reader.ReadString('\n')
+		if err != nil && err != io.EOF {
+			return nil, false, err
+		}
+		if line = strings.TrimRight(line, "\r\n"); line != "" {
+			return parseTransaction(line), wrapped, nil
+		}
+		if err == io.EOF {
+			if wrapped {
+				// Nothing was read since the rewind, there are no transactions.
+				return nil, true, nil
+			}
+			if err := c.rewind(); err != nil {
+				return nil, false, err
+			}
+			wrapped = true
+		}
+	}
+}
+
+// Makes the next transaction the first one of the reader.
+func (c *cyclicTransactions) rewind() error {
+	c.reader = nil
+	_, err := c.r.Seek(0, io.SeekStart)
+	return err
+}
@@ -0,0 +1,67 @@
+package apriori
+
+import (
+	"fmt"
+	"math/rand"
+	"strings"
+	"testing"
+)
+
+func TestDICFrequentItemsets(t *testing.T) {
+	random := rand.New(rand.NewSource(3))
+	items := []string{"beer", "nuts", "cheese", "jam", "wine", "butter"}
+	var transactions [][]string
+	var lines []string
+	for i := 0; i < 100; i++ {
+		var transaction []string
+		for _, item := range items {
+			if random.Float64() < 0.4 {
+				transaction = append(transaction, item)
+			}
+		}
+		if len(transaction) == 0 {
+			continue
+		}
+		transactions = append(transactions, transaction)
+		lines = append(lines, strings.Join(transaction, ", "))
+	}
+	data := strings.Join(lines, "\n")
+
+	format := func(records []SupportRecord) string {
+		var formatted []string
+		for _, record := range records {
+			formatted = append(formatted, formatSupportRecord(record))
+		}
+		return fmt.Sprint(formatted)
+	}
+
+	provider := []func(options *Options){
+		func(options *Options) {},
+		func(options *Options) { options.maxLength = 2 },
+		func(options *Options) { options.MinLength = 2 },
+		func(options *Options) { options.MinAllConfidence = 0.3 },
+		func(options *Options) { options.ExcludeItems = []string{"jam"} },
+	}
+
+	for _, configure := range provider {
+		options := NewOptions(0.05, 0, 0, 0)
+		configure(&options)
+		expected := format(NewApriori(transactions).FrequentItemsets(options))
+		for _, blockSize := range []int{1, 7, 100, 1000} {
+			records, err := DICFrequentItemsets(strings.NewReader(data), blockSize, options)
+			assert(err == nil, "Expected DIC to succeed")
+			assert(format(records) == expected, fmt.Sprintf("Unexpected itemsets for blocks of %d: %s", blockSize, format(records)))
+		}
+	}
+
+	records, err := DICFrequentItemsets(strings.NewReader(""), 10, NewOptions(0.05, 0, 0, 0))
+	assert(err == nil && records == nil, "Expected no itemsets without transactions")
+
+	_, err = DICFrequentItemsets(strings.NewReader(data), 0, NewOptions(0.05, 0, 0, 0))
+	assert(err != nil, "Expected an error for an empty block size")
+
+	options := NewOptions(0.05, 0, 0, 0)
+	options.KeepTransactionIDs = true
+	_, err = DICFrequentItemsets(strings.NewReader(data), 10, options)
+	assert(err != nil, "Expected an error for the transaction IDs option")
+}
@@ -0,0 +1,111 @@
+package apriori
+
+import "math"
+
+// RuleChange contains a rule found by both models and its statistics in each of them
+type RuleChange struct {
+	before        OrderedStatistic
+	after         OrderedStatistic
+	supportBefore float64
+	supportAfter  float64
+}
+
+// GetBefore will return the rule as found by the old model
+func (rc RuleChange) GetBefore() OrderedStatistic {
+	return rc.before
+}
+
+// GetAfter will return the rule as found by the new model
+func (rc RuleChange) GetAfter() OrderedStatistic {
+	return rc.after
+}
+
+// GetSupportDelta will return the change of the support of the rule itemset
+func (rc RuleChange) GetSupportDelta() float64 {
+	return rc.supportAfter - rc.supportBefore
+}
+
+// GetConfidenceDelta will return the change of the confidence of the rule
+func (rc RuleChange) GetConfidenceDelta() float64 {
+	return rc.after.confidence - rc.before.confidence
+}
+
+// GetLiftDelta will return the change of the lift of the rule
+func (rc RuleChange) GetLiftDelta() float64 {
+	return rc.after.lift - rc.before.lift
+}
+
+// RuleDiff contains the differences between the rules of two models
+type RuleDiff struct {
+	added   []RelationRecord
+	removed []RelationRecord
+	changed []RuleChange
+}
+
+// GetAdded will return the rules found only by the new model, grouped by itemset like the mining results
+func (rd RuleDiff) GetAdded() []RelationRecord {
+	return rd.added
+}
+
+// GetRemoved will return the rules found only by the old model, grouped by itemset like the mining results
+func (rd RuleDiff) GetRemoved() []RelationRecord {
+	return rd.removed
+}
+
+// GetChanged will return the rules found by both models whose support, confidence or lift moved
+func (rd RuleDiff) GetChanged() []RuleChange {
+	return rd.changed
+}
+
+// DiffRuleSets compares the rules of two mining results, e.g. of consecutive runs of a scheduled job, and returns
+// the added, removed and changed rules. A rule is identified by its base and add, the order of the items and of
+// the records doesn't matter.
+func DiffRuleSets(oldRecords, newRecords []RelationRecord) RuleDiff {
+	type indexedRule struct {
+		orderedStatistic OrderedStatistic
+		support          float64
+	}
+	index := func(records []RelationRecord) map[string]indexedRule {
+		rules := make(map[string]indexedRule)
+		for _, record := range records {
+			for _, orderedStatistic := range record.orderedStatistic {
+				rules[RuleKey(orderedStatistic)] = indexedRule{orderedStatistic, record.supportRecord.support}
+			}
+		}
+		return rules
+	}
+	oldRules, newRules := index(oldRecords), index(newRecords)
+
+	var diff RuleDiff
+	for _, record := range newRecords {
+		var added []OrderedStatistic
+		for _, orderedStatistic := range record.orderedStatistic {
+			before, ok := oldRules[RuleKey(orderedStatistic)]
+			if !ok {
+				added = append(added, orderedStatistic)
+				continue
+			}
+			change := RuleChange{before.orderedStatistic, orderedStatistic, before.support, record.supportRecord.support}
+			if math.Abs(change.GetSupportDelta()) > confidenceEpsilon || math.Abs(change.GetConfidenceDelta()) > confidenceEpsilon ||
+				math.Abs(change.GetLiftDelta()) > confidenceEpsilon {
+				diff.changed = append(diff.changed, change)
+			}
+		}
+		if len(added) > 0 {
+			diff.added = append(diff.added, RelationRecord{record.supportRecord, added})
+		}
+	}
+	for _, record := range oldRecords {
+		var removed []OrderedStatistic
+		for _, orderedStatistic := range record.orderedStatistic {
+			if _, ok := newRules[RuleKey(orderedStatistic)]; !ok {
+				removed = append(removed, orderedStatistic)
+			}
+		}
+		if len(removed) > 0 {
+			diff.removed = append(diff.removed, RelationRecord{record.supportRecord, removed})
+		}
+	}
+
+	return diff
+}
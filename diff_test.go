@@ -0,0 +1,36 @@
+package apriori
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestDiffRuleSets(t *testing.T) {
+	old := []RelationRecord{
+		NewRelationRecord(NewSupportRecord([]string{"beer", "nuts"}, 0.5), []OrderedStatistic{
+			NewOrderedStatistic([]string{"beer"}, []string{"nuts"}, 0.8, 1.2),
+			NewOrderedStatistic([]string{"nuts"}, []string{"beer"}, 0.7, 1.2),
+		}),
+		NewRelationRecord(NewSupportRecord([]string{"jam", "nuts"}, 0.2), []OrderedStatistic{
+			NewOrderedStatistic([]string{"jam"}, []string{"nuts"}, 0.6, 1.1),
+		}),
+	}
+	current := []RelationRecord{
+		NewRelationRecord(NewSupportRecord([]string{"nuts", "beer"}, 0.5), []OrderedStatistic{
+			NewOrderedStatistic([]string{"nuts"}, []string{"beer"}, 0.7, 1.2),
+			NewOrderedStatistic([]string{"beer"}, []string{"nuts"}, 0.5, 1.0),
+		}),
+		NewRelationRecord(NewSupportRecord([]string{"cheese", "nuts"}, 0.3), []OrderedStatistic{
+			NewOrderedStatistic([]string{"cheese"}, []string{"nuts"}, 0.9, 1.5),
+		}),
+	}
+
+	diff := DiffRuleSets(old, current)
+	assert(formatRecords(diff.GetAdded()) == "[{{[cheese nuts] 0.3} [{[cheese] [nuts] 0.9 1.5}]}]", "Unexpected added rules: "+formatRecords(diff.GetAdded()))
+	assert(formatRecords(diff.GetRemoved()) == "[{{[jam nuts] 0.2} [{[jam] [nuts] 0.6 1.1}]}]", "Unexpected removed rules: "+formatRecords(diff.GetRemoved()))
+
+	changed := diff.GetChanged()
+	assert(len(changed) == 1, "Expected a single changed rule")
+	result := fmt.Sprintf("%v %.2f %.2f %.2f", changed[0].GetAfter().GetBase(), changed[0].GetSupportDelta(), changed[0].GetConfidenceDelta(), changed[0].GetLiftDelta())
+	assert(result == "[beer] 0.00 -0.30 -0.20", "Unexpected changed rule: "+result)
+}
@@ -0,0 +1,125 @@
+package apriori
+
+import (
+	"context"
+	"sort"
+)
+
+// Itemset of a class of dEclat, the itemsets sharing all but their last item. The set is the tidset of the single
+// items and, deeper, the diffset: the transactions of the prefix of the class that don't contain the itemset.
+type diffsetNode struct {
+	items []string
+	set   []int64
+	count int64
+}
+
+// Finds the frequent itemsets extending the initial candidates depth-first with diffsets (dEclat) and emits them in
+// the order of the level-wise generator, by length then items. The count of an itemset is the count of its prefix
+// minus the length of its diffset, and the diffsets shrink as the itemsets grow longer, so a candidate needs less
+// memory the deeper it is, unlike the intersected indexes.
+func (a *Apriori) generateDiffsetSupportRecords(ctx context.Context, candidates [][]string, options Options, emit func(SupportRecord)) {
+	_, span := options.startSpan(ctx, "apriori.diffsets")
+	defer span.End()
+
+	var records []SupportRecord
+	candidatesByLength := make(map[int]int)
+	candidatesByLength[1] = len(candidates)
+	var class []diffsetNode
+	for _, candidate := range candidates {
+		indexes := a.itemIndexes(candidate[0])
+		count := int64(len(indexes))
+		support := a.countToSupport(count)
+		if support < options.minSupportFrom(1) {
+			options.debug("candidate pruned", "items", candidate, "reason", "support", "support", support)
+			continue
+		}
+		class = append(class, diffsetNode{candidate, indexes, count})
+		records = append(records, SupportRecord{items: candidate, support: support, supportCount: count, allConfidence: 1})
+	}
+
+	var mine func(class []diffsetNode, tidsets bool) bool
+	mine = func(class []diffsetNode, tidsets bool) bool {
+		for i, prefix := range class {
+			if options.maxLength != 0 && len(prefix.items) >= options.maxLength {
+				return true
+			}
+			var next []diffsetNode
+			for _, other := range class[i+1:] {
+				if ctx.Err() != nil {
+					return false
+				}
+				items := make([]string, len(prefix.items)+1)
+				copy(items, prefix.items)
+				items[len(prefix.items)] = other.items[len(other.items)-1]
+				candidatesByLength[len(items)]++
+				if len(options.Taxonomy) > 0 && a.containsAncestorPair(items, options.Taxonomy) {
+					continue
+				}
+				if options.DistinctAttributes && a.containsAttributePair(items) {
+					continue
+				}
+
+				// d(XY) = t(X) - t(Y) for the single items, d(PXY) = d(PY) - d(PX) deeper.
+				var diffset []int64
+				if tidsets {
+					diffset = a.transactionDifference(prefix.set, other.set)
+				} else {
+					diffset = a.transactionDifference(other.set, prefix.set)
+				}
+				count := prefix.count - int64(len(diffset))
+				support := a.countToSupport(count)
+				if support < options.minSupportFrom(len(items)) {
+					options.debug("candidate pruned", "items", items, "reason", "support", "support", support)
+					continue
+				}
+				allConfidence := a.calculateAllConfidence(items, support)
+				if allConfidence < options.MinAllConfidence {
+					options.debug("candidate pruned", "items", items, "reason", "all-confidence", "allConfidence", allConfidence)
+					continue
+				}
+				next = append(next, diffsetNode{items, diffset, count})
+				records = append(records, SupportRecord{items: items, support: support, supportCount: count, allConfidence: allConfidence})
+			}
+			if len(next) > 0 && !mine(next, false) {
+				return false
+			}
+		}
+		return true
+	}
+	if !mine(class, true) {
+		return
+	}
+
+	if options.MetricsCollector != nil {
+		for length := 1; candidatesByLength[length] > 0; length++ {
+			options.MetricsCollector.CandidatesCounted(length, candidatesByLength[length])
+		}
+	}
+	sort.Slice(records, func(i, j int) bool {
+		if len(records[i].items) != len(records[j].items) {
+			return len(records[i].items) < len(records[j].items)
+		}
+		return itemsetKey(records[i].items) < itemsetKey(records[j].items)
+	})
+	span.SetAttribute("frequent", int64(len(records)))
+	for _, record := range records {
+		emit(record)
+	}
+}
+
+// Returns the sorted transaction indexes of first that aren't in second, both sorted.
+func (a *Apriori) transactionDifference(first []int64, second []int64) []int64 {
+	var difference []int64
+	j := 0
+	for _, index := range first {
+		for j < len(second) && second[j] < index {
+			j++
+		}
+		if j < len(second) && second[j] == index {
+			continue
+		}
+		difference = append(difference, index)
+	}
+
+	return difference
+}
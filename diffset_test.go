@@ -0,0 +1,63 @@
+package apriori
+
+import (
+	"fmt"
+	"math/rand"
+	"testing"
+)
+
+func TestApriori_CalculateWithDiffsets(t *testing.T) {
+	random := rand.New(rand.NewSource(11))
+	items := []string{"beer", "nuts", "cheese", "jam", "wine", "butter", "bread"}
+	var transactions [][]string
+	for i := 0; i < 200; i++ {
+		var transaction []string
+		for _, item := range items {
+			if random.Float64() < 0.5 {
+				transaction = append(transaction, item)
+			}
+		}
+		transactions = append(transactions, transaction)
+	}
+
+	provider := []func(options *Options){
+		func(options *Options) {},
+		func(options *Options) { options.maxLength = 3 },
+		func(options *Options) { options.MinLength = 2 },
+		func(options *Options) { options.MinAllConfidence = 0.3 },
+		func(options *Options) { options.NegatedItemsMinSupport = 0.5 },
+		func(options *Options) { options.Taxonomy = map[string]string{"beer": "drinks", "wine": "drinks"} },
+		func(options *Options) { options.ExcludeItems = []string{"jam"} },
+	}
+
+	for i, configure := range provider {
+		options := NewOptions(0.05, 0.0, 0.0, 0)
+		configure(&options)
+		expected := NewApriori(transactions).Calculate(options)
+		options.Diffsets = true
+		out := NewApriori(transactions).Calculate(options)
+
+		assert(len(out) > 0 && formatRecords(expected) == formatRecords(out), fmt.Sprintf("Expected the same output with diffsets for options %d", i))
+		for j := range out {
+			assert(expected[j].GetSupportRecord().GetSupportCount() == out[j].GetSupportRecord().GetSupportCount(), "Expected the same support counts with diffsets")
+		}
+	}
+
+	options, _ := NewOptionsWith(WithMinSupport(0.05), WithDiffsets())
+	store := NewMemoryTransactionStore()
+	for _, transaction := range transactions {
+		_ = store.AddTransaction(transaction)
+	}
+	stored, _ := NewAprioriFromStore(store)
+	assert(formatRecords(stored.Calculate(options)) == formatRecords(NewApriori(transactions).Calculate(options)), "Expected the same output from a store")
+
+	_, err := NewOptionsWith(WithDiffsets(), WithTransactionIDs(0))
+	assert(err != nil, "Expected an error for diffsets with transaction IDs")
+}
+
+func TestApriori_transactionDifference(t *testing.T) {
+	var a Apriori
+	difference := a.transactionDifference([]int64{1, 2, 4, 7, 9}, []int64{0, 2, 3, 7, 10})
+	assert(fmt.Sprint(difference) == "[1 4 9]", "Unexpected difference: "+fmt.Sprint(difference))
+	assert(a.transactionDifference([]int64{1, 2}, []int64{1, 2}) == nil, "Expected an empty difference")
+}
@@ -0,0 +1,173 @@
+package apriori
+
+import (
+	"errors"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// BinningStrategy is the way the numeric attributes are split into intervals
+type BinningStrategy int
+
+const (
+	// EqualWidthBinning splits the range of the attribute into intervals of the same width
+	EqualWidthBinning BinningStrategy = iota
+	// EqualFrequencyBinning splits the values of the attribute into intervals holding about as many values
+	EqualFrequencyBinning
+)
+
+// Separator between the attribute and its value in the items of the transactions.
+const attributeSeparator = "="
+
+// Discretizer replaces the numeric items of the transactions, e.g. `age=34`, with interval items, e.g.
+// `age∈[30,40)`, so that mixed categorical and numeric datasets can be mined
+type Discretizer struct {
+	edges map[string][]float64
+}
+
+// NewDiscretizer learns the intervals of the numeric attributes of the transactions. An attribute is numeric when
+// all the items carrying it have the `attribute=number` form, the other items are kept as they are.
+func NewDiscretizer(transactions [][]string, bins int, strategy BinningStrategy) (*Discretizer, error) {
+	if bins < 1 {
+		return nil, errors.New("the number of bins must be at least 1")
+	}
+	if strategy != EqualWidthBinning && strategy != EqualFrequencyBinning {
+		return nil, errors.New("unknown binning strategy")
+	}
+
+	values := make(map[string][]float64)
+	categorical := make(map[string]bool)
+	for _, transaction := range transactions {
+		for _, item := range transaction {
+			attribute, value, ok := parseNumericItem(item)
+			if !ok {
+				if i := strings.Index(item, attributeSeparator); i > 0 {
+					categorical[item[:i]] = true
+				}
+				continue
+			}
+			values[attribute] = append(values[attribute], value)
+		}
+	}
+
+	d := Discretizer{edges: make(map[string][]float64)}
+	for attribute, attributeValues := range values {
+		if categorical[attribute] {
+			continue
+		}
+		sort.Float64s(attributeValues)
+		if strategy == EqualWidthBinning {
+			d.edges[attribute] = equalWidthEdges(attributeValues, bins)
+		} else {
+			d.edges[attribute] = equalFrequencyEdges(attributeValues, bins)
+		}
+	}
+
+	return &d, nil
+}
+
+// Discretize is a quick way to learn the intervals of the transactions and replace their numeric items
+func Discretize(transactions [][]string, bins int, strategy BinningStrategy) ([][]string, error) {
+	d, err := NewDiscretizer(transactions, bins, strategy)
+	if err != nil {
+		return nil, err
+	}
+
+	return d.Transform(transactions), nil
+}
+
+// Transform returns a copy of the transactions with the numeric items replaced by their interval items. Values
+// outside of the learned range fall into the first or the last interval.
+func (d *Discretizer) Transform(transactions [][]string) [][]string {
+	transformed := make([][]string, len(transactions))
+	for i, transaction := range transactions {
+		transformed[i] = make([]string, len(transaction))
+		for j, item := range transaction {
+			transformed[i][j] = d.transformItem(item)
+		}
+	}
+
+	return transformed
+}
+
+// Intervals returns the bounds of the intervals learned for the attribute, nil when it isn't numeric
+func (d *Discretizer) Intervals(attribute string) []float64 {
+	return d.edges[attribute]
+}
+
+func (d *Discretizer) transformItem(item string) string {
+	attribute, value, ok := parseNumericItem(item)
+	if !ok {
+		return item
+	}
+	edges, ok := d.edges[attribute]
+	if !ok {
+		return item
+	}
+
+	bin := sort.Search(len(edges), func(i int) bool { return edges[i] > value }) - 1
+	if bin < 0 {
+		bin = 0
+	}
+	if bin > len(edges)-2 {
+		bin = len(edges) - 2
+	}
+
+	closing := ")"
+	if bin == len(edges)-2 {
+		closing = "]"
+	}
+
+	return attribute + "∈[" + formatBound(edges[bin]) + "," + formatBound(edges[bin+1]) + closing
+}
+
+// Returns the attribute and the value of an `attribute=number` item.
+func parseNumericItem(item string) (string, float64, bool) {
+	i := strings.Index(item, attributeSeparator)
+	if i <= 0 {
+		return "", 0, false
+	}
+	value, err := strconv.ParseFloat(item[i+len(attributeSeparator):], 64)
+	if err != nil {
+		return "", 0, false
+	}
+
+	return item[:i], value, true
+}
+
+// Returns the bounds of bins intervals of the same width over the sorted values.
+func equalWidthEdges(values []float64, bins int) []float64 {
+	min, max := values[0], values[len(values)-1]
+	if min == max {
+		return []float64{min, max}
+	}
+
+	edges := make([]float64, bins+1)
+	for i := range edges {
+		edges[i] = min + (max-min)*float64(i)/float64(bins)
+	}
+	edges[bins] = max
+
+	return edges
+}
+
+// Returns the bounds of at most bins intervals holding about the same number of the sorted values.
+func equalFrequencyEdges(values []float64, bins int) []float64 {
+	edges := []float64{values[0]}
+	for i := 1; i < bins; i++ {
+		edge := values[i*len(values)/bins]
+		if edge > edges[len(edges)-1] {
+			edges = append(edges, edge)
+		}
+	}
+	if max := values[len(values)-1]; max > edges[len(edges)-1] || len(edges) == 1 {
+		edges = append(edges, max)
+	}
+
+	return edges
+}
+
+func formatBound(bound float64) string {
+	return strconv.FormatFloat(bound, 'g', -1, 64)
+}
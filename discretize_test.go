@@ -0,0 +1,37 @@
+package apriori
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestDiscretize(t *testing.T) {
+	transactions := [][]string{
+		{"age=20", "amount=10", "beer"},
+		{"age=30", "amount=15", "city=Iasi"},
+		{"age=40", "amount=20.5"},
+		{"age=60", "amount=100", "city=Cluj"},
+	}
+
+	provider := []struct {
+		strategy BinningStrategy
+		out      string
+	}{
+		{EqualWidthBinning, "[[age∈[20,40) amount∈[10,55) beer] [age∈[20,40) amount∈[10,55) city=Iasi] [age∈[40,60] amount∈[10,55)] [age∈[40,60] amount∈[55,100] city=Cluj]]"},
+		{EqualFrequencyBinning, "[[age∈[20,40) amount∈[10,20.5) beer] [age∈[20,40) amount∈[10,20.5) city=Iasi] [age∈[40,60] amount∈[20.5,100]] [age∈[40,60] amount∈[20.5,100] city=Cluj]]"},
+	}
+	for _, data := range provider {
+		transformed, err := Discretize(transactions, 2, data.strategy)
+		assert(err == nil, "Expected the transactions to be discretized")
+		assert(fmt.Sprint(transformed) == data.out, "Unexpected discretized transactions: "+fmt.Sprint(transformed))
+	}
+
+	d, err := NewDiscretizer(transactions, 2, EqualWidthBinning)
+	assert(err == nil, "Expected the discretizer to be created")
+	assert(fmt.Sprint(d.Intervals("age")) == "[20 40 60]", "Unexpected age intervals")
+	assert(d.Intervals("city") == nil, "Expected no intervals for a categorical attribute")
+	assert(fmt.Sprint(d.Transform([][]string{{"age=99", "age=1"}})) == "[[age∈[40,60] age∈[20,40)]]", "Expected out of range values to be clamped")
+
+	_, err = NewDiscretizer(transactions, 0, EqualWidthBinning)
+	assert(err != nil, "Expected an error for zero bins")
+}
@@ -0,0 +1,184 @@
+package apriori
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+)
+
+// ShardCandidates are the itemsets frequent in a shard of the transactions, the candidates of the distributed
+// mining. The fields are exported so that they can be sent between machines, e.g. as JSON.
+type ShardCandidates struct {
+	Itemsets [][]string `json:"itemsets"`
+}
+
+// ShardCounts are the numbers of transactions of a shard containing each candidate, in the order of the candidates.
+// The fields are exported so that they can be sent between machines, e.g. as JSON.
+type ShardCounts struct {
+	Transactions int64   `json:"transactions"`
+	Counts       []int64 `json:"counts"`
+}
+
+// Worker mines a shard of the transactions for MineDistributed. The Apriori struct is a Worker for the transactions
+// it holds, the workers on other machines are called through an implementation of the interface sending the
+// options, the candidates and the results over the network.
+type Worker interface {
+	// MineShard returns the itemsets frequent in the shard
+	MineShard(ctx context.Context, options Options) (ShardCandidates, error)
+	// CountShard returns the number of transactions of the shard containing each candidate
+	CountShard(ctx context.Context, candidates [][]string, options Options) (ShardCounts, error)
+}
+
+// MineDistributed finds the frequent itemsets of the transactions sharded across the workers with the SON algorithm:
+// the itemsets frequent in any shard become the candidates, and their global supports are counted by the workers in
+// a second pass, so the results are the ones of the whole dataset. The workers are called concurrently, the first
+// error stops the other ones. The transaction IDs, negated items, minimum supports by length and target itemset
+// count aren't supported.
+func MineDistributed(ctx context.Context, workers []Worker, options Options) ([]SupportRecord, error) {
+	if err := checkDistributedOptions(options); err != nil {
+		return nil, err
+	}
+	if len(workers) == 0 {
+		return nil, errors.New("at least one worker is needed")
+	}
+
+	// First pass: the candidates are the itemsets frequent in any shard.
+	shards := make([]ShardCandidates, len(workers))
+	err := forEachWorker(ctx, workers, func(ctx context.Context, i int, worker Worker) (err error) {
+		shards[i], err = worker.MineShard(ctx, options)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	var a Apriori
+	candidates := make(map[string][]string)
+	for _, shard := range shards {
+		for _, items := range shard.Itemsets {
+			items = a.normalizeItems(items)
+			candidates[itemsetKey(items)] = items
+		}
+	}
+	counted := countedItemsets(candidates)
+	keys := make([]string, 0, len(counted))
+	for key := range counted {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	itemsets := make([][]string, len(keys))
+	for i, key := range keys {
+		itemsets[i] = counted[key]
+	}
+
+	// Second pass: the global counts of the candidates.
+	shardCounts := make([]ShardCounts, len(workers))
+	err = forEachWorker(ctx, workers, func(ctx context.Context, i int, worker Worker) (err error) {
+		shardCounts[i], err = worker.CountShard(ctx, itemsets, options)
+		if err == nil && len(shardCounts[i].Counts) != len(itemsets) {
+			err = fmt.Errorf("worker %d returned %d counts for %d candidates", i, len(shardCounts[i].Counts), len(itemsets))
+		}
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	var transactionNo int64
+	counts := make(map[string]int64, len(keys))
+	for _, shard := range shardCounts {
+		transactionNo += shard.Transactions
+		for i, count := range shard.Counts {
+			counts[keys[i]] += count
+		}
+	}
+
+	return globalSupportRecords(candidates, counts, nil, transactionNo, options), nil
+}
+
+// MineShard returns the itemsets frequent in the transactions of the Apriori struct, the first pass of
+// MineDistributed. The length, maximum support and all-confidence thresholds are only applied to the global results.
+func (a *Apriori) MineShard(ctx context.Context, options Options) (ShardCandidates, error) {
+	if err := checkDistributedOptions(options); err != nil {
+		return ShardCandidates{}, err
+	}
+	a = a.miningView()
+	options.MinLength = 0
+	options.MaxSupport = 0
+	options.MinAllConfidence = 0
+	options.OnItemset = nil
+	options.Consequent = a.normalizeItems(options.Consequent)
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	supportRecords := make(chan SupportRecord)
+	go a.generateSupportRecords(ctx, supportRecords, options)
+	var shard ShardCandidates
+	for {
+		select {
+		case supportRecord := <-supportRecords:
+			if supportRecord.support == -1 {
+				return shard, ctx.Err()
+			}
+			shard.Itemsets = append(shard.Itemsets, supportRecord.items)
+		case <-ctx.Done():
+			return ShardCandidates{}, ctx.Err()
+		}
+	}
+}
+
+// CountShard returns the number of transactions of the Apriori struct containing each candidate, the second pass of
+// MineDistributed
+func (a *Apriori) CountShard(ctx context.Context, candidates [][]string, options Options) (ShardCounts, error) {
+	a = a.miningView()
+	if len(options.Taxonomy) > 0 {
+		a.materializeAncestors(options.Taxonomy)
+	}
+
+	counts := ShardCounts{Transactions: a.transactionNo, Counts: make([]int64, len(candidates))}
+	for i, items := range candidates {
+		if err := ctx.Err(); err != nil {
+			return ShardCounts{}, err
+		}
+		counts.Counts[i] = int64(len(a.calculateTransactionIndexes(a.normalizeItems(items))))
+	}
+
+	return counts, nil
+}
+
+// Returns an error for the invalid options and the ones that can't be mined distributed.
+func checkDistributedOptions(options Options) error {
+	if err := options.check(); err != nil {
+		return err
+	}
+	if options.KeepTransactionIDs || options.NegatedItemsMinSupport > 0 || len(options.MinSupportByLength) > 0 || options.TargetItemsetCount > 0 {
+		return errors.New("distributed mining doesn't support transaction IDs, negated items, minimum supports by length and target itemset counts")
+	}
+
+	return nil
+}
+
+// Calls fn for every worker concurrently, returning the first error, which cancels the context of the other calls.
+func forEachWorker(ctx context.Context, workers []Worker, fn func(ctx context.Context, i int, worker Worker) error) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var wg sync.WaitGroup
+	var once sync.Once
+	var firstErr error
+	for i, worker := range workers {
+		wg.Add(1)
+		go func(i int, worker Worker) {
+			defer wg.Done()
+			if err := fn(ctx, i, worker); err != nil {
+				once.Do(func() {
+					firstErr = err
+					cancel()
+				})
+			}
+		}(i, worker)
+	}
+	wg.Wait()
+
+	return firstErr
+}
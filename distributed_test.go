@@ -0,0 +1,69 @@
+package apriori
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+)
+
+// failingWorker is a worker whose shard can't be mined.
+type failingWorker struct{}
+
+func (failingWorker) MineShard(ctx context.Context, options Options) (ShardCandidates, error) {
+	return ShardCandidates{}, errors.New("worker unreachable")
+}
+
+func (failingWorker) CountShard(ctx context.Context, candidates [][]string, options Options) (ShardCounts, error) {
+	return ShardCounts{}, errors.New("worker unreachable")
+}
+
+func TestMineDistributed(t *testing.T) {
+	transactions := [][]string{
+		{"beer", "nuts", "cheese"},
+		{"beer", "nuts", "jam"},
+		{"beer", "butter"},
+		{"nuts", "cheese"},
+		{"beer", "nuts", "cheese", "jam"},
+		{"butter"},
+		{"beer", "nuts", "jam", "butter"},
+		{"jam"},
+	}
+	format := func(records []SupportRecord) string {
+		var formatted []string
+		for _, record := range records {
+			formatted = append(formatted, formatSupportRecord(record))
+		}
+		return fmt.Sprint(formatted)
+	}
+
+	for _, shardSize := range []int{1, 3, 8} {
+		var workers []Worker
+		for i := 0; i < len(transactions); i += shardSize {
+			end := i + shardSize
+			if end > len(transactions) {
+				end = len(transactions)
+			}
+			workers = append(workers, NewApriori(transactions[i:end]))
+		}
+
+		options := NewOptions(0.25, 0, 0, 0)
+		options.MinLength = 2
+		options.MinAllConfidence = 0.5
+		records, err := MineDistributed(context.Background(), workers, options)
+		assert(err == nil, "Expected the distributed mining to succeed")
+		expected := format(NewApriori(transactions).FrequentItemsets(options))
+		assert(format(records) == expected, fmt.Sprintf("Unexpected itemsets for shards of %d: %s", shardSize, format(records)))
+	}
+
+	workers := []Worker{NewApriori(transactions), failingWorker{}}
+	_, err := MineDistributed(context.Background(), workers, NewOptions(0.25, 0, 0, 0))
+	assert(err != nil && err.Error() == "worker unreachable", "Expected the error of the worker")
+
+	options := NewOptions(0.25, 0, 0, 0)
+	options.KeepTransactionIDs = true
+	_, err = MineDistributed(context.Background(), workers, options)
+	assert(err != nil, "Expected transaction IDs to be rejected")
+	_, err = MineDistributed(context.Background(), nil, NewOptions(0.25, 0, 0, 0))
+	assert(err != nil, "Expected an error without workers")
+}
@@ -0,0 +1,84 @@
+package apriori
+
+import (
+	"errors"
+	"math"
+)
+
+// SelectDiverseRules returns up to n rules that have a high lift and are dissimilar to each other, with maximal
+// marginal relevance: the next rule is the one maximizing lambda * lift / max lift - (1 - lambda) * its highest
+// Jaccard similarity, over the items of the rules, to the rules already selected. A lambda of 1 ranks by lift only,
+// lower ones favor the diversity over the near-duplicates of one strong pattern. The statistics of the single items
+// are left out.
+func SelectDiverseRules(records []RelationRecord, n int, lambda float64) ([]Rule, error) {
+	if n < 0 {
+		return nil, errors.New("the number of rules must be >= 0")
+	}
+	if lambda < 0 || lambda > 1 {
+		return nil, errors.New("lambda must be between 0 and 1")
+	}
+
+	var a Apriori
+	var rules []Rule
+	var itemsets [][]string
+	maxLift := 0.0
+	for _, record := range records {
+		for _, orderedStatistic := range record.orderedStatistic {
+			if len(orderedStatistic.base) == 0 {
+				continue
+			}
+			rules = append(rules, Rule{orderedStatistic, record.supportRecord.support})
+			itemsets = append(itemsets, a.normalizeItems(append(append([]string{}, orderedStatistic.base...), orderedStatistic.add...)))
+			if orderedStatistic.lift > maxLift {
+				maxLift = orderedStatistic.lift
+			}
+		}
+	}
+
+	var selected []Rule
+	similarities := make([]float64, len(rules)) // Highest similarity of every rule to the selected ones.
+	picked := make([]bool, len(rules))
+	for len(selected) < n && len(selected) < len(rules) {
+		best, bestScore := -1, math.Inf(-1)
+		for i, rule := range rules {
+			if picked[i] {
+				continue
+			}
+			// The rules with an unknown lift are only picked for their diversity.
+			relevance := 0.0
+			if maxLift > 0 && !math.IsNaN(rule.orderedStatistic.lift) {
+				relevance = rule.orderedStatistic.lift / maxLift
+			}
+			if score := lambda*relevance - (1-lambda)*similarities[i]; score > bestScore {
+				best, bestScore = i, score
+			}
+		}
+		picked[best] = true
+		selected = append(selected, rules[best])
+		for i := range rules {
+			similarities[i] = math.Max(similarities[i], jaccard(itemsets[i], itemsets[best]))
+		}
+	}
+
+	return selected, nil
+}
+
+// Returns the Jaccard similarity of the sorted itemsets, the size of their intersection over the size of their
+// union.
+func jaccard(first []string, second []string) float64 {
+	intersection := 0
+	for i, j := 0, 0; i < len(first) && j < len(second); {
+		switch {
+		case first[i] == second[j]:
+			intersection++
+			i++
+			j++
+		case first[i] < second[j]:
+			i++
+		default:
+			j++
+		}
+	}
+
+	return float64(intersection) / float64(len(first)+len(second)-intersection)
+}
@@ -0,0 +1,39 @@
+package apriori
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestSelectDiverseRules(t *testing.T) {
+	transactions := [][]string{
+		{"beer", "nuts", "chips"},
+		{"beer", "nuts", "chips"},
+		{"beer", "nuts", "chips"},
+		{"bread", "butter"},
+		{"bread", "butter"},
+		{"bread", "jam"},
+		{"milk"},
+		{"milk"},
+	}
+	records := NewApriori(transactions).Calculate(NewOptions(0.25, 0.6, 0, 0))
+
+	format := func(rules []Rule) string {
+		var formatted []string
+		for _, rule := range rules {
+			formatted = append(formatted, RuleKey(rule.GetOrderedStatistic()))
+		}
+		return fmt.Sprint(formatted)
+	}
+	// By lift only, the top rules are all about beer, nuts and chips.
+	rules, err := SelectDiverseRules(records, 2, 1)
+	assert(err == nil, "Expected the rules to be selected")
+	assert(format(rules) == `[{"beer"} => {"chips"} {"chips"} => {"beer"}]`, "Unexpected rules: "+format(rules))
+	rules, _ = SelectDiverseRules(records, 2, 0.5)
+	assert(format(rules) == `[{"beer"} => {"chips"} {"bread"} => {"butter"}]`, "Unexpected diverse rules: "+format(rules))
+	rules, _ = SelectDiverseRules(records, 100, 0.5)
+	assert(len(rules) == len(RankBy(records, OrderedStatistic.GetLift)), "Expected all the rules")
+
+	_, err = SelectDiverseRules(records, 2, 1.5)
+	assert(err != nil, "Expected an invalid lambda to be rejected")
+}
@@ -0,0 +1,83 @@
+package apriori
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+)
+
+// DOTOptions are the options of ExportDOT
+type DOTOptions struct {
+	Name        string  // Name of the graph, "rules" when empty.
+	MinLift     float64 // Only the rules with at least this lift are drawn.
+	MaxPenWidth float64 // Width of the edge of the highest lift, 5 when 0.
+}
+
+// ExportDOT renders the rules as a Graphviz graph, e.g. for `dot -Tsvg`: the bases and adds are the nodes, and
+// every rule is an edge labeled and weighted by its lift, the highest lift getting the widest edge. The bases
+// and adds of several items are a single node. The statistics of the single items aren't rules and are skipped.
+func ExportDOT(records []RelationRecord, w io.Writer, opts DOTOptions) error {
+	name := opts.Name
+	if name == "" {
+		name = "rules"
+	}
+	maxPenWidth := opts.MaxPenWidth
+	if maxPenWidth <= 0 {
+		maxPenWidth = 5
+	}
+
+	var rules []OrderedStatistic
+	var supports []float64
+	nodes := make(map[string]bool)
+	maxLift := 0.0
+	for _, record := range records {
+		for _, orderedStatistic := range record.orderedStatistic {
+			if len(orderedStatistic.base) == 0 || orderedStatistic.lift < opts.MinLift {
+				continue
+			}
+			rules = append(rules, orderedStatistic)
+			supports = append(supports, record.supportRecord.support)
+			nodes[dotNode(orderedStatistic.base)] = true
+			nodes[dotNode(orderedStatistic.add)] = true
+			if orderedStatistic.lift > maxLift {
+				maxLift = orderedStatistic.lift
+			}
+		}
+	}
+	sortedNodes := make([]string, 0, len(nodes))
+	for node := range nodes {
+		sortedNodes = append(sortedNodes, node)
+	}
+	sort.Strings(sortedNodes)
+
+	writer := bufio.NewWriter(w)
+	fmt.Fprintf(writer, "digraph %s {\n", dotQuote(name))
+	fmt.Fprintln(writer, "  node [shape=box];")
+	for _, node := range sortedNodes {
+		fmt.Fprintf(writer, "  %s;\n", dotQuote(node))
+	}
+	for i, rule := range rules {
+		penWidth := 1.0
+		if maxLift > 0 {
+			penWidth = 1 + (maxPenWidth-1)*rule.lift/maxLift
+		}
+		tooltip := fmt.Sprintf("support %.4g, confidence %.4g, lift %.4g", supports[i], rule.confidence, rule.lift)
+		fmt.Fprintf(writer, "  %s -> %s [label=%s, penwidth=%.2f, tooltip=%s];\n",
+			dotQuote(dotNode(rule.base)), dotQuote(dotNode(rule.add)), dotQuote(fmt.Sprintf("%.2f", rule.lift)), penWidth, dotQuote(tooltip))
+	}
+	fmt.Fprintln(writer, "}")
+
+	return writer.Flush()
+}
+
+// Returns the label of the node of the items.
+func dotNode(items []string) string {
+	return strings.Join(items, ", ")
+}
+
+// Returns s as a quoted DOT identifier.
+func dotQuote(s string) string {
+	return `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`).Replace(s) + `"`
+}
@@ -0,0 +1,34 @@
+package apriori
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestExportDOT(t *testing.T) {
+	transactions := [][]string{
+		{"beer", "nuts"},
+		{"beer", "nuts"},
+		{"beer"},
+		{"jam"},
+	}
+	records := NewApriori(transactions).Calculate(NewOptions(0.5, 0, 0, 0))
+
+	var buffer bytes.Buffer
+	assert(ExportDOT(records, &buffer, DOTOptions{}) == nil, "Expected the graph to be written")
+	expected := `digraph "rules" {
+  node [shape=box];
+  "beer";
+  "nuts";
+  "beer" -> "nuts" [label="1.33", penwidth=5.00, tooltip="support 0.5, confidence 0.6667, lift 1.333"];
+  "nuts" -> "beer" [label="1.33", penwidth=5.00, tooltip="support 0.5, confidence 1, lift 1.333"];
+}
+`
+	assert(buffer.String() == expected, "Unexpected graph: "+buffer.String())
+
+	buffer.Reset()
+	ExportDOT(records, &buffer, DOTOptions{Name: `my "rules"`, MinLift: 2})
+	assert(buffer.String() == "digraph \"my \\\"rules\\\"\" {\n  node [shape=box];\n}\n", "Expected the names to be escaped and the rules filtered: "+buffer.String())
+	assert(!strings.Contains(buffer.String(), "->"), "Expected the rules below the min lift to be skipped")
+}
@@ -0,0 +1,151 @@
+package apriori
+
+import (
+	"errors"
+	"math"
+	"time"
+)
+
+// Approximate bytes kept per item of an itemset, a string header, and per itemset, the slice header and the
+// statistics of its record.
+const (
+	itemsetItemBytes = 16
+	itemsetBytes     = 64
+)
+
+// WorkEstimate is the extrapolated work of a mining run, see Apriori.EstimateWork
+type WorkEstimate struct {
+	candidates       []float64
+	frequentItemsets []float64
+	duration         time.Duration
+	memoryBytes      float64
+}
+
+// GetCandidates will return the number of candidate itemsets of every length, starting with 1, counted for the
+// lengths 1 and 2 and extrapolated for the longer ones
+func (we WorkEstimate) GetCandidates() []float64 {
+	return we.candidates
+}
+
+// GetFrequentItemsets will return the number of frequent itemsets of every length, starting with 1, counted for the
+// lengths 1 and 2 and extrapolated for the longer ones
+func (we WorkEstimate) GetFrequentItemsets() []float64 {
+	return we.frequentItemsets
+}
+
+// GetTotalCandidates will return the number of candidate itemsets of all the lengths
+func (we WorkEstimate) GetTotalCandidates() float64 {
+	total := 0.0
+	for _, candidates := range we.candidates {
+		total += candidates
+	}
+
+	return total
+}
+
+// GetDuration will return the extrapolated duration of the mining of the frequent itemsets, without the rules
+func (we WorkEstimate) GetDuration() time.Duration {
+	return we.duration
+}
+
+// GetMemoryBytes will return the extrapolated memory of the largest level of candidates and of the frequent itemsets
+func (we WorkEstimate) GetMemoryBytes() float64 {
+	return we.memoryBytes
+}
+
+// EstimateWork counts the candidate and frequent itemsets of the lengths 1 and 2 and extrapolates the ones of the
+// longer lengths, and from them the duration and memory of the whole run, so a service can reject the pathological
+// requests before committing resources. The extrapolation assumes that an itemset is frequent when all its pairs
+// are, each pair of the frequent items being frequent with the probability observed at the length 2: it is a rough
+// order of magnitude, not a bound. The options are validated, their consequent isn't supported and their
+// TargetItemsetCount is ignored.
+func (a *Apriori) EstimateWork(options Options) (WorkEstimate, error) {
+	if err := options.check(); err != nil {
+		return WorkEstimate{}, err
+	}
+	if len(options.Consequent) > 0 {
+		return WorkEstimate{}, errors.New("work estimation doesn't support a consequent")
+	}
+	a = a.miningView()
+
+	start := time.Now()
+	candidates := a.initialCandidates(options)
+	var frequentItems [][]string
+	for _, candidate := range candidates {
+		if a.calculateSupport(candidate) >= options.minSupportFrom(1) {
+			frequentItems = append(frequentItems, candidate)
+		}
+	}
+	estimate := WorkEstimate{
+		candidates:       []float64{float64(len(candidates))},
+		frequentItemsets: []float64{float64(len(frequentItems))},
+	}
+	// The cost of a candidate grows with its number of items, whose index lists are intersected.
+	itemsCounted := float64(len(candidates))
+
+	if options.maxLength != 1 && len(frequentItems) > 1 {
+		pairs := a.createNextCandidates(frequentItems, 2)
+		frequentPairs := 0
+		for _, pair := range pairs {
+			if len(options.Taxonomy) > 0 && a.containsAncestorPair(pair, options.Taxonomy) {
+				continue
+			}
+			if options.DistinctAttributes && a.containsAttributePair(pair) {
+				continue
+			}
+			support := a.calculateSupport(pair)
+			if support >= options.minSupportFrom(2) && a.calculateAllConfidence(pair, support) >= options.MinAllConfidence {
+				frequentPairs++
+			}
+		}
+		estimate.candidates = append(estimate.candidates, float64(len(pairs)))
+		estimate.frequentItemsets = append(estimate.frequentItemsets, float64(frequentPairs))
+		itemsCounted += 2 * float64(len(pairs))
+
+		// An itemset of length k is a candidate when all its pairs but the one of its last 2 items are frequent, and
+		// frequent when all of them are.
+		p := float64(frequentPairs) / float64(len(pairs))
+		n := float64(len(frequentItems))
+		for k := 3.0; options.maxLength == 0 || k <= float64(options.maxLength); k++ {
+			combinations := binomialFloat(n, k)
+			pairsOfK := k * (k - 1) / 2
+			candidatesOfK := combinations * math.Pow(p, pairsOfK-1)
+			if candidatesOfK < 1 {
+				break
+			}
+			estimate.candidates = append(estimate.candidates, candidatesOfK)
+			estimate.frequentItemsets = append(estimate.frequentItemsets, combinations*math.Pow(p, pairsOfK))
+		}
+	}
+	elapsed := time.Since(start)
+
+	estimatedItems := 0.0
+	for i, candidatesOfK := range estimate.candidates {
+		length := float64(i + 1)
+		estimatedItems += length * candidatesOfK
+		levelBytes := candidatesOfK * (length*itemsetItemBytes + itemsetBytes)
+		if levelBytes > estimate.memoryBytes {
+			estimate.memoryBytes = levelBytes
+		}
+	}
+	for i, frequentOfK := range estimate.frequentItemsets {
+		estimate.memoryBytes += frequentOfK * (float64(i+1)*itemsetItemBytes + itemsetBytes)
+	}
+	if itemsCounted > 0 {
+		estimate.duration = time.Duration(math.Min(float64(elapsed)*estimatedItems/itemsCounted, math.MaxInt64))
+	}
+
+	return estimate, nil
+}
+
+// Returns the number of combinations of k of n items, as a float since it can overflow the integers.
+func binomialFloat(n float64, k float64) float64 {
+	if k > n {
+		return 0
+	}
+	lgammaN, _ := math.Lgamma(n + 1)
+	lgammaK, _ := math.Lgamma(k + 1)
+	lgammaNK, _ := math.Lgamma(n - k + 1)
+
+	return math.Round(math.Exp(lgammaN - lgammaK - lgammaNK))
+}
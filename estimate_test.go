@@ -0,0 +1,32 @@
+package apriori
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestApriori_EstimateWork(t *testing.T) {
+	// Every pair of items is frequent, so the extrapolation is exact.
+	var transactions [][]string
+	for i := 0; i < 10; i++ {
+		transactions = append(transactions, []string{"beer", "nuts", "cheese", "jam", "wine"})
+	}
+	transactions = append(transactions, []string{"bread"})
+	estimate, err := NewApriori(transactions).EstimateWork(NewOptions(0.5, 0, 0, 0))
+	assert(err == nil, "Expected the estimation to succeed")
+	assert(fmt.Sprint(estimate.GetCandidates()) == "[6 10 10 5 1]", "Unexpected candidates: "+fmt.Sprint(estimate.GetCandidates()))
+	assert(fmt.Sprint(estimate.GetFrequentItemsets()) == "[5 10 10 5 1]", "Unexpected frequent itemsets: "+fmt.Sprint(estimate.GetFrequentItemsets()))
+	assert(estimate.GetTotalCandidates() == 32 && estimate.GetMemoryBytes() > 0, "Unexpected totals of the estimate")
+	estimate, _ = NewApriori(transactions).EstimateWork(NewOptions(0.5, 0, 0, 3))
+	assert(len(estimate.GetCandidates()) == 3, "Expected the maximum length to stop the extrapolation")
+
+	// With a third of the pairs frequent, fewer than 1 candidate of length 3 is expected.
+	transactions = [][]string{{"a", "b"}, {"a", "b"}, {"c", "d"}, {"c", "d"}, {"a"}, {"c"}, {"b", "d"}, {"a", "c"}}
+	estimate, _ = NewApriori(transactions).EstimateWork(NewOptions(0.25, 0, 0, 3))
+	frequent := NewApriori(transactions).FrequentItemsets(NewOptions(0.25, 0, 0, 2))
+	assert(len(estimate.GetFrequentItemsets()) == 2 && estimate.GetFrequentItemsets()[0]+estimate.GetFrequentItemsets()[1] == float64(len(frequent)),
+		"Expected the counted itemsets of the lengths 1 and 2: "+fmt.Sprint(estimate.GetFrequentItemsets()))
+
+	_, err = NewApriori(transactions).EstimateWork(Options{minSupport: 0.1, Consequent: []string{"a"}})
+	assert(err != nil, "Expected an error for a consequent")
+}
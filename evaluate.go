@@ -0,0 +1,66 @@
+package apriori
+
+import "math"
+
+// RuleEvaluation contains a rule and how it performs on held-out transactions
+type RuleEvaluation struct {
+	rule      OrderedStatistic
+	coverage  float64
+	precision float64
+	lift      float64
+}
+
+// GetRule will return the evaluated rule, with its statistics on the mined transactions
+func (re RuleEvaluation) GetRule() OrderedStatistic {
+	return re.rule
+}
+
+// GetCoverage will return the fraction of the held-out transactions that contain the base of the rule
+func (re RuleEvaluation) GetCoverage() float64 {
+	return re.coverage
+}
+
+// GetPrecision will return the confidence of the rule on the held-out transactions, the fraction of the covered
+// ones that contain the add too, NaN when none is covered
+func (re RuleEvaluation) GetPrecision() float64 {
+	return re.precision
+}
+
+// GetLift will return the lift of the rule on the held-out transactions, NaN when none is covered or none
+// contains the add
+func (re RuleEvaluation) GetLift() float64 {
+	return re.lift
+}
+
+// GetLiftDrift will return the change of the lift from the mined to the held-out transactions, a large drop
+// hints at an overfit rule
+func (re RuleEvaluation) GetLiftDrift() float64 {
+	return re.lift - re.rule.lift
+}
+
+// EvaluateRules computes the coverage, precision and lift of the rules of the records on held-out transactions,
+// e.g. to detect the overfit rules before deploying them for recommendations. The statistics of the single items,
+// with an empty base, are left out.
+func EvaluateRules(records []RelationRecord, testTransactions [][]string) []RuleEvaluation {
+	test := NewApriori(testTransactions)
+	var evaluations []RuleEvaluation
+	for _, record := range records {
+		for _, orderedStatistic := range record.orderedStatistic {
+			if len(orderedStatistic.base) == 0 {
+				continue
+			}
+			items := test.normalizeItems(append(append([]string{}, orderedStatistic.base...), orderedStatistic.add...))
+			coverage := test.calculateSupport(orderedStatistic.base)
+			precision, lift := math.NaN(), math.NaN()
+			if coverage > 0 {
+				precision = test.calculateSupport(items) / coverage
+				if addSupport := test.calculateSupport(orderedStatistic.add); addSupport > 0 {
+					lift = precision / addSupport
+				}
+			}
+			evaluations = append(evaluations, RuleEvaluation{orderedStatistic, coverage, precision, lift})
+		}
+	}
+
+	return evaluations
+}
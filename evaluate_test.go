@@ -0,0 +1,33 @@
+package apriori
+
+import (
+	"fmt"
+	"math"
+	"testing"
+)
+
+func TestEvaluateRules(t *testing.T) {
+	training := [][]string{
+		{"beer", "nuts"},
+		{"beer", "nuts"},
+		{"beer", "nuts", "jam"},
+		{"jam"},
+	}
+	test := [][]string{
+		{"beer", "nuts"},
+		{"beer"},
+		{"nuts", "jam"},
+		{"jam"},
+	}
+	records := NewApriori(training).Calculate(NewOptions(0.5, 0.0, 0.0, 2))
+
+	var evaluations []string
+	for _, evaluation := range EvaluateRules(records, test) {
+		evaluations = append(evaluations, fmt.Sprintf("%s %.2f %.2f %.2f %.2f", RuleKey(evaluation.GetRule()), evaluation.GetCoverage(),
+			evaluation.GetPrecision(), evaluation.GetLift(), evaluation.GetLiftDrift()))
+	}
+	assert(fmt.Sprint(evaluations) == `[{"beer"} => {"nuts"} 0.50 0.50 1.00 -0.33 {"nuts"} => {"beer"} 0.50 0.50 1.00 -0.33]`, "Unexpected evaluations: "+fmt.Sprint(evaluations))
+
+	evaluation := EvaluateRules(records, [][]string{{"jam"}})[0]
+	assert(evaluation.GetCoverage() == 0 && math.IsNaN(evaluation.GetPrecision()) && math.IsNaN(evaluation.GetLift()), "Expected NaN statistics for uncovered rules")
+}
@@ -0,0 +1,56 @@
+package apriori
+
+import (
+	"math"
+	"strings"
+	"text/template"
+)
+
+// DefaultExplanation is the explanation template of a cross-sell rule
+const DefaultExplanation = "Customers who bought {{.Base}} also bought {{.Add}} {{.ConfidencePct}}% of the time"
+
+// RuleExplanation is the data of a rule given to the explanation templates
+type RuleExplanation struct {
+	Base          string   // Items of the base joined with ", "
+	Add           string   // Items of the add joined with ", "
+	BaseItems     []string // Items of the base
+	AddItems      []string // Items of the add
+	Confidence    float64  // Confidence of the rule
+	ConfidencePct int      // Confidence of the rule as a rounded percentage
+	Lift          float64  // Lift of the rule
+}
+
+// Explainer renders human-readable explanations of rules from a text/template, e.g. to embed them into emails or
+// dashboards. The output isn't escaped, the items should be escaped by the template for HTML.
+type Explainer struct {
+	template *template.Template
+}
+
+// NewExplainer parses an explanation template, e.g. DefaultExplanation, executed with a RuleExplanation
+func NewExplainer(text string) (*Explainer, error) {
+	tmpl, err := template.New("explanation").Parse(text)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Explainer{template: tmpl}, nil
+}
+
+// Explain renders the explanation of the rule
+func (e *Explainer) Explain(orderedStatistic OrderedStatistic) (string, error) {
+	var builder strings.Builder
+	err := e.template.Execute(&builder, RuleExplanation{
+		Base:          strings.Join(orderedStatistic.base, ", "),
+		Add:           strings.Join(orderedStatistic.add, ", "),
+		BaseItems:     orderedStatistic.base,
+		AddItems:      orderedStatistic.add,
+		Confidence:    orderedStatistic.confidence,
+		ConfidencePct: int(math.Round(orderedStatistic.confidence * 100)),
+		Lift:          orderedStatistic.lift,
+	})
+	if err != nil {
+		return "", err
+	}
+
+	return builder.String(), nil
+}
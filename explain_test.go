@@ -0,0 +1,22 @@
+package apriori
+
+import "testing"
+
+func TestExplainer(t *testing.T) {
+	explainer, err := NewExplainer(DefaultExplanation)
+	assert(err == nil, "Expected the default template to parse")
+	explanation, err := explainer.Explain(NewOrderedStatistic([]string{"beer", "chips"}, []string{"nuts"}, 0.756, 1.4))
+	assert(err == nil, "Expected the explanation to render")
+	assert(explanation == "Customers who bought beer, chips also bought nuts 76% of the time", "Unexpected explanation: "+explanation)
+
+	explainer, err = NewExplainer(`{{range $i, $item := .AddItems}}{{if $i}} & {{end}}{{$item}}{{end}} ({{printf "%.1f" .Lift}}x)`)
+	assert(err == nil, "Expected the template to parse")
+	explanation, _ = explainer.Explain(NewOrderedStatistic([]string{"beer"}, []string{"jam", "nuts"}, 0.5, 2))
+	assert(explanation == "jam & nuts (2.0x)", "Unexpected explanation: "+explanation)
+
+	_, err = NewExplainer("{{.Base")
+	assert(err != nil, "Expected an error for an invalid template")
+	explainer, _ = NewExplainer("{{.Unknown}}")
+	_, err = explainer.Explain(NewOrderedStatistic([]string{"beer"}, []string{"nuts"}, 0.5, 2))
+	assert(err != nil, "Expected an error for an unknown field")
+}
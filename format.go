@@ -0,0 +1,155 @@
+package apriori
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"unicode/utf8"
+)
+
+// TableStyle is the layout of the tables written by WriteTable
+type TableStyle int
+
+const (
+	// ASCIITable draws the borders of the table with +, - and |
+	ASCIITable TableStyle = iota
+	// MarkdownTable writes a GitHub flavored Markdown table
+	MarkdownTable
+)
+
+// Returns the base, add, support, confidence and lift of every rule, formatted, leaving out the statistics of the
+// single items.
+func formattedRules(records []RelationRecord, precision int) [][]string {
+	var rows [][]string
+	for _, record := range records {
+		for _, orderedStatistic := range record.orderedStatistic {
+			if len(orderedStatistic.base) == 0 {
+				continue
+			}
+			rows = append(rows, []string{
+				"{" + strings.Join(orderedStatistic.base, ", ") + "}",
+				"{" + strings.Join(orderedStatistic.add, ", ") + "}",
+				fmt.Sprintf("%.*g", precision, record.supportRecord.support),
+				fmt.Sprintf("%.*g", precision, orderedStatistic.confidence),
+				fmt.Sprintf("%.*g", precision, orderedStatistic.lift),
+			})
+		}
+	}
+
+	return rows
+}
+
+// String returns the rule like Format does, e.g. "{beer} => {diapers} conf=0.612 lift=3.4", or with both
+// confidences when it is bidirectional, e.g. "{beer} <=> {diapers} conf=0.612/0.5 lift=3.4"
+func (os OrderedStatistic) String() string {
+	if os.bidirectional {
+		return fmt.Sprintf("{%s} <=> {%s} conf=%.3g/%.3g lift=%.3g", strings.Join(os.base, ", "), strings.Join(os.add, ", "), os.confidence, os.reverseConfidence, os.lift)
+	}
+
+	return fmt.Sprintf("{%s} => {%s} conf=%.3g lift=%.3g", strings.Join(os.base, ", "), strings.Join(os.add, ", "), os.confidence, os.lift)
+}
+
+// String returns the itemset, its support and its rules, e.g.
+// "{beer, diapers} supp=0.02 [{beer} => {diapers} conf=0.612 lift=3.4]"
+func (r RelationRecord) String() string {
+	rules := make([]string, len(r.orderedStatistic))
+	for i, orderedStatistic := range r.orderedStatistic {
+		rules[i] = orderedStatistic.String()
+	}
+
+	return fmt.Sprintf("{%s} supp=%.3g [%s]", strings.Join(r.supportRecord.items, ", "), r.supportRecord.support, strings.Join(rules, ", "))
+}
+
+// Format returns a line per rule, e.g. "{beer} => {diapers}  supp=0.02 conf=0.61 lift=3.4", with the statistics
+// aligned, for CLI output and logs
+func Format(records []RelationRecord) string {
+	var lines [][]string
+	for _, row := range formattedRules(records, 3) {
+		lines = append(lines, []string{row[0] + " => " + row[1], "supp=" + row[2], "conf=" + row[3], "lift=" + row[4]})
+	}
+	widths := make([]int, 4)
+	for _, line := range lines {
+		for i, cell := range line {
+			if length := utf8.RuneCountInString(cell); length > widths[i] {
+				widths[i] = length
+			}
+		}
+	}
+	pad := func(line []string, i int) string {
+		return line[i] + strings.Repeat(" ", widths[i]-utf8.RuneCountInString(line[i]))
+	}
+
+	var builder strings.Builder
+	for _, line := range lines {
+		fmt.Fprintf(&builder, "%s  %s %s %s\n", pad(line, 0), pad(line, 1), pad(line, 2), line[3])
+	}
+
+	return builder.String()
+}
+
+// WriteTable writes the rules as a table with base, add, support, confidence and lift columns, for reports
+func WriteTable(w io.Writer, records []RelationRecord, style TableStyle) error {
+	header := []string{"base", "add", "support", "confidence", "lift"}
+	rows := formattedRules(records, 4)
+	if style == MarkdownTable {
+		for _, row := range rows {
+			for i := range row {
+				row[i] = strings.ReplaceAll(row[i], "|", `\|`)
+			}
+		}
+	}
+
+	widths := make([]int, len(header))
+	for _, row := range append([][]string{header}, rows...) {
+		for i, cell := range row {
+			if length := utf8.RuneCountInString(cell); length > widths[i] {
+				widths[i] = length
+			}
+		}
+	}
+	// The statistics are right aligned.
+	line := func(row []string) string {
+		cells := make([]string, len(row))
+		for i, cell := range row {
+			padding := strings.Repeat(" ", widths[i]-utf8.RuneCountInString(cell))
+			if i < 2 {
+				cells[i] = cell + padding
+			} else {
+				cells[i] = padding + cell
+			}
+		}
+		return "| " + strings.Join(cells, " | ") + " |\n"
+	}
+
+	var builder strings.Builder
+	switch style {
+	case MarkdownTable:
+		builder.WriteString(line(header))
+		separators := make([]string, len(widths))
+		for i, width := range widths {
+			if i < 2 {
+				separators[i] = strings.Repeat("-", width)
+			} else {
+				separators[i] = strings.Repeat("-", width-1) + ":"
+			}
+		}
+		builder.WriteString("| " + strings.Join(separators, " | ") + " |\n")
+		for _, row := range rows {
+			builder.WriteString(line(row))
+		}
+	default:
+		borders := make([]string, len(widths))
+		for i, width := range widths {
+			borders[i] = strings.Repeat("-", width+2)
+		}
+		border := "+" + strings.Join(borders, "+") + "+\n"
+		builder.WriteString(border + line(header) + border)
+		for _, row := range rows {
+			builder.WriteString(line(row))
+		}
+		builder.WriteString(border)
+	}
+
+	_, err := io.WriteString(w, builder.String())
+	return err
+}
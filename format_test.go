@@ -0,0 +1,58 @@
+package apriori
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestFormat(t *testing.T) {
+	records := []RelationRecord{
+		NewRelationRecord(NewSupportRecord([]string{"beer", "diapers"}, 0.02), []OrderedStatistic{
+			NewOrderedStatistic([]string{"beer"}, []string{"diapers"}, 0.6123, 3.4),
+			NewOrderedStatistic([]string{"diapers"}, []string{"beer"}, 0.25, 3.4),
+		}),
+		NewRelationRecord(NewSupportRecord([]string{"ipa", "nuts", "¬jam"}, 0.125), []OrderedStatistic{
+			NewOrderedStatistic([]string{"ipa", "¬jam"}, []string{"nuts"}, 1, 1.25),
+		}),
+	}
+
+	expected := "{beer} => {diapers}    supp=0.02  conf=0.612 lift=3.4\n" +
+		"{diapers} => {beer}    supp=0.02  conf=0.25  lift=3.4\n" +
+		"{ipa, ¬jam} => {nuts}  supp=0.125 conf=1     lift=1.25\n"
+	assert(Format(records) == expected, "Unexpected format: "+Format(records))
+}
+
+func TestWriteTable(t *testing.T) {
+	records := []RelationRecord{
+		NewRelationRecord(NewSupportRecord([]string{"beer", "a|b"}, 0.5), []OrderedStatistic{
+			NewOrderedStatistic(nil, []string{"beer"}, 0.5, 1),
+			NewOrderedStatistic([]string{"beer"}, []string{"a|b"}, 0.66666, 1.3333),
+		}),
+	}
+
+	var buffer bytes.Buffer
+	assert(WriteTable(&buffer, records, ASCIITable) == nil, "Expected the table to be written")
+	expected := "+--------+-------+---------+------------+-------+\n" +
+		"| base   | add   | support | confidence |  lift |\n" +
+		"+--------+-------+---------+------------+-------+\n" +
+		"| {beer} | {a|b} |     0.5 |     0.6667 | 1.333 |\n" +
+		"+--------+-------+---------+------------+-------+\n"
+	assert(buffer.String() == expected, "Unexpected ASCII table:\n"+buffer.String())
+
+	buffer.Reset()
+	assert(WriteTable(&buffer, records, MarkdownTable) == nil, "Expected the table to be written")
+	expected = "| base   | add    | support | confidence |  lift |\n" +
+		"| ------ | ------ | ------: | ---------: | ----: |\n" +
+		"| {beer} | {a\\|b} |     0.5 |     0.6667 | 1.333 |\n"
+	assert(buffer.String() == expected, "Unexpected Markdown table:\n"+buffer.String())
+}
+
+func TestRelationRecord_String(t *testing.T) {
+	record := NewRelationRecord(NewSupportRecord([]string{"beer", "diapers"}, 0.02), []OrderedStatistic{
+		NewOrderedStatistic([]string{"beer"}, []string{"diapers"}, 0.6123, 3.4),
+		NewOrderedStatistic(nil, []string{"beer"}, 0.25, 1),
+	})
+
+	expected := "{beer, diapers} supp=0.02 [{beer} => {diapers} conf=0.612 lift=3.4, {} => {beer} conf=0.25 lift=1]"
+	assert(record.String() == expected, "Unexpected string: "+record.String())
+}
@@ -0,0 +1,36 @@
+package apriori
+
+// Rule is a rule of the mining results together with the support of its itemset, which the OrderedStatistic
+// leaves to the RelationRecord
+type Rule struct {
+	orderedStatistic OrderedStatistic
+	support          float64
+}
+
+// GetOrderedStatistic will return the base, add and statistics of the rule
+func (r Rule) GetOrderedStatistic() OrderedStatistic {
+	return r.orderedStatistic
+}
+
+// GetSupport will return the support of the rule itemset
+func (r Rule) GetSupport() float64 {
+	return r.support
+}
+
+// GroupByConsequent returns the rules of the records grouped by their add, keyed by ItemsetKey(add), e.g. the
+// rules driving the purchases of jam are under ItemsetKey([]string{"jam"}). The rules keep the order of the
+// records, and the statistics of the single items, with an empty base, are left out.
+func GroupByConsequent(records []RelationRecord) map[string][]Rule {
+	groups := make(map[string][]Rule)
+	for _, record := range records {
+		for _, orderedStatistic := range record.orderedStatistic {
+			if len(orderedStatistic.base) == 0 {
+				continue
+			}
+			key := ItemsetKey(orderedStatistic.add)
+			groups[key] = append(groups[key], Rule{orderedStatistic, record.supportRecord.support})
+		}
+	}
+
+	return groups
+}
@@ -0,0 +1,33 @@
+package apriori
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestGroupByConsequent(t *testing.T) {
+	transactions := [][]string{
+		{"beer", "nuts", "cheese"},
+		{"beer", "nuts", "jam"},
+		{"beer", "butter"},
+		{"nuts", "cheese"},
+	}
+	records := NewApriori(transactions).Calculate(NewOptions(0.25, 0.5, 0.0, 2))
+
+	groups := GroupByConsequent(records)
+	var drivers []string
+	for _, rule := range groups[ItemsetKey([]string{"nuts"})] {
+		drivers = append(drivers, fmt.Sprintf("%v %.2f %.2f", rule.GetOrderedStatistic().GetBase(), rule.GetSupport(), rule.GetOrderedStatistic().GetConfidence()))
+	}
+	assert(fmt.Sprint(drivers) == "[[beer] 0.50 0.67 [cheese] 0.50 1.00 [jam] 0.25 1.00]", "Unexpected rules for nuts: "+fmt.Sprint(drivers))
+
+	rules := 0
+	for key, group := range groups {
+		for _, rule := range group {
+			assert(ItemsetKey(rule.GetOrderedStatistic().GetAdd()) == key, "Expected the rules to be grouped by their add")
+			assert(len(rule.GetOrderedStatistic().GetBase()) > 0, "Expected the single items to be left out")
+			rules++
+		}
+	}
+	assert(rules == 8, fmt.Sprintf("Expected all the rules to be grouped, got %d", rules))
+}
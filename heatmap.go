@@ -0,0 +1,114 @@
+package apriori
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"sort"
+	"strconv"
+)
+
+// HeatmapFormat is the format of the matrix written by WriteLiftMatrix
+type HeatmapFormat int
+
+const (
+	// HeatmapCSV is a CSV file with the items as header and as first column
+	HeatmapCSV HeatmapFormat = iota
+	// HeatmapJSON is a JSON object with the items and the rows of lifts
+	HeatmapJSON
+)
+
+// LiftMatrix holds the lifts of the pairs of items, e.g. for merchandising heatmaps: Lifts[i][j] is the lift of the
+// pair of Items[i] and Items[j], 0 when they are never bought together and NaN on the diagonal.
+type LiftMatrix struct {
+	Items []string
+	Lifts [][]float64
+}
+
+// LiftMatrix returns the lifts of the pairs of the topK most frequent items, or of all the items when topK <= 0.
+// The items are sorted by decreasing support, then by name. The lifts are computed from the transactions, without
+// mining.
+func (a *Apriori) LiftMatrix(topK int) LiftMatrix {
+	items := a.Items()
+	indexes := make(map[string][]int64, len(items))
+	supports := make(map[string]float64, len(items))
+	for _, item := range items {
+		indexes[item] = a.storedItemIndexes(item)
+		supports[item] = a.indexesToSupport([]string{item}, indexes[item])
+	}
+	sort.SliceStable(items, func(i, j int) bool {
+		return supports[items[i]] > supports[items[j]]
+	})
+	if topK > 0 && len(items) > topK {
+		items = items[:topK]
+	}
+
+	matrix := LiftMatrix{Items: items, Lifts: make([][]float64, len(items))}
+	for i := range items {
+		matrix.Lifts[i] = make([]float64, len(items))
+		matrix.Lifts[i][i] = math.NaN()
+	}
+	for i, first := range items {
+		for j := i + 1; j < len(items); j++ {
+			second := items[j]
+			pairIndexes := a.transactionIntersection(indexes[first], indexes[second])
+			lift := 0.0
+			if len(pairIndexes) > 0 {
+				lift = a.indexesToSupport([]string{first, second}, pairIndexes) / (supports[first] * supports[second])
+			}
+			matrix.Lifts[i][j], matrix.Lifts[j][i] = lift, lift
+		}
+	}
+
+	return matrix
+}
+
+// WriteLiftMatrix writes the matrix for heatmap tools, as CSV with an empty top left cell, or as JSON with the items
+// and the rows of lifts. The lifts of the diagonal are empty in CSV and null in JSON.
+func WriteLiftMatrix(w io.Writer, matrix LiftMatrix, format HeatmapFormat) error {
+	switch format {
+	case HeatmapCSV:
+		writer := csv.NewWriter(w)
+		if err := writer.Write(append([]string{""}, matrix.Items...)); err != nil {
+			return err
+		}
+		for i, item := range matrix.Items {
+			row := []string{item}
+			for _, lift := range matrix.Lifts[i] {
+				if math.IsNaN(lift) {
+					row = append(row, "")
+				} else {
+					row = append(row, strconv.FormatFloat(lift, 'g', -1, 64))
+				}
+			}
+			if err := writer.Write(row); err != nil {
+				return err
+			}
+		}
+		writer.Flush()
+
+		return writer.Error()
+	case HeatmapJSON:
+		heatmap := struct {
+			Items []string     `json:"items"`
+			Lifts [][]*float64 `json:"lifts"`
+		}{Items: matrix.Items, Lifts: make([][]*float64, len(matrix.Lifts))}
+		if heatmap.Items == nil {
+			heatmap.Items = []string{}
+		}
+		for i, row := range matrix.Lifts {
+			heatmap.Lifts[i] = make([]*float64, len(row))
+			for j := range row {
+				if !math.IsNaN(row[j]) {
+					heatmap.Lifts[i][j] = &row[j]
+				}
+			}
+		}
+
+		return json.NewEncoder(w).Encode(heatmap)
+	default:
+		return fmt.Errorf("unknown heatmap format %d", format)
+	}
+}
@@ -0,0 +1,35 @@
+package apriori
+
+import (
+	"bytes"
+	"fmt"
+	"testing"
+)
+
+func TestApriori_LiftMatrix(t *testing.T) {
+	transactions := [][]string{
+		{"beer", "nuts"},
+		{"beer", "nuts"},
+		{"beer", "jam"},
+		{"bread"},
+	}
+	matrix := NewApriori(transactions).LiftMatrix(3)
+	assert(fmt.Sprint(matrix.Items) == "[beer nuts bread]", fmt.Sprint("Unexpected items ", matrix.Items))
+	assert(fmt.Sprint(matrix.Lifts) == "[[NaN 1.3333333333333333 0] [1.3333333333333333 NaN 0] [0 0 NaN]]", fmt.Sprint("Unexpected lifts ", matrix.Lifts))
+
+	var buffer bytes.Buffer
+	assert(WriteLiftMatrix(&buffer, matrix, HeatmapCSV) == nil, "Expected the CSV to be written")
+	expected := ",beer,nuts,bread\n" +
+		"beer,,1.3333333333333333,0\n" +
+		"nuts,1.3333333333333333,,0\n" +
+		"bread,0,0,\n"
+	assert(buffer.String() == expected, "Unexpected CSV: "+buffer.String())
+
+	buffer.Reset()
+	assert(WriteLiftMatrix(&buffer, matrix, HeatmapJSON) == nil, "Expected the JSON to be written")
+	expected = `{"items":["beer","nuts","bread"],"lifts":[[null,1.3333333333333333,0],[1.3333333333333333,null,0],[0,0,null]]}` + "\n"
+	assert(buffer.String() == expected, "Unexpected JSON: "+buffer.String())
+
+	assert(len(NewApriori(transactions).LiftMatrix(0).Items) == 4, "Expected all the items")
+	assert(WriteLiftMatrix(&buffer, matrix, HeatmapFormat(5)) != nil, "Expected an error for an unknown format")
+}
@@ -0,0 +1,108 @@
+package apriori
+
+import (
+	"errors"
+	"sort"
+)
+
+// UpdateFrequentItemsets adds the transactions to the Apriori struct and returns the frequent itemsets of all the
+// transactions, reusing the frequent itemsets previously returned by FrequentItemsets with the same options (FUP).
+// The previously frequent itemsets are only counted in the new transactions, and the other candidates are only
+// counted in all the transactions when they are frequent in the new ones. The consequent, negated items, taxonomy,
+// minimum length, maximum support, minimum all-confidence, minimum supports by length, target itemset count,
+// distinct attributes, quantity support, itemset callback and checkpoint options are not supported. Like
+// FrequentItemsets, the rule options are ignored.
+func (a *Apriori) UpdateFrequentItemsets(previous []SupportRecord, transactions [][]string, options Options) ([]SupportRecord, error) {
+	if err := options.check(); err != nil {
+		return nil, err
+	}
+	if len(options.Consequent) > 0 || options.NegatedItemsMinSupport > 0 || len(options.Taxonomy) > 0 ||
+		options.MinLength > 0 || options.MaxSupport > 0 || options.MinAllConfidence > 0 {
+		return nil, errors.New("incremental update doesn't support the consequent, negated items, taxonomy, minimum length, maximum support and minimum all-confidence options")
+	}
+	if len(options.MinSupportByLength) > 0 || options.TargetItemsetCount > 0 || options.DistinctAttributes ||
+		options.QuantitySupport != CountSupport || options.OnItemset != nil || options.CheckpointPath != "" {
+		return nil, errors.New("incremental update doesn't support the minimum supports by length, target itemset count, distinct attributes, quantity support, itemset callback and checkpoint options")
+	}
+	if a.weights != nil {
+		return nil, errors.New("incremental update doesn't support weighted transactions")
+	}
+
+	previousCounts := make(map[string]int64, len(previous))
+	for _, record := range previous {
+		items := a.normalizeItems(record.items)
+		count := record.supportCount
+		if count == 0 {
+			count = a.calculateSupportCount(items)
+		}
+		previousCounts[itemsetKey(items)] = count
+	}
+
+	from := a.transactionNo
+	if err := a.AddTransactionsBatch(transactions, 1); err != nil {
+		return nil, err
+	}
+	added := a.transactionNo - from
+	a = a.miningView()
+
+	var records []SupportRecord
+	candidates := a.initialCandidates(options)
+	length := 1
+	for len(candidates) > 0 {
+		if options.maxLength != 0 && length > options.maxLength {
+			break
+		}
+		var relations [][]string
+		for _, candidate := range candidates {
+			deltaCount := int64(len(a.calculateTransactionIndexesFrom(candidate, from)))
+			count, wasFrequent := previousCounts[itemsetKey(candidate)]
+			if wasFrequent {
+				count += deltaCount
+			} else {
+				// Not frequent in the previous transactions, so it can only be frequent overall when it is
+				// frequent in the new ones.
+				if added == 0 || float64(deltaCount)/float64(added) < options.minSupport {
+					continue
+				}
+				count = a.calculateSupportCount(candidate)
+			}
+			support := a.countToSupport(count)
+			if support < options.minSupport {
+				continue
+			}
+			relations = append(relations, candidate)
+
+			record := SupportRecord{items: candidate, support: support, supportCount: count, allConfidence: a.calculateAllConfidence(candidate, support)}
+			if options.KeepTransactionIDs {
+				record = a.newSupportRecord(candidate, support, record.allConfidence, a.calculateTransactionIndexes(candidate), options)
+			}
+			records = append(records, record)
+		}
+		length++
+		candidates = a.createNextCandidates(relations, length)
+	}
+
+	return records, nil
+}
+
+// Returns the indexes, starting with from, of the transactions that contain all the (non empty) items.
+func (a *Apriori) calculateTransactionIndexesFrom(items []string, from int64) []int64 {
+	var sumIndexes []int64
+	for i, item := range items {
+		indexes := a.itemIndexes(item)
+		indexes = indexes[sort.Search(len(indexes), func(j int) bool { return indexes[j] >= from }):]
+		if len(indexes) == 0 {
+			return nil
+		}
+		if i == 0 {
+			sumIndexes = indexes
+		} else {
+			sumIndexes = a.transactionIntersection(sumIndexes, indexes)
+		}
+		if len(sumIndexes) == 0 {
+			return nil
+		}
+	}
+
+	return sumIndexes
+}
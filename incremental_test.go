@@ -0,0 +1,53 @@
+package apriori
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestApriori_UpdateFrequentItemsets(t *testing.T) {
+	oldTransactions := [][]string{
+		{"beer", "nuts", "cheese"},
+		{"beer", "nuts", "jam"},
+		{"beer", "butter"},
+		{"nuts", "cheese"},
+	}
+	newTransactions := [][]string{
+		{"beer", "nuts", "cheese", "jam"},
+		{"butter"},
+		{"beer", "nuts", "jam", "butter"},
+		{"jam"},
+	}
+	options := NewOptions(0.3, 0, 0, 0)
+
+	format := func(records []SupportRecord) string {
+		var formatted []string
+		for _, record := range records {
+			formatted = append(formatted, formatSupportRecord(record))
+		}
+		return fmt.Sprint(formatted)
+	}
+
+	a := NewApriori(oldTransactions)
+	updated, err := a.UpdateFrequentItemsets(a.FrequentItemsets(options), newTransactions, options)
+	assert(err == nil, "Expected the frequent itemsets to be updated")
+	expected := format(NewApriori(append(oldTransactions, newTransactions...)).FrequentItemsets(options))
+	assert(format(updated) == expected, "Expected the same itemsets as mining from scratch: "+format(updated))
+	assert(a.TransactionCount() == 8, "Expected the new transactions to be added")
+	assert(a.negatedIndexMap == nil && a.ancestorIndexMap == nil, "Expected the mining not to modify the Apriori struct")
+
+	provider := []Option{
+		WithConsequent("jam"),
+		WithMinSupportByLength(map[int]float64{2: 0.5}),
+		WithTargetItemsetCount(3),
+		WithDistinctAttributes(),
+		WithQuantitySupport(MinQuantitySupport),
+		WithOnItemset(func(SupportRecord) {}),
+	}
+	for _, opt := range provider {
+		unsupported := options
+		opt(&unsupported)
+		_, err = a.UpdateFrequentItemsets(nil, nil, unsupported)
+		assert(err != nil, "Expected an error for an unsupported option")
+	}
+}
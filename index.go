@@ -0,0 +1,137 @@
+package apriori
+
+import (
+	"runtime"
+	"sync"
+)
+
+// Minimum number of transactions indexed by each goroutine of AddTransactionsBatch, below it the goroutines cost
+// more than they save.
+const minTransactionsPerWorker = 10000
+
+// Index of a range of transactions built by a goroutine of AddTransactionsBatch, sharded by item hash.
+type transactionRangeIndex struct {
+	shards []map[string][]int64
+	items  []string // Items in the order they were first seen in the range.
+}
+
+// AddTransactionsBatch adds the transactions, building their index with up to workers goroutines, GOMAXPROCS when
+// workers is < 1. The transactions are split in ranges indexed in parallel, each index sharded by item hash, then
+// the index lists of every shard are merged in parallel, so indexing millions of transactions doesn't dominate the
+// mining time. Small batches, and the transactions of a store, are indexed by the calling goroutine. The whole batch
+// is checked by the TransactionPolicy first: when it rejects a transaction, the error is returned and none of the
+// batch is added. The error of a store is returned as well, the transactions before the failed one being added.
+func (a *Apriori) AddTransactionsBatch(transactions [][]string, workers int) error {
+	if workers < 1 {
+		workers = runtime.GOMAXPROCS(0)
+	}
+	if maxWorkers := len(transactions) / minTransactionsPerWorker; workers > maxWorkers {
+		workers = maxWorkers
+	}
+	if a.transactionIndexMap == nil {
+		a.transactionIndexMap = make(map[interface{}][]int64)
+	}
+	if a.itemNormalizer != nil || a.transactionPolicy != nil {
+		admitted, err := a.admitTransactions(transactions)
+		if err != nil {
+			return err
+		}
+		transactions = admitted
+	}
+	if workers <= 1 || a.store != nil {
+		for _, transaction := range transactions {
+			if err := a.indexTransaction(transaction); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	// Index the ranges of transactions.
+	ranges := make([]transactionRangeIndex, workers)
+	rangeSize := (len(transactions) + workers - 1) / workers
+	var wg sync.WaitGroup
+	for r := range ranges {
+		wg.Add(1)
+		go func(r int) {
+			defer wg.Done()
+			index := transactionRangeIndex{shards: make([]map[string][]int64, workers)}
+			for shard := range index.shards {
+				index.shards[shard] = make(map[string][]int64)
+			}
+			from := r * rangeSize
+			to := from + rangeSize
+			if to > len(transactions) {
+				to = len(transactions)
+			}
+			for i := from; i < to; i++ {
+				for _, item := range transactions[i] {
+					shard := index.shards[itemShard(item, workers)]
+					indexes, ok := shard[item]
+					if !ok {
+						index.items = append(index.items, item)
+					}
+					if len(indexes) > 0 && indexes[len(indexes)-1] == a.transactionNo+int64(i) {
+						continue
+					}
+					shard[item] = append(indexes, a.transactionNo+int64(i))
+				}
+			}
+			ranges[r] = index
+		}(r)
+	}
+	wg.Wait()
+
+	// Merge the index lists of every shard, in the order of the ranges so they stay sorted. The existing lists
+	// are only read, the merged ones are new.
+	merged := make([]map[string][]int64, workers)
+	for shard := range merged {
+		wg.Add(1)
+		go func(shard int) {
+			defer wg.Done()
+			lists := make(map[string][]int64)
+			for _, index := range ranges {
+				for item, indexes := range index.shards[shard] {
+					list, ok := lists[item]
+					if !ok {
+						existing := a.transactionIndexMap[item]
+						list = make([]int64, len(existing), len(existing)+len(indexes))
+						copy(list, existing)
+					}
+					lists[item] = append(list, indexes...)
+				}
+			}
+			merged[shard] = lists
+		}(shard)
+	}
+	wg.Wait()
+
+	// The new items are added in the order they were first seen, like addTransaction does.
+	for _, index := range ranges {
+		for _, item := range index.items {
+			if _, ok := a.transactionIndexMap[item]; !ok {
+				a.addItem(item)
+				a.transactionIndexMap[item] = nil
+			}
+		}
+	}
+	for _, lists := range merged {
+		for item, indexes := range lists {
+			a.transactionIndexMap[item] = indexes
+		}
+	}
+	a.transactionNo += int64(len(transactions))
+
+	return nil
+}
+
+// Returns the shard of the item among the given number, from its FNV-1a hash.
+func itemShard(item string, shards int) int {
+	hash := uint32(2166136261)
+	for i := 0; i < len(item); i++ {
+		hash ^= uint32(item[i])
+		hash *= 16777619
+	}
+
+	return int(hash % uint32(shards))
+}
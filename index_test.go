@@ -0,0 +1,55 @@
+package apriori
+
+import (
+	"fmt"
+	"math/rand"
+	"reflect"
+	"testing"
+)
+
+func TestApriori_AddTransactionsBatch(t *testing.T) {
+	random := rand.New(rand.NewSource(5))
+	var transactions [][]string
+	for i := 0; i < 3*minTransactionsPerWorker; i++ {
+		var transaction []string
+		for item := 0; item < 30; item++ {
+			if random.Float64() < 0.2 {
+				transaction = append(transaction, fmt.Sprintf("item%d", random.Intn(40)))
+			}
+		}
+		transactions = append(transactions, transaction)
+	}
+
+	sequential := NewApriori(nil)
+	for _, transaction := range transactions {
+		sequential.addTransaction(transaction)
+	}
+
+	// The second batch extends the index lists of the first one.
+	a := NewApriori(transactions[:minTransactionsPerWorker])
+	a.AddTransactionsBatch(transactions[minTransactionsPerWorker:], 4)
+
+	assert(a.transactionNo == sequential.transactionNo, "Expected the same number of transactions")
+	assert(fmt.Sprint(a.items) == fmt.Sprint(sequential.items), "Expected the items in the order they were first seen")
+	assert(reflect.DeepEqual(a.transactionIndexMap, sequential.transactionIndexMap), "Expected the same index")
+
+	options := NewOptions(0.05, 0.5, 0.0, 2)
+	assert(formatRecords(a.Calculate(options)) == formatRecords(sequential.Calculate(options)), "Expected output not equal to actual output")
+}
+
+func BenchmarkNewApriori(b *testing.B) {
+	random := rand.New(rand.NewSource(1))
+	var transactions [][]string
+	for i := 0; i < 200000; i++ {
+		var transaction []string
+		for item := 0; item < 10; item++ {
+			transaction = append(transaction, fmt.Sprintf("item%d", random.Intn(1000)))
+		}
+		transactions = append(transactions, transaction)
+	}
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		NewApriori(transactions)
+	}
+}
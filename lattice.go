@@ -0,0 +1,112 @@
+package apriori
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+)
+
+// LatticeFormat is the format of the lattice written by ExportLattice
+type LatticeFormat int
+
+const (
+	// LatticeJSON is a JSON object with the nodes and the edges of the Lattice
+	LatticeJSON LatticeFormat = iota
+	// LatticeDOT is a Graphviz graph, e.g. for `dot -Tsvg`
+	LatticeDOT
+)
+
+// Lattice is the lattice of the frequent itemsets, showing how the patterns specialize: every itemset is a node, with
+// an edge from each of its subsets one item shorter. The fields are exported so that it can be encoded, e.g. as JSON.
+type Lattice struct {
+	Nodes []LatticeNode `json:"nodes"`
+	Edges []LatticeEdge `json:"edges"`
+}
+
+// LatticeNode is an itemset of the lattice, identified by its index in the nodes
+type LatticeNode struct {
+	ID           int      `json:"id"`
+	Items        []string `json:"items"`
+	Support      float64  `json:"support"`
+	SupportCount int64    `json:"supportCount"`
+}
+
+// LatticeEdge links an itemset, the parent, to one of its supersets one item longer, the child
+type LatticeEdge struct {
+	Parent int `json:"parent"`
+	Child  int `json:"child"`
+}
+
+// NewLattice builds the lattice of previously mined frequent itemsets. The nodes are sorted by length, then by items,
+// and the edges by parent, then by child. The subsets missing from the itemsets, e.g. shorter than the minimum
+// length, have no node and no edges.
+func NewLattice(itemsets []SupportRecord) Lattice {
+	var a Apriori
+	records := make([]SupportRecord, len(itemsets))
+	for i, itemset := range itemsets {
+		records[i] = itemset
+		records[i].items = a.normalizeItems(itemset.items)
+	}
+	sort.SliceStable(records, func(i, j int) bool {
+		if len(records[i].items) != len(records[j].items) {
+			return len(records[i].items) < len(records[j].items)
+		}
+		return itemsetKey(records[i].items) < itemsetKey(records[j].items)
+	})
+
+	var lattice Lattice
+	ids := make(map[string]int, len(records))
+	for _, record := range records {
+		key := itemsetKey(record.items)
+		if _, ok := ids[key]; ok {
+			continue
+		}
+		ids[key] = len(lattice.Nodes)
+		lattice.Nodes = append(lattice.Nodes, LatticeNode{len(lattice.Nodes), record.items, record.support, record.supportCount})
+	}
+	for _, node := range lattice.Nodes {
+		if len(node.Items) < 2 {
+			continue
+		}
+		for _, subset := range a.generateCandidateCombinations(node.Items, len(node.Items)-1) {
+			if parent, ok := ids[itemsetKey(subset)]; ok {
+				lattice.Edges = append(lattice.Edges, LatticeEdge{parent, node.ID})
+			}
+		}
+	}
+	sort.Slice(lattice.Edges, func(i, j int) bool {
+		if lattice.Edges[i].Parent != lattice.Edges[j].Parent {
+			return lattice.Edges[i].Parent < lattice.Edges[j].Parent
+		}
+		return lattice.Edges[i].Child < lattice.Edges[j].Child
+	})
+
+	return lattice
+}
+
+// ExportLattice writes the lattice of previously mined frequent itemsets for visualization tools, as JSON or as a
+// Graphviz graph drawn top-down from the single items, the nodes being labeled with their supports.
+func ExportLattice(itemsets []SupportRecord, w io.Writer, format LatticeFormat) error {
+	lattice := NewLattice(itemsets)
+	switch format {
+	case LatticeJSON:
+		return json.NewEncoder(w).Encode(lattice)
+	case LatticeDOT:
+		writer := bufio.NewWriter(w)
+		fmt.Fprintln(writer, "digraph lattice {")
+		fmt.Fprintln(writer, "  node [shape=box];")
+		for _, node := range lattice.Nodes {
+			fmt.Fprintf(writer, "  n%d [label=%s];\n", node.ID, dotQuote(fmt.Sprintf("{%s}\n%.4g", dotNode(node.Items), node.Support)))
+		}
+		for _, edge := range lattice.Edges {
+			fmt.Fprintf(writer, "  n%d -> n%d;\n", edge.Parent, edge.Child)
+		}
+		fmt.Fprintln(writer, "}")
+
+		return writer.Flush()
+	default:
+		return fmt.Errorf("unknown lattice format %d", format)
+	}
+}
@@ -0,0 +1,49 @@
+package apriori
+
+import (
+	"bytes"
+	"fmt"
+	"testing"
+)
+
+func TestNewLattice(t *testing.T) {
+	transactions := [][]string{
+		{"beer", "nuts"},
+		{"beer", "nuts"},
+		{"beer"},
+		{"jam"},
+	}
+	lattice := NewLattice(NewApriori(transactions).FrequentItemsets(NewOptions(0.25, 0, 0, 0)))
+
+	assert(fmt.Sprint(lattice.Nodes) == "[{0 [beer] 0.75 3} {1 [jam] 0.25 1} {2 [nuts] 0.5 2} {3 [beer nuts] 0.5 2}]", fmt.Sprint("Unexpected nodes ", lattice.Nodes))
+	assert(fmt.Sprint(lattice.Edges) == "[{0 3} {2 3}]", fmt.Sprint("Unexpected edges ", lattice.Edges))
+}
+
+func TestExportLattice(t *testing.T) {
+	itemsets := []SupportRecord{
+		NewSupportRecord([]string{"nuts", "beer"}, 0.5),
+		NewSupportRecord([]string{"beer"}, 0.75),
+		NewSupportRecord([]string{"nuts"}, 0.5),
+	}
+
+	var buffer bytes.Buffer
+	assert(ExportLattice(itemsets, &buffer, LatticeJSON) == nil, "Expected the lattice to be written as JSON")
+	expected := `{"nodes":[{"id":0,"items":["beer"],"support":0.75,"supportCount":0},{"id":1,"items":["nuts"],"support":0.5,"supportCount":0},{"id":2,"items":["beer","nuts"],"support":0.5,"supportCount":0}],"edges":[{"parent":0,"child":2},{"parent":1,"child":2}]}
+`
+	assert(buffer.String() == expected, "Unexpected JSON lattice: "+buffer.String())
+
+	buffer.Reset()
+	assert(ExportLattice(itemsets, &buffer, LatticeDOT) == nil, "Expected the lattice to be written as DOT")
+	expected = `digraph lattice {
+  node [shape=box];
+  n0 [label="{beer}\n0.75"];
+  n1 [label="{nuts}\n0.5"];
+  n2 [label="{beer, nuts}\n0.5"];
+  n0 -> n2;
+  n1 -> n2;
+}
+`
+	assert(buffer.String() == expected, "Unexpected DOT lattice: "+buffer.String())
+
+	assert(ExportLattice(itemsets, &buffer, LatticeFormat(9)) != nil, "Expected an error for an unknown format")
+}
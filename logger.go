@@ -0,0 +1,14 @@
+package apriori
+
+// Logger receives the debug logs of the mining runs, see Options.Logger. A *slog.Logger is a Logger, the args
+// being alternating keys and values.
+type Logger interface {
+	Debug(msg string, args ...interface{})
+}
+
+// Logs at debug level with the Logger of the options, if any.
+func (options Options) debug(msg string, args ...interface{}) {
+	if options.Logger != nil {
+		options.Logger.Debug(msg, args...)
+	}
+}
@@ -0,0 +1,43 @@
+package apriori
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+)
+
+type recordingLogger struct {
+	mu   sync.Mutex
+	logs []string
+}
+
+func (l *recordingLogger) Debug(msg string, args ...interface{}) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.logs = append(l.logs, strings.TrimSpace(fmt.Sprintln(append([]interface{}{msg}, args...)...)))
+}
+
+func TestApriori_CalculateWithLogger(t *testing.T) {
+	transactions := [][]string{
+		{"beer", "nuts"},
+		{"beer", "nuts"},
+		{"beer", "jam"},
+		{"jam"},
+	}
+	logger := &recordingLogger{}
+	options, _ := NewOptionsWith(WithMinSupport(0.5), WithMinConfidence(0.7), WithLogger(logger))
+	NewApriori(transactions).Calculate(options)
+
+	logs := strings.Join(logger.logs, "\n")
+	for _, expected := range []string{
+		"level started length 1 candidates 3",
+		"level finished length 1 frequent 3",
+		"candidate pruned items [beer jam] reason support support 0.25",
+		"level finished length 2 frequent 1",
+		"rule rejected base [beer] add [nuts] reason confidence",
+	} {
+		assert(strings.Contains(logs, expected), "Expected the log "+expected+" in: "+logs)
+	}
+	assert(!strings.Contains(logs, "base [nuts] add [beer]"), "Expected the kept rules not to be logged: "+logs)
+}
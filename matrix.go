@@ -0,0 +1,37 @@
+package apriori
+
+import "fmt"
+
+// NewAprioriFromMatrix builds an Apriori struct from a one-hot encoded dataset, e.g. an mlxtend style dataframe:
+// a column per item and a row per transaction, true meaning the transaction contains the item. The items that
+// are never true are left out.
+func NewAprioriFromMatrix(columns []string, rows [][]bool) (*Apriori, error) {
+	seen := make(map[string]bool, len(columns))
+	for _, column := range columns {
+		if seen[column] {
+			return nil, fmt.Errorf("column %q is repeated", column)
+		}
+		seen[column] = true
+	}
+	for i, row := range rows {
+		if len(row) != len(columns) {
+			return nil, fmt.Errorf("row %d has %d values for %d columns", i, len(row), len(columns))
+		}
+	}
+
+	a := NewApriori(nil)
+	a.transactionNo = int64(len(rows))
+	for j, column := range columns {
+		for i, row := range rows {
+			if !row[j] {
+				continue
+			}
+			if _, ok := a.transactionIndexMap[column]; !ok {
+				a.addItem(column)
+			}
+			a.transactionIndexMap[column] = append(a.transactionIndexMap[column], int64(i))
+		}
+	}
+
+	return a, nil
+}
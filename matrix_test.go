@@ -0,0 +1,39 @@
+package apriori
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestNewAprioriFromMatrix(t *testing.T) {
+	columns := []string{"beer", "nuts", "jam"}
+	rows := [][]bool{
+		{true, true, false},
+		{true, false, false},
+		{false, false, false},
+		{true, true, false},
+	}
+	a, err := NewAprioriFromMatrix(columns, rows)
+	assert(err == nil, "Expected the matrix to be indexed")
+	assert(a.TransactionCount() == 4, "Expected the empty row to be counted")
+	result := fmt.Sprint(a.Items())
+	assert(result == "[beer nuts]", "Expected the items never bought to be left out: "+result)
+
+	var transactions [][]string
+	for _, row := range rows {
+		var transaction []string
+		for j, value := range row {
+			if value {
+				transaction = append(transaction, columns[j])
+			}
+		}
+		transactions = append(transactions, transaction)
+	}
+	expected := formatRecords(NewApriori(transactions).Calculate(NewOptions(0.1, 0, 0, 0)))
+	assert(formatRecords(a.Calculate(NewOptions(0.1, 0, 0, 0))) == expected, "Expected the same rules as from the baskets")
+
+	_, err = NewAprioriFromMatrix(columns, [][]bool{{true}})
+	assert(err != nil, "Expected an error for a short row")
+	_, err = NewAprioriFromMatrix([]string{"beer", "beer"}, nil)
+	assert(err != nil, "Expected an error for a repeated column")
+}
@@ -0,0 +1,64 @@
+package apriori
+
+import (
+	"math"
+	"sort"
+)
+
+// RuleMetric is a measure of the rules, e.g. OrderedStatistic.GetJMeasure or a custom one, used to rank them
+type RuleMetric func(OrderedStatistic) float64
+
+// GetJMeasure will return the J-measure of the rule, the information in bits it gives about the add, weighted by
+// the support of the base: a high one means a frequent base whose add is much more or less likely than usual. NaN
+// when the supports of the base and add are unknown.
+func (os OrderedStatistic) GetJMeasure() float64 {
+	return os.baseSupport * (informationGain(os.confidence, os.addSupport) + informationGain(1-os.confidence, 1-os.addSupport))
+}
+
+// GetGiniIndex will return the Gini index gain of the rule, how much knowing whether a transaction contains the
+// base reduces the Gini impurity of whether it contains the add, 0 for independent items. NaN when the supports of
+// the base and add are unknown.
+func (os OrderedStatistic) GetGiniIndex() float64 {
+	support := os.confidence * os.baseSupport
+	// The probability of the add in the transactions without the base.
+	addWithoutBase := 0.0
+	if os.baseSupport < 1 {
+		addWithoutBase = (os.addSupport - support) / (1 - os.baseSupport)
+	}
+
+	return os.baseSupport*(square(os.confidence)+square(1-os.confidence)) +
+		(1-os.baseSupport)*(square(addWithoutBase)+square(1-addWithoutBase)) -
+		square(os.addSupport) - square(1-os.addSupport)
+}
+
+// Returns p * log2(p / q), 0 when p is 0.
+func informationGain(p float64, q float64) float64 {
+	if p == 0 {
+		return 0
+	}
+
+	return p * math.Log2(p/q)
+}
+
+func square(x float64) float64 {
+	return x * x
+}
+
+// RankBy returns the rules of the records, leaving out the statistics of the single items, sorted by the metric,
+// the highest first and the NaN ones last, e.g. RankBy(results, OrderedStatistic.GetJMeasure)
+func RankBy(records []RelationRecord, metric RuleMetric) []Rule {
+	var rules []Rule
+	var values []float64
+	for _, record := range records {
+		for _, orderedStatistic := range record.orderedStatistic {
+			if len(orderedStatistic.base) == 0 {
+				continue
+			}
+			rules = append(rules, Rule{orderedStatistic, record.supportRecord.support})
+			values = append(values, metric(orderedStatistic))
+		}
+	}
+	sort.Stable(rulesByValue{rules, values})
+
+	return rules
+}
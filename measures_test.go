@@ -0,0 +1,44 @@
+package apriori
+
+import (
+	"fmt"
+	"math"
+	"testing"
+)
+
+func TestRankBy(t *testing.T) {
+	transactions := [][]string{
+		{"beer", "nuts"},
+		{"beer", "nuts"},
+		{"beer"},
+		{"jam"},
+	}
+	records := NewApriori(transactions).Calculate(NewOptions(0.5, 0, 0, 0))
+
+	format := func(rules []Rule, metric RuleMetric) string {
+		var formatted []string
+		for _, rule := range rules {
+			formatted = append(formatted, fmt.Sprintf("%s %.4f", RuleKey(rule.GetOrderedStatistic()), metric(rule.GetOrderedStatistic())))
+		}
+		return fmt.Sprint(formatted)
+	}
+	ranked := format(RankBy(records, OrderedStatistic.GetJMeasure), OrderedStatistic.GetJMeasure)
+	assert(ranked == `[{"nuts"} => {"beer"} 0.2075 {"beer"} => {"nuts"} 0.0613]`, "Unexpected J-measures: "+ranked)
+	ranked = format(RankBy(records, OrderedStatistic.GetGiniIndex), OrderedStatistic.GetGiniIndex)
+	assert(ranked == `[{"beer"} => {"nuts"} 0.1667 {"nuts"} => {"beer"} 0.1250]`, "Unexpected Gini indexes: "+ranked)
+
+	rule := NewOrderedStatistic([]string{"beer"}, []string{"nuts"}, 0.5, 1)
+	assert(math.IsNaN(rule.GetJMeasure()) && math.IsNaN(rule.GetGiniIndex()), "Expected unknown measures without supports")
+
+	options := NewOptions(0.5, 0, 0, 0)
+	options.RuleFilter = All(JMeasureAtLeast(0.1), GiniIndexAtLeast(0.1))
+	var rules []string
+	for _, record := range NewApriori(transactions).Calculate(options) {
+		for _, orderedStatistic := range record.GetOrderedStatistic() {
+			if len(orderedStatistic.GetBase()) > 0 {
+				rules = append(rules, RuleKey(orderedStatistic))
+			}
+		}
+	}
+	assert(fmt.Sprint(rules) == `[{"nuts"} => {"beer"}]`, "Unexpected rules: "+fmt.Sprint(rules))
+}
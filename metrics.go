@@ -0,0 +1,17 @@
+package apriori
+
+import "time"
+
+// MetricsCollector receives the metrics of the mining runs, e.g. to export them to a monitoring system, see
+// Options.MetricsCollector. The methods are called by the mining goroutines, of several runs at once when the
+// Apriori struct is mined concurrently, so the implementations must be safe for concurrent use.
+type MetricsCollector interface {
+	// TransactionsIndexed is called at the start of a run with the number of mined transactions
+	TransactionsIndexed(count int64)
+	// CandidatesCounted is called at every level of a run with the length of its candidate itemsets and their number
+	CandidatesCounted(length int, count int)
+	// RulesEmitted is called at the end of a run of Calculate with the number of rules it returned or streamed
+	RulesEmitted(count int)
+	// MiningFinished is called with the duration of every completed run
+	MiningFinished(duration time.Duration)
+}
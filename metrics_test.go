@@ -0,0 +1,63 @@
+package apriori
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+)
+
+type recordingCollector struct {
+	mu           sync.Mutex
+	transactions int64
+	candidates   []string
+	rules        int
+	runs         int
+}
+
+func (c *recordingCollector) TransactionsIndexed(count int64) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.transactions = count
+}
+
+func (c *recordingCollector) CandidatesCounted(length int, count int) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.candidates = append(c.candidates, fmt.Sprintf("%d:%d", length, count))
+}
+
+func (c *recordingCollector) RulesEmitted(count int) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.rules += count
+}
+
+func (c *recordingCollector) MiningFinished(duration time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.runs++
+}
+
+func TestApriori_CalculateWithMetricsCollector(t *testing.T) {
+	transactions := [][]string{
+		{"beer", "nuts", "cheese"},
+		{"beer", "nuts"},
+		{"beer", "nuts", "jam"},
+		{"beer", "cheese"},
+	}
+	collector := &recordingCollector{}
+	options, _ := NewOptionsWith(WithMinSupport(0.5), WithMinConfidence(0.5), WithMetricsCollector(collector))
+	records := NewApriori(transactions).Calculate(options)
+
+	rules := 0
+	for _, record := range records {
+		rules += len(record.GetOrderedStatistic())
+	}
+	assert(collector.transactions == 4, "Expected the number of mined transactions")
+	assert(fmt.Sprint(collector.candidates) == "[1:4 2:3]", "Unexpected candidates per level: "+fmt.Sprint(collector.candidates))
+	assert(collector.rules == rules && collector.runs == 1, "Expected the rules of the run to be reported")
+
+	NewApriori(transactions).FrequentItemsets(options)
+	assert(collector.rules == rules && collector.runs == 2, "Expected FrequentItemsets to report no rule")
+}
@@ -0,0 +1,18 @@
+package apriori
+
+import "context"
+
+// Miner is the extension point for the mining backends: code written against it takes the Apriori struct, a
+// StreamMiner or an alternative implementation, e.g. FP-Growth, distributed or approximate, as a drop-in
+// replacement. The mining method is CalculateContext since Calculate keeps its signature without a context.
+type Miner interface {
+	// AddTransaction adds a transaction to the mined ones
+	AddTransaction(transaction []string)
+	// CalculateContext mines the rules of the transactions, see Apriori.CalculateContext
+	CalculateContext(ctx context.Context, options Options) ([]RelationRecord, error)
+}
+
+var (
+	_ Miner = (*Apriori)(nil)
+	_ Miner = (*StreamMiner)(nil)
+)
@@ -0,0 +1,36 @@
+package apriori
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+func TestMiner_CalculateContext(t *testing.T) {
+	transactions := [][]string{
+		{"beer", "nuts", "cheese"},
+		{"beer", "nuts"},
+		{"beer", "nuts", "jam"},
+		{"beer", "cheese"},
+	}
+	options := NewOptions(0.5, 0.5, 0.0, 0)
+	expected := formatRecords(NewApriori(transactions).Calculate(options))
+
+	streamMiner, _ := NewStreamMiner(10)
+	for _, miner := range []Miner{&Apriori{}, streamMiner} {
+		for _, transaction := range transactions {
+			miner.AddTransaction(transaction)
+		}
+		records, err := miner.CalculateContext(context.Background(), options)
+		assert(err == nil, "Expected the mining to succeed")
+		assert(formatRecords(records) == expected, "Expected the same rules as Calculate: "+formatRecords(records))
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err := NewApriori(transactions).CalculateContext(ctx, options)
+	assert(errors.Is(err, context.Canceled), "Expected the mining to stop with the context")
+
+	_, err = NewApriori(transactions).CalculateContext(context.Background(), NewOptions(0, 0.5, 0.0, 0))
+	assert(err != nil, "Expected an error for invalid options")
+}
@@ -0,0 +1,114 @@
+package apriori
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// Columns of the association_rules dataframe of mlxtend.
+var mlxtendColumns = []string{"antecedents", "consequents", "antecedent support", "consequent support", "support", "confidence", "lift", "leverage", "conviction"}
+
+// mlxtendRule is a row of the association_rules dataframe of mlxtend.
+type mlxtendRule struct {
+	Antecedents       []string `json:"antecedents"`
+	Consequents       []string `json:"consequents"`
+	AntecedentSupport float64  `json:"antecedent support"`
+	ConsequentSupport float64  `json:"consequent support"`
+	Support           float64  `json:"support"`
+	Confidence        float64  `json:"confidence"`
+	Lift              float64  `json:"lift"`
+	Leverage          float64  `json:"leverage"`
+	Conviction        *float64 `json:"conviction"` // Infinite for the rules with a confidence of 1, null in JSON.
+}
+
+// Returns the rules of the records as mlxtend rows, leaving out the statistics of the single items.
+func mlxtendRules(records []RelationRecord) []mlxtendRule {
+	var rules []mlxtendRule
+	for _, record := range records {
+		support := record.supportRecord.support
+		for _, orderedStatistic := range record.orderedStatistic {
+			if len(orderedStatistic.base) == 0 {
+				continue
+			}
+			// When unknown, the supports of the antecedent and the consequent follow from the confidence and the lift.
+			antecedentSupport, consequentSupport := orderedStatistic.baseSupport, orderedStatistic.addSupport
+			if math.IsNaN(antecedentSupport) || math.IsNaN(consequentSupport) {
+				antecedentSupport = support / orderedStatistic.confidence
+				consequentSupport = orderedStatistic.confidence / orderedStatistic.lift
+			}
+			conviction := math.Inf(1)
+			if orderedStatistic.confidence < 1 {
+				conviction = (1 - consequentSupport) / (1 - orderedStatistic.confidence)
+			}
+			rules = append(rules, mlxtendRule{
+				Antecedents:       orderedStatistic.base,
+				Consequents:       orderedStatistic.add,
+				AntecedentSupport: antecedentSupport,
+				ConsequentSupport: consequentSupport,
+				Support:           support,
+				Confidence:        orderedStatistic.confidence,
+				Lift:              orderedStatistic.lift,
+				Leverage:          support - antecedentSupport*consequentSupport,
+				Conviction:        &conviction,
+			})
+		}
+	}
+
+	return rules
+}
+
+// WriteMlxtendCSV writes the rules with the columns of the association_rules dataframe of mlxtend: antecedents,
+// consequents, antecedent support, consequent support, support, confidence, lift, leverage and conviction. The
+// items of the antecedents and consequents are separated by ", ", and an infinite conviction is written as inf,
+// as pandas does.
+func WriteMlxtendCSV(w io.Writer, records []RelationRecord) error {
+	writer := csv.NewWriter(w)
+	if err := writer.Write(mlxtendColumns); err != nil {
+		return err
+	}
+	format := func(value float64) string {
+		if math.IsInf(value, 1) {
+			return "inf"
+		}
+		return strconv.FormatFloat(value, 'g', -1, 64)
+	}
+	for _, rule := range mlxtendRules(records) {
+		err := writer.Write([]string{
+			strings.Join(rule.Antecedents, ", "),
+			strings.Join(rule.Consequents, ", "),
+			format(rule.AntecedentSupport),
+			format(rule.ConsequentSupport),
+			format(rule.Support),
+			format(rule.Confidence),
+			format(rule.Lift),
+			format(rule.Leverage),
+			format(*rule.Conviction),
+		})
+		if err != nil {
+			return err
+		}
+	}
+	writer.Flush()
+
+	return writer.Error()
+}
+
+// WriteMlxtendJSON writes the rules as a JSON array of objects keyed by the columns of the association_rules
+// dataframe of mlxtend, like its to_json(orient="records"). An infinite conviction is written as null.
+func WriteMlxtendJSON(w io.Writer, records []RelationRecord) error {
+	rules := mlxtendRules(records)
+	for i := range rules {
+		if math.IsInf(*rules[i].Conviction, 1) {
+			rules[i].Conviction = nil
+		}
+	}
+	if rules == nil {
+		rules = []mlxtendRule{}
+	}
+
+	return json.NewEncoder(w).Encode(rules)
+}
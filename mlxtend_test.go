@@ -0,0 +1,33 @@
+package apriori
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestWriteMlxtend(t *testing.T) {
+	transactions := [][]string{
+		{"beer", "nuts"},
+		{"beer", "nuts"},
+		{"beer"},
+		{"jam"},
+	}
+	records := NewApriori(transactions).Calculate(NewOptions(0.5, 0, 0, 0))
+
+	var buffer bytes.Buffer
+	assert(WriteMlxtendCSV(&buffer, records) == nil, "Expected the CSV to be written")
+	expected := "antecedents,consequents,antecedent support,consequent support,support,confidence,lift,leverage,conviction\n" +
+		"beer,nuts,0.75,0.5,0.5,0.6666666666666666,1.3333333333333333,0.125,1.4999999999999998\n" +
+		"nuts,beer,0.5,0.75,0.5,1,1.3333333333333333,0.125,inf\n"
+	assert(buffer.String() == expected, "Unexpected CSV: "+buffer.String())
+
+	buffer.Reset()
+	assert(WriteMlxtendJSON(&buffer, records) == nil, "Expected the JSON to be written")
+	assert(strings.Contains(buffer.String(), `"antecedents":["nuts"],"consequents":["beer"],"antecedent support":0.5`), "Unexpected JSON: "+buffer.String())
+	assert(strings.Contains(buffer.String(), `"conviction":null`), "Expected an infinite conviction to be null: "+buffer.String())
+
+	buffer.Reset()
+	WriteMlxtendJSON(&buffer, nil)
+	assert(buffer.String() == "[]\n", "Expected an empty array: "+buffer.String())
+}
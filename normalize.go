@@ -0,0 +1,49 @@
+package apriori
+
+import "strings"
+
+// NewAprioriWithNormalizer creates an Apriori struct whose transactions, these ones and the ones added later, have
+// their items normalized before being indexed, so that the variants of an item found in dirty data (case, whitespace,
+// SKUs of a product family) count as the same item. The items normalized to "" are dropped, and so are the
+// duplicates of a transaction. The items given to the mining options and to the lookups must be normalized already.
+func NewAprioriWithNormalizer(transactions [][]string, normalize func(item string) string) *Apriori {
+	a := &Apriori{transactionIndexMap: make(map[interface{}][]int64), itemNormalizer: normalize}
+	a.AddTransactionsBatch(transactions, 0)
+
+	return a
+}
+
+// NormalizeItem trims the whitespace around the item and lowercases it, a normalizer for NewAprioriWithNormalizer.
+// Aliases can be mapped on top of it:
+//
+//	func(item string) string {
+//		item = NormalizeItem(item)
+//		if family, ok := families[item]; ok {
+//			return family
+//		}
+//		return item
+//	}
+func NormalizeItem(item string) string {
+	return strings.ToLower(strings.TrimSpace(item))
+}
+
+// Returns the normalized items of the transaction, without the empty and duplicate ones, the transaction itself when
+// there is no normalizer.
+func (a *Apriori) normalizeTransaction(transaction []string) []string {
+	if a.itemNormalizer == nil {
+		return transaction
+	}
+
+	normalized := make([]string, 0, len(transaction))
+	seen := make(map[string]bool, len(transaction))
+	for _, item := range transaction {
+		item = a.itemNormalizer(item)
+		if item == "" || seen[item] {
+			continue
+		}
+		seen[item] = true
+		normalized = append(normalized, item)
+	}
+
+	return normalized
+}
@@ -0,0 +1,51 @@
+package apriori
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestNewAprioriWithNormalizer(t *testing.T) {
+	transactions := [][]string{
+		{" Beer", "NUTS "},
+		{"beer", "Beer", "nuts"},
+		{"beer", "  "},
+	}
+	apriori := NewAprioriWithNormalizer(transactions, NormalizeItem)
+	apriori.AddTransaction([]string{"BEER"})
+
+	assert(fmt.Sprint(apriori.Items()) == "[beer nuts]", fmt.Sprint("Unexpected items ", apriori.Items()))
+	assert(apriori.ItemFrequency("beer") == 4, fmt.Sprint("Unexpected frequency of beer ", apriori.ItemFrequency("beer")))
+	itemsets := apriori.FrequentItemsets(NewOptions(0.5, 0, 0, 0))
+	assert(formatSupportRecord(itemsets[len(itemsets)-1]) == "{[beer nuts] 0.5 2 0.5}", "Unexpected itemset "+formatSupportRecord(itemsets[len(itemsets)-1]))
+
+	clone := apriori.Clone()
+	clone.AddTransaction([]string{" Nuts"})
+	assert(clone.ItemFrequency("nuts") == 3, "Expected the clone to keep the normalizer")
+}
+
+func TestNewAprioriWithNormalizer_Aliases(t *testing.T) {
+	families := map[string]string{"sku-1": "beer", "sku-2": "beer"}
+	normalize := func(item string) string {
+		item = NormalizeItem(item)
+		if family, ok := families[item]; ok {
+			return family
+		}
+		return item
+	}
+	apriori := NewAprioriWithNormalizer([][]string{{"SKU-1", "sku-2", "nuts"}, {"sku-2"}}, normalize)
+	assert(apriori.ItemFrequency("beer") == 2, "Expected the SKUs to be counted as their family")
+
+	err := apriori.AddTransactionWithQuantities([]Item{{"SKU-1", 2}, {"sku-2", 4}})
+	assert(err == nil, "Expected the quantities to be added")
+	assert(apriori.quantity(2, "beer") == 6, fmt.Sprint("Expected the quantities of the family to be summed ", apriori.quantity(2, "beer")))
+
+	batch := make([][]string, 3*minTransactionsPerWorker)
+	for i := range batch {
+		batch[i] = []string{strings.ToUpper("nuts")}
+	}
+	apriori.AddTransactionsBatch(batch, 4)
+	assert(apriori.ItemFrequency("nuts") == int64(len(batch))+1, "Expected the batches indexed in parallel to be normalized")
+	assert(apriori.ItemFrequency("NUTS") == 0, "Expected no unnormalized items")
+}
@@ -0,0 +1,583 @@
+package apriori
+
+import "errors"
+
+// Options struct contain the options that the apriori algorithm will take into account
+type Options struct {
+	minSupport    float64 // The minimum support of relations (float).
+	minConfidence float64 // The minimum confidence of relations (float).
+	minLift       float64 // The minimum lift of relations (float).
+	maxLength     int     // The maximum length of the relation (integer).
+
+	// Consequent restricts mining to rules that predict exactly these items. Only itemsets containing all of them
+	// are counted and a single rule (itemset minus consequent => consequent) is generated for each of them.
+	Consequent []string
+
+	// MinLength is the minimum length of the itemsets in the output. Shorter itemsets are still counted,
+	// because longer candidates are built from them, but they are not returned.
+	MinLength int
+	// MaxSupport, when > 0, drops the itemsets with a higher support from the output, to mine the rare but confident
+	// patterns, e.g. fraud signatures, that the frequent ones hide. The support is anti-monotone, so the itemsets
+	// above it are still counted, the rare ones being their supersets, and only minSupport prunes the candidates.
+	MaxSupport float64
+
+	// NegatedItemsMinSupport enables negative association rules when > 0. Items with at least this support get
+	// a negated counterpart (NegatedItemPrefix + item) supported by the transactions that do not contain them.
+	// Rare items are left out because their negations would be in almost every transaction.
+	NegatedItemsMinSupport float64
+
+	// PruneRedundantRules drops the rules for which a more general rule (same add, subset of the base) has an
+	// equal or higher confidence, e.g. {a,b} => {c} when {a} => {c} is at least as confident.
+	PruneRedundantRules bool
+	// MinImprovement, when > 0, keeps only the rules whose confidence exceeds the best confidence of any of their
+	// simplifications (the empty base included) by at least this threshold.
+	MinImprovement float64
+
+	// MaxPValue, when > 0, keeps only the rules whose Fisher exact test p-value, corrected with PValueCorrection
+	// over all the rules passing the confidence and lift thresholds, is at most this value.
+	MaxPValue float64
+	// PValueCorrection is the multiple testing correction used by MaxPValue.
+	PValueCorrection PValueCorrection
+
+	// MinAllConfidence drops the itemsets whose all-confidence (h-confidence) is lower, which removes the
+	// cross-support patterns between very frequent and very rare items. The remaining itemsets are hypercliques.
+	MinAllConfidence float64
+
+	// KeepTransactionIDs makes every support record carry the indexes of the transactions that support it, for
+	// drilling down from a rule to the actual orders behind it. MaxTransactionIDs, when > 0, caps their number.
+	KeepTransactionIDs bool
+	MaxTransactionIDs  int
+
+	// IncludeItems, when not empty, is the whitelist of items that are considered by the mining.
+	IncludeItems []string
+	// ExcludeItems are ignored by the mining, for example noise items like "plastic bag".
+	ExcludeItems []string
+
+	// Taxonomy maps the items to their parents (SKU => subcategory => category). The ancestors of the items are
+	// mined together with them, so rules are found at and across levels, except for the itemsets containing both
+	// an item and one of its ancestors.
+	Taxonomy map[string]string
+	// PruneAncestorRedundantRules drops the rules for which an ancestor rule, with a base item replaced by one of
+	// its ancestors, has an equal or higher confidence.
+	PruneAncestorRedundantRules bool
+
+	// ReduceTransactions drops, after each level, the transactions that can't support any candidate of the next
+	// level, so the deeper levels intersect shorter index lists (AprioriTid). It trades the memory of the reduced
+	// indexes for time, which pays off when mining long itemsets.
+	ReduceTransactions bool
+	// Diffsets mines the itemsets depth-first with diffsets (dEclat): an itemset keeps the transactions of its
+	// prefix that don't contain it instead of the ones that do, which shrink as the itemsets grow longer, so deep
+	// mining needs less memory per candidate. The results are the same. It can't be combined with a consequent,
+	// transaction IDs or ReduceTransactions, and weighted transactions are still mined level-wise.
+	Diffsets bool
+	// MinSupportByLength overrides the minimum support of the itemsets of the given lengths, e.g. lower thresholds
+	// for the longer itemsets, which are rarer. The candidates are pruned by the lowest threshold of their length and
+	// the longer ones, so the longer itemsets of a lower threshold are still found, which costs more candidates.
+	// Only Calculate and FrequentItemsets use it.
+	MinSupportByLength map[int]float64
+	// TargetItemsetCount, when > 0, replaces the minimum support with the lowest one producing at most this number
+	// of frequent itemsets, found by a binary search, see Apriori.TuneMinSupport.
+	TargetItemsetCount int
+
+	// MinKulczynski drops the rules with a lower Kulczynski measure and MaxImbalanceRatio, when > 0, the ones with
+	// a higher imbalance ratio. Both are null-invariant, so unlike the lift they aren't misled by skewed supports.
+	MinKulczynski     float64
+	MaxImbalanceRatio float64
+
+	// MinConfidenceLowerBound drops the rules whose 95% Wilson score interval of the confidence has a lower bound
+	// below it, so the rules supported by a handful of transactions don't pass for reliable.
+	MinConfidenceLowerBound float64
+
+	// MaxChiSquarePValue, when > 0, drops the rules whose base and add aren't positively correlated even though they
+	// pass the confidence threshold: the ones with a lift of at most 1, or whose chi-square test p-value is above it,
+	// i.e. whose items may well be independent.
+	MaxChiSquarePValue float64
+
+	// RuleFilter, when not nil, is called with every rule passing the confidence and lift thresholds and keeps only
+	// the ones it returns true for, e.g. the ones whose add is in a given category. The rules are dropped while
+	// mining, before the other pruning options, instead of being kept until the end.
+	RuleFilter func(OrderedStatistic) bool
+
+	// MaxAntecedentLength, when > 0, is the maximum length of the bases of the rules, e.g. 2 for rules like
+	// {a,b} => {c}. The itemsets too long for any rule aren't mined by Calculate.
+	MaxAntecedentLength int
+	// ConsequentLength, when > 0, is the length of the adds of the rules, 1 otherwise. The itemsets of exactly
+	// this length get a rule with an empty base, like the single items do by default.
+	ConsequentLength int
+
+	// OnItemset, when not nil, is called with every frequent itemset as soon as it's found, by Calculate and
+	// FrequentItemsets. FrequentItemsets then passes the itemsets to it instead of returning them, so they can be
+	// streamed, e.g. into a database, without being buffered.
+	OnItemset func(SupportRecord)
+	// OnRule, when not nil, is called by Calculate with every record of rules as soon as it's generated, instead of
+	// returning them. With MaxPValue the rules are only known once all of them are corrected, they are passed at the
+	// end. The callbacks are called by the goroutine calling Calculate or FrequentItemsets, one at a time.
+	OnRule func(RelationRecord)
+	// MetricsCollector, when not nil, receives the metrics of the runs of Calculate and FrequentItemsets, e.g. to
+	// export them with the aprioriprometheus module.
+	MetricsCollector MetricsCollector
+	// Tracer, when not nil, starts the spans of the phases of the runs of Calculate and FrequentItemsets: the run,
+	// every level of candidates, the index reduction of ReduceTransactions, the rule generation and filtering, and
+	// the p-value correction. The spans of the levels overlap the one of the rule generation, both happen at once.
+	Tracer Tracer
+	// Logger, when not nil, logs at debug level the levels of candidates, the pruned candidates and the rules
+	// rejected by the thresholds, e.g. slog.Default(), so long runs can be followed.
+	Logger Logger
+	// CheckpointPath, when not empty, is the file where Calculate and FrequentItemsets save the state of the run
+	// before every level of candidates: the candidates and the itemsets found so far. A run with the same
+	// transactions and options resumes from it, e.g. after a crash or a deployment, sending the itemsets found before
+	// the checkpoint again. The file is removed once all the itemsets are found. It can't be combined with Diffsets.
+	CheckpointPath string
+	// QuantitySupport is the way the quantities of the items, see NewAprioriWithQuantities, count in the supports:
+	// CountSupport ignores them, MinQuantitySupport counts every transaction by the lowest quantity of the items of
+	// the itemset. Only Calculate and FrequentItemsets use it.
+	QuantitySupport QuantitySupport
+	// DistinctAttributes skips the itemsets with two values of the same attribute, e.g. "interest=sports" and
+	// "interest=music" for users with several interests, so that only the rules between different attributes are
+	// mined, see EncodeRecords and ParseAttributeItem. The items without an attribute aren't affected.
+	DistinctAttributes bool
+	// ItemCategories are the categories of the items, e.g. their departments, and RuleTemplates, when not empty,
+	// keep only the rules matching one of the templates, e.g. the bases from some departments and the adds from
+	// another one. The rules are dropped while mining, like the ones of RuleFilter.
+	ItemCategories map[string]string
+	RuleTemplates  []RuleTemplate
+	// CollapseSymmetricRules collapses the rules passing the filters in both directions, {a} => {b} and {b} => {a},
+	// into a single bidirectional rule keeping both confidences, see OrderedStatistic.IsBidirectional. The more
+	// confident direction is kept.
+	CollapseSymmetricRules bool
+
+	checkpoint *checkpoint // The checkpoint of the current run, nil when not enabled.
+}
+
+// Returns the reason why the thresholds are out of their ranges. Calculate has always accepted them, e.g. a minimum
+// support > 1 finding nothing, so they are only rejected by NewOptionsWith and Validate.
+func (options Options) checkRanges() error {
+	if options.minSupport > 1 {
+		return errors.New("minimum support must be <= 1")
+	}
+	if options.minConfidence < 0 || options.minConfidence > 1 {
+		return errors.New("minimum confidence must be between 0 and 1")
+	}
+	if options.minLift < 0 {
+		return errors.New("minimum lift must be >= 0")
+	}
+	if options.maxLength < 0 {
+		return errors.New("maximum length must be >= 0")
+	}
+
+	return nil
+}
+
+func (options Options) check() error {
+	// Check Options
+	if options.minSupport <= 0 {
+		return errors.New("minimum support must be > 0")
+	}
+	if options.NegatedItemsMinSupport < 0 || options.NegatedItemsMinSupport > 1 {
+		return errors.New("negated items minimum support must be between 0 and 1")
+	}
+	if options.MinImprovement < 0 {
+		return errors.New("minimum improvement must be >= 0")
+	}
+	if options.MaxPValue < 0 || options.MaxPValue > 1 {
+		return errors.New("maximum p-value must be between 0 and 1")
+	}
+	if options.MinAllConfidence < 0 || options.MinAllConfidence > 1 {
+		return errors.New("minimum all-confidence must be between 0 and 1")
+	}
+	if options.MaxTransactionIDs < 0 {
+		return errors.New("maximum transaction IDs must be >= 0")
+	}
+	for length, minSupport := range options.MinSupportByLength {
+		if length < 1 {
+			return errors.New("lengths of the minimum supports must be >= 1")
+		}
+		if minSupport <= 0 || minSupport > 1 {
+			return errors.New("minimum support by length must be > 0 and <= 1")
+		}
+	}
+	if options.TargetItemsetCount < 0 {
+		return errors.New("target itemset count must be >= 0")
+	}
+	if options.MinLength < 0 {
+		return errors.New("minimum length must be >= 0")
+	}
+	if options.maxLength > 0 && options.MinLength > options.maxLength {
+		return errors.New("minimum length must be <= maximum length")
+	}
+	if options.MaxSupport < 0 {
+		return errors.New("maximum support must be >= 0")
+	}
+	if options.MaxSupport > 0 && options.MaxSupport < options.minSupport {
+		return errors.New("maximum support must be >= minimum support")
+	}
+	if options.MinKulczynski < 0 || options.MinKulczynski > 1 {
+		return errors.New("minimum Kulczynski measure must be between 0 and 1")
+	}
+	if options.MaxImbalanceRatio < 0 || options.MaxImbalanceRatio > 1 {
+		return errors.New("maximum imbalance ratio must be between 0 and 1")
+	}
+	if options.MinConfidenceLowerBound < 0 || options.MinConfidenceLowerBound > 1 {
+		return errors.New("minimum confidence lower bound must be between 0 and 1")
+	}
+	if options.MaxChiSquarePValue < 0 || options.MaxChiSquarePValue > 1 {
+		return errors.New("maximum chi-square p-value must be between 0 and 1")
+	}
+	if options.MaxAntecedentLength < 0 {
+		return errors.New("maximum antecedent length must be >= 0")
+	}
+	if options.ConsequentLength < 0 {
+		return errors.New("consequent length must be >= 0")
+	}
+	if options.ConsequentLength > 0 && len(options.Consequent) > 0 {
+		return errors.New("consequent length can't be combined with a consequent")
+	}
+	if options.Diffsets && (len(options.Consequent) > 0 || options.KeepTransactionIDs || options.ReduceTransactions) {
+		return errors.New("diffsets can't be combined with a consequent, transaction IDs or transaction reduction")
+	}
+	if options.QuantitySupport != CountSupport && options.QuantitySupport != MinQuantitySupport {
+		return errors.New("unknown quantity support")
+	}
+	if len(options.RuleTemplates) > 0 && len(options.ItemCategories) == 0 {
+		return errors.New("rule templates need the categories of the items")
+	}
+	if options.Diffsets && options.CheckpointPath != "" {
+		return errors.New("diffsets can't be combined with checkpoints")
+	}
+	if err := checkTaxonomy(options.Taxonomy); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// Returns the filter of the rules passing the confidence and lift thresholds, nil when every one is kept.
+func (options Options) ruleFilter() func(OrderedStatistic) bool {
+	if options.MinKulczynski == 0 && options.MaxImbalanceRatio == 0 && options.MinConfidenceLowerBound == 0 &&
+		options.MaxChiSquarePValue == 0 && len(options.RuleTemplates) == 0 {
+		return options.RuleFilter
+	}
+
+	return func(orderedStatistic OrderedStatistic) bool {
+		if orderedStatistic.GetKulczynski() < options.MinKulczynski {
+			return false
+		}
+		if options.MaxImbalanceRatio > 0 && orderedStatistic.GetImbalanceRatio() > options.MaxImbalanceRatio {
+			return false
+		}
+		if options.MinConfidenceLowerBound > 0 {
+			// The rules with unknown counts, whose bounds are NaN, are dropped too.
+			if lowerBound, _ := orderedStatistic.GetConfidenceInterval(); !(lowerBound >= options.MinConfidenceLowerBound) {
+				return false
+			}
+		}
+		// The statistics of the single items, with an empty base, aren't correlations. The rules with unknown counts,
+		// whose p-values are NaN, are dropped.
+		if options.MaxChiSquarePValue > 0 && len(orderedStatistic.base) > 0 {
+			if !(orderedStatistic.lift > 1) || !(orderedStatistic.chiSquarePValue <= options.MaxChiSquarePValue) {
+				return false
+			}
+		}
+		if !options.matchesRuleTemplates(orderedStatistic) {
+			return false
+		}
+		return options.RuleFilter == nil || options.RuleFilter(orderedStatistic)
+	}
+}
+
+// Returns the minimum support of the itemsets of the length.
+func (options Options) minSupportFor(length int) float64 {
+	if minSupport, ok := options.MinSupportByLength[length]; ok {
+		return minSupport
+	}
+
+	return options.minSupport
+}
+
+// Returns the lowest minimum support of the itemsets of the length and longer, below which an itemset of the length
+// can't be a subset of a frequent itemset.
+func (options Options) minSupportFrom(length int) float64 {
+	minSupport := options.minSupportFor(length)
+	for longer, longerMinSupport := range options.MinSupportByLength {
+		if longer > length && (options.maxLength == 0 || longer <= options.maxLength) && longerMinSupport < minSupport {
+			minSupport = longerMinSupport
+		}
+	}
+	// Without a maximum length there are always longer lengths left to the default minimum support.
+	defaultApplies := options.maxLength == 0
+	for longer := length + 1; longer <= options.maxLength && !defaultApplies; longer++ {
+		_, ok := options.MinSupportByLength[longer]
+		defaultApplies = !ok
+	}
+	if defaultApplies && options.minSupport < minSupport {
+		minSupport = options.minSupport
+	}
+
+	return minSupport
+}
+
+// NewOptions is a quick way to create an Options struct. The thresholds aren't validated, out of range ones are
+// accepted as they always were (e.g. a minimum support > 1 finds nothing); use NewOptionsWith or Validate to reject
+// them.
+func NewOptions(minSupport float64, minConfidence float64, minLift float64, maxLength int) Options {
+	return Options{minSupport: minSupport, minConfidence: minConfidence, minLift: minLift, maxLength: maxLength}
+}
+
+// Option configures an Options struct created with NewOptionsWith
+type Option func(*Options)
+
+// Default thresholds used by NewOptionsWith
+const (
+	DefaultMinSupport    = 0.1
+	DefaultMinConfidence = 0.0
+	DefaultMinLift       = 0.0
+	DefaultMaxLength     = 0
+)
+
+// NewOptionsWith creates an Options struct starting from the default thresholds and applying the given options,
+// e.g. NewOptionsWith(WithMinSupport(0.01), WithMaxLength(3)). The result is validated, thresholds out of their
+// ranges included.
+func NewOptionsWith(opts ...Option) (Options, error) {
+	options := NewOptions(DefaultMinSupport, DefaultMinConfidence, DefaultMinLift, DefaultMaxLength)
+	for _, opt := range opts {
+		opt(&options)
+	}
+	if err := options.Validate(); err != nil {
+		return Options{}, err
+	}
+
+	return options, nil
+}
+
+// WithMinSupport sets the minimum support of relations
+func WithMinSupport(minSupport float64) Option {
+	return func(options *Options) { options.minSupport = minSupport }
+}
+
+// WithMinConfidence sets the minimum confidence of relations
+func WithMinConfidence(minConfidence float64) Option {
+	return func(options *Options) { options.minConfidence = minConfidence }
+}
+
+// WithMinLift sets the minimum lift of relations
+func WithMinLift(minLift float64) Option {
+	return func(options *Options) { options.minLift = minLift }
+}
+
+// WithMaxLength sets the maximum length of the relation, 0 means no maximum length
+func WithMaxLength(maxLength int) Option {
+	return func(options *Options) { options.maxLength = maxLength }
+}
+
+// WithMinLength sets the minimum length of the returned itemsets
+func WithMinLength(minLength int) Option {
+	return func(options *Options) { options.MinLength = minLength }
+}
+
+// WithConsequent restricts mining to rules that predict exactly these items
+func WithConsequent(items ...string) Option {
+	return func(options *Options) { options.Consequent = items }
+}
+
+// WithNegatedItems enables negated items for the items with at least the given support
+func WithNegatedItems(minSupport float64) Option {
+	return func(options *Options) { options.NegatedItemsMinSupport = minSupport }
+}
+
+// WithPruneRedundantRules drops the rules for which a more general rule is at least as confident
+func WithPruneRedundantRules() Option {
+	return func(options *Options) { options.PruneRedundantRules = true }
+}
+
+// WithMinImprovement keeps only the rules improving on all their simplifications by at least minImprovement
+func WithMinImprovement(minImprovement float64) Option {
+	return func(options *Options) { options.MinImprovement = minImprovement }
+}
+
+// WithMaxPValue keeps only the rules whose Fisher exact test p-value passes maxPValue after the correction
+func WithMaxPValue(maxPValue float64, correction PValueCorrection) Option {
+	return func(options *Options) {
+		options.MaxPValue = maxPValue
+		options.PValueCorrection = correction
+	}
+}
+
+// WithMinAllConfidence drops the itemsets with a lower all-confidence
+func WithMinAllConfidence(minAllConfidence float64) Option {
+	return func(options *Options) { options.MinAllConfidence = minAllConfidence }
+}
+
+// WithTransactionIDs keeps up to max (0 for all) supporting transaction indexes in every support record
+func WithTransactionIDs(max int) Option {
+	return func(options *Options) {
+		options.KeepTransactionIDs = true
+		options.MaxTransactionIDs = max
+	}
+}
+
+// WithIncludeItems restricts mining to these items
+func WithIncludeItems(items ...string) Option {
+	return func(options *Options) { options.IncludeItems = items }
+}
+
+// WithExcludeItems ignores these items during mining
+func WithExcludeItems(items ...string) Option {
+	return func(options *Options) { options.ExcludeItems = items }
+}
+
+// WithTaxonomy mines the items together with their ancestors in the taxonomy (item => parent)
+func WithTaxonomy(taxonomy map[string]string) Option {
+	return func(options *Options) { options.Taxonomy = taxonomy }
+}
+
+// WithPruneAncestorRedundantRules drops the rules for which an ancestor rule is at least as confident
+func WithPruneAncestorRedundantRules() Option {
+	return func(options *Options) { options.PruneAncestorRedundantRules = true }
+}
+
+// WithReduceTransactions drops the transactions that can't support the next level's candidates while mining
+func WithReduceTransactions() Option {
+	return func(options *Options) { options.ReduceTransactions = true }
+}
+
+// WithDiffsets mines the itemsets depth-first with diffsets (dEclat)
+func WithDiffsets() Option {
+	return func(options *Options) { options.Diffsets = true }
+}
+
+// WithMinSupportByLength overrides the minimum support of the itemsets of the given lengths
+func WithMinSupportByLength(minSupports map[int]float64) Option {
+	return func(options *Options) { options.MinSupportByLength = minSupports }
+}
+
+// WithTargetItemsetCount tunes the minimum support to produce at most about count frequent itemsets
+func WithTargetItemsetCount(count int) Option {
+	return func(options *Options) { options.TargetItemsetCount = count }
+}
+
+// WithMinKulczynski drops the rules with a lower Kulczynski measure
+func WithMinKulczynski(minKulczynski float64) Option {
+	return func(options *Options) { options.MinKulczynski = minKulczynski }
+}
+
+// WithMaxImbalanceRatio drops the rules with a higher imbalance ratio
+func WithMaxImbalanceRatio(maxImbalanceRatio float64) Option {
+	return func(options *Options) { options.MaxImbalanceRatio = maxImbalanceRatio }
+}
+
+// WithMaxChiSquarePValue drops the rules whose items aren't significantly positively correlated
+func WithMaxChiSquarePValue(maxChiSquarePValue float64) Option {
+	return func(options *Options) { options.MaxChiSquarePValue = maxChiSquarePValue }
+}
+
+// WithMinConfidenceLowerBound drops the rules whose confidence interval has a lower bound below it
+func WithMinConfidenceLowerBound(minConfidenceLowerBound float64) Option {
+	return func(options *Options) { options.MinConfidenceLowerBound = minConfidenceLowerBound }
+}
+
+// WithRuleFilter keeps only the rules the filter returns true for
+func WithRuleFilter(filter func(OrderedStatistic) bool) Option {
+	return func(options *Options) { options.RuleFilter = filter }
+}
+
+// WithRuleShape limits the bases of the rules to maxAntecedentLength items (0 for any) and makes their adds
+// consequentLength items long
+func WithRuleShape(maxAntecedentLength int, consequentLength int) Option {
+	return func(options *Options) {
+		options.MaxAntecedentLength = maxAntecedentLength
+		options.ConsequentLength = consequentLength
+	}
+}
+
+// WithOnItemset streams the frequent itemsets to the callback as they are found
+func WithOnItemset(onItemset func(SupportRecord)) Option {
+	return func(options *Options) { options.OnItemset = onItemset }
+}
+
+// WithOnRule streams the records of rules to the callback as they are generated, instead of returning them
+func WithOnRule(onRule func(RelationRecord)) Option {
+	return func(options *Options) { options.OnRule = onRule }
+}
+
+// WithMetricsCollector reports the metrics of the mining runs to the collector
+func WithMetricsCollector(collector MetricsCollector) Option {
+	return func(options *Options) { options.MetricsCollector = collector }
+}
+
+// WithTracer traces the phases of the mining runs with the tracer
+func WithTracer(tracer Tracer) Option {
+	return func(options *Options) { options.Tracer = tracer }
+}
+
+// WithLogger logs the progress of the mining runs at debug level
+func WithLogger(logger Logger) Option {
+	return func(options *Options) { options.Logger = logger }
+}
+
+// WithCheckpoint saves the state of the mining runs to the file after every level, and resumes from it
+func WithCheckpoint(path string) Option {
+	return func(options *Options) { options.CheckpointPath = path }
+}
+
+// WithQuantitySupport sets the way the quantities of the items count in the supports
+func WithQuantitySupport(quantitySupport QuantitySupport) Option {
+	return func(options *Options) { options.QuantitySupport = quantitySupport }
+}
+
+// WithDistinctAttributes skips the itemsets with two values of the same attribute
+func WithDistinctAttributes() Option {
+	return func(options *Options) { options.DistinctAttributes = true }
+}
+
+// WithRuleTemplates keeps only the rules whose items have the categories of one of the templates
+func WithRuleTemplates(itemCategories map[string]string, templates ...RuleTemplate) Option {
+	return func(options *Options) {
+		options.ItemCategories = itemCategories
+		options.RuleTemplates = templates
+	}
+}
+
+// WithCollapseSymmetricRules collapses the rules passing the filters in both directions into bidirectional rules
+func WithCollapseSymmetricRules() Option {
+	return func(options *Options) { options.CollapseSymmetricRules = true }
+}
+
+// WithMaxSupport drops the itemsets with a higher support, to mine the rare itemsets
+func WithMaxSupport(maxSupport float64) Option {
+	return func(options *Options) { options.MaxSupport = maxSupport }
+}
+
+// Returns whether the support is above the maximum support of the output.
+func (options Options) aboveMaxSupport(support float64) bool {
+	return options.MaxSupport > 0 && support > options.MaxSupport
+}
+
+// GetMinSupport will return the minimum support of relations
+func (options Options) GetMinSupport() float64 {
+	return options.minSupport
+}
+
+// GetMinConfidence will return the minimum confidence of relations
+func (options Options) GetMinConfidence() float64 {
+	return options.minConfidence
+}
+
+// GetMinLift will return the minimum lift of relations
+func (options Options) GetMinLift() float64 {
+	return options.minLift
+}
+
+// GetMaxLength will return the maximum length of the relation
+func (options Options) GetMaxLength() int {
+	return options.maxLength
+}
+
+// Validate returns the reason why the options can't be used for mining, nil if they are valid. It's stricter than
+// Calculate: the thresholds out of their ranges, e.g. a minimum confidence > 1, are rejected too.
+func (options Options) Validate() error {
+	if err := options.checkRanges(); err != nil {
+		return err
+	}
+
+	return options.check()
+}
@@ -0,0 +1,69 @@
+package apriori
+
+import "testing"
+
+func TestNewOptionsWith(t *testing.T) {
+	options, err := NewOptionsWith()
+	assert(err == nil, "Expected default options to be valid")
+	assert(options.GetMinSupport() == DefaultMinSupport && options.GetMinConfidence() == DefaultMinConfidence &&
+		options.GetMinLift() == DefaultMinLift && options.GetMaxLength() == DefaultMaxLength, "Expected default thresholds")
+
+	options, err = NewOptionsWith(
+		WithMinSupport(0.01),
+		WithMinConfidence(0.5),
+		WithMinLift(1.2),
+		WithMaxLength(3),
+		WithMinLength(2),
+		WithConsequent("churn"),
+		WithMaxPValue(0.05, BonferroniCorrection))
+	assert(err == nil, "Expected options to be valid")
+	assert(options.GetMinSupport() == 0.01 && options.GetMinConfidence() == 0.5 && options.GetMinLift() == 1.2 &&
+		options.GetMaxLength() == 3 && options.MinLength == 2 && options.Consequent[0] == "churn" &&
+		options.MaxPValue == 0.05 && options.PValueCorrection == BonferroniCorrection, "Expected options to be applied")
+
+	provider := [][]Option{
+		{WithMinSupport(0)},
+		{WithMinSupport(1.5)},
+		{WithMinConfidence(-0.1)},
+		{WithMinLift(-1)},
+		{WithMaxLength(-1)},
+		{WithMaxLength(2), WithMinLength(3)},
+		{WithMaxPValue(2, NoCorrection)},
+		{WithMaxSupport(-0.1)},
+		{WithMinSupport(0.5), WithMaxSupport(0.2)},
+	}
+	for _, opts := range provider {
+		_, err := NewOptionsWith(opts...)
+		assert(err != nil, "Expected invalid options to be rejected")
+	}
+}
+
+func TestNewOptions_outOfRange(t *testing.T) {
+	a := NewApriori([][]string{{"beer", "nuts"}, {"beer", "cheese"}})
+	provider := []Options{
+		NewOptions(1.5, 0, 0, 0),
+		NewOptions(0.5, 1.5, 0, 0),
+		NewOptions(0.5, -0.1, 0, 0),
+		NewOptions(0.5, 0, -1, 0),
+		NewOptions(0.5, 0, 0, -1),
+	}
+	for _, options := range provider {
+		assert(options.Validate() != nil, "Expected thresholds out of range to be invalid")
+		// The legacy path kept accepting them.
+		_ = a.Calculate(options)
+	}
+}
+
+func TestOptions_minSupportFrom(t *testing.T) {
+	options := NewOptions(0.3, 0, 0, 0)
+	options.MinSupportByLength = map[int]float64{1: 0.4, 2: 0.5, 3: 0.1}
+	assert(options.minSupportFor(2) == 0.5 && options.minSupportFor(4) == 0.3, "Unexpected minimum supports by length")
+	assert(options.minSupportFrom(1) == 0.1 && options.minSupportFrom(3) == 0.1 && options.minSupportFrom(4) == 0.3, "Unexpected pruning minimum supports")
+
+	options = NewOptions(0.05, 0, 0, 2)
+	options.MinSupportByLength = map[int]float64{1: 0.4, 2: 0.2}
+	assert(options.minSupportFrom(1) == 0.2 && options.minSupportFrom(2) == 0.2, "Expected the default to apply to no length")
+
+	_, err := NewOptionsWith(WithMinSupportByLength(map[int]float64{0: 0.1}))
+	assert(err != nil, "Expected an error for a length of 0")
+}
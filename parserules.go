@@ -0,0 +1,187 @@
+package apriori
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// RuleFormat is the format of the rules read by ParseRules
+type RuleFormat int
+
+const (
+	// RulesCSV is a CSV file with a header, like the one written by WriteMlxtendCSV
+	RulesCSV RuleFormat = iota
+	// RulesJSON is a JSON array of objects, like the one written by WriteMlxtendJSON
+	RulesJSON
+	// RulesArules is a CSV file written by R's arules package, or by WriteArulesCSV
+	RulesArules
+)
+
+// ParseRules reads rules authored elsewhere, or exported by an older run, so that they can be scored and
+// recommended from without mining again. The rules have the columns of WriteMlxtendCSV and WriteMlxtendJSON:
+// antecedents, consequents and confidence are required, support, lift, antecedent support and consequent support
+// are optional, the other columns are ignored. The items of the antecedents and consequents of a CSV are separated
+// by commas, pandas' frozenset({'a', 'b'}) being accepted as well. The unknown statistics are NaN, unless they
+// follow from the known ones. The rules written by arules have its columns instead, see WriteArulesCSV.
+func ParseRules(r io.Reader, format RuleFormat) (RuleSet, error) {
+	var rules []mlxtendRule
+	var err error
+	switch format {
+	case RulesCSV:
+		rules, err = parseCSVRules(r)
+	case RulesJSON:
+		rules, err = parseJSONRules(r)
+	case RulesArules:
+		rules, err = parseArulesRules(r)
+	default:
+		err = fmt.Errorf("unknown rule format %d", format)
+	}
+	if err != nil {
+		return RuleSet{}, err
+	}
+
+	var a Apriori
+	records := make([]RelationRecord, 0, len(rules))
+	for i, rule := range rules {
+		if len(rule.Antecedents) == 0 || len(rule.Consequents) == 0 {
+			return RuleSet{}, fmt.Errorf("rule %d: the antecedents and consequents must not be empty", i+1)
+		}
+		if math.IsNaN(rule.Confidence) || rule.Confidence < 0 || rule.Confidence > 1 {
+			return RuleSet{}, fmt.Errorf("rule %d: the confidence must be between 0 and 1", i+1)
+		}
+		if math.IsNaN(rule.Support) {
+			rule.Support = rule.Confidence * rule.AntecedentSupport
+		}
+		if math.IsNaN(rule.Lift) {
+			rule.Lift = rule.Confidence / rule.ConsequentSupport
+		}
+
+		orderedStatistic := NewOrderedStatistic(rule.Antecedents, rule.Consequents, rule.Confidence, rule.Lift)
+		orderedStatistic.baseSupport = rule.AntecedentSupport
+		orderedStatistic.addSupport = rule.ConsequentSupport
+		items := a.normalizeItems(append(append([]string{}, rule.Antecedents...), rule.Consequents...))
+		records = append(records, RelationRecord{
+			supportRecord:    NewSupportRecord(items, rule.Support),
+			orderedStatistic: []OrderedStatistic{orderedStatistic},
+		})
+	}
+
+	return NewRuleSet(records), nil
+}
+
+// Reads the rules of a CSV file with a header.
+func parseCSVRules(r io.Reader) ([]mlxtendRule, error) {
+	reader := csv.NewReader(r)
+	header, err := reader.Read()
+	if err == io.EOF {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	columns := make(map[string]int, len(header))
+	for i, column := range header {
+		columns[strings.TrimSpace(column)] = i
+	}
+	for _, column := range []string{"antecedents", "consequents", "confidence"} {
+		if _, ok := columns[column]; !ok {
+			return nil, fmt.Errorf("missing column %q", column)
+		}
+	}
+
+	var rules []mlxtendRule
+	for {
+		row, err := reader.Read()
+		if err == io.EOF {
+			return rules, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		rule := mlxtendRule{
+			Antecedents: parseRuleItems(row[columns["antecedents"]]),
+			Consequents: parseRuleItems(row[columns["consequents"]]),
+		}
+		statistics := []struct {
+			column string
+			value  *float64
+		}{
+			{"confidence", &rule.Confidence},
+			{"support", &rule.Support},
+			{"lift", &rule.Lift},
+			{"antecedent support", &rule.AntecedentSupport},
+			{"consequent support", &rule.ConsequentSupport},
+		}
+		for _, statistic := range statistics {
+			*statistic.value = math.NaN()
+			i, ok := columns[statistic.column]
+			if !ok || strings.TrimSpace(row[i]) == "" {
+				continue
+			}
+			if *statistic.value, err = strconv.ParseFloat(strings.TrimSpace(row[i]), 64); err != nil {
+				return nil, fmt.Errorf("rule %d: invalid %s: %w", len(rules)+1, statistic.column, err)
+			}
+		}
+		rules = append(rules, rule)
+	}
+}
+
+// Returns the items of an antecedents or consequents cell, e.g. "beer, nuts" or "frozenset({'beer', 'nuts'})".
+func parseRuleItems(cell string) []string {
+	cell = strings.TrimSpace(cell)
+	if strings.HasPrefix(cell, "frozenset({") && strings.HasSuffix(cell, "})") {
+		cell = strings.TrimSuffix(strings.TrimPrefix(cell, "frozenset({"), "})")
+		var items []string
+		for _, item := range parseTransaction(cell) {
+			items = append(items, strings.Trim(item, `'"`))
+		}
+		return items
+	}
+
+	return parseTransaction(cell)
+}
+
+// Reads the rules of a JSON array of objects.
+func parseJSONRules(r io.Reader) ([]mlxtendRule, error) {
+	var objects []struct {
+		Antecedents       []string `json:"antecedents"`
+		Consequents       []string `json:"consequents"`
+		AntecedentSupport *float64 `json:"antecedent support"`
+		ConsequentSupport *float64 `json:"consequent support"`
+		Support           *float64 `json:"support"`
+		Confidence        *float64 `json:"confidence"`
+		Lift              *float64 `json:"lift"`
+	}
+	if err := json.NewDecoder(r).Decode(&objects); err != nil {
+		return nil, err
+	}
+
+	value := func(v *float64) float64 {
+		if v == nil {
+			return math.NaN()
+		}
+		return *v
+	}
+	rules := make([]mlxtendRule, len(objects))
+	for i, object := range objects {
+		if object.Confidence == nil {
+			return nil, fmt.Errorf("rule %d: missing confidence", i+1)
+		}
+		rules[i] = mlxtendRule{
+			Antecedents:       object.Antecedents,
+			Consequents:       object.Consequents,
+			AntecedentSupport: value(object.AntecedentSupport),
+			ConsequentSupport: value(object.ConsequentSupport),
+			Support:           value(object.Support),
+			Confidence:        *object.Confidence,
+			Lift:              value(object.Lift),
+		}
+	}
+
+	return rules, nil
+}
@@ -0,0 +1,58 @@
+package apriori
+
+import (
+	"bytes"
+	"math"
+	"strings"
+	"testing"
+)
+
+func TestParseRules(t *testing.T) {
+	transactions := [][]string{
+		{"beer", "nuts"},
+		{"beer", "nuts"},
+		{"beer"},
+		{"jam"},
+	}
+	records := NewApriori(transactions).Calculate(NewOptions(0.5, 0, 0, 0))
+
+	// The exported rules round trip.
+	var buffer bytes.Buffer
+	assert(WriteMlxtendCSV(&buffer, records) == nil, "Expected the CSV to be written")
+	rules, err := ParseRules(&buffer, RulesCSV)
+	assert(err == nil, "Expected the CSV to be parsed")
+	assert(rules.Len() == 2, "Expected 2 rules")
+	assert(rules.Intersect(NewRuleSet(records)).Len() == 2, "Expected the mined rules")
+	assert(len(rules.GetRecords()) == 1, "Expected the rules of {beer, nuts} to be grouped")
+	rule := rules.GetRecords()[0].GetOrderedStatistic()[0]
+	assert(formatSupportRecord(rules.GetRecords()[0].GetSupportRecord()) == "{[beer nuts] 0.5 0 NaN}", "Unexpected itemset")
+	assert(rule.String() == "{beer} => {nuts} conf=0.667 lift=1.33", "Unexpected rule: "+rule.String())
+	assert(rule.GetBaseSupport() == 0.75, "Expected the support of the base to be read")
+
+	buffer.Reset()
+	assert(WriteMlxtendJSON(&buffer, records) == nil, "Expected the JSON to be written")
+	rules, err = ParseRules(&buffer, RulesJSON)
+	assert(err == nil, "Expected the JSON to be parsed")
+	assert(rules.Intersect(NewRuleSet(records)).Len() == 2, "Expected the mined rules")
+
+	// Hand written rules, the missing statistics following from the known ones.
+	csv := "antecedents,consequents,confidence,consequent support\n" +
+		"\"frozenset({'bread', 'butter'})\",frozenset({'jam'}),0.5,0.25\n" +
+		"tea,milk,0.8,\n"
+	rules, err = ParseRules(strings.NewReader(csv), RulesCSV)
+	assert(err == nil, "Expected the CSV to be parsed")
+	assert(rules.Len() == 2, "Expected 2 rules")
+	rule = rules.GetRecords()[0].GetOrderedStatistic()[0]
+	assert(rule.String() == "{bread, butter} => {jam} conf=0.5 lift=2", "Unexpected rule: "+rule.String())
+	assert(math.IsNaN(rules.GetRecords()[0].GetSupportRecord().GetSupport()), "Expected an unknown support")
+	assert(math.IsNaN(rules.GetRecords()[1].GetOrderedStatistic()[0].GetLift()), "Expected an unknown lift")
+
+	_, err = ParseRules(strings.NewReader("antecedents,consequents\nbeer,nuts\n"), RulesCSV)
+	assert(err != nil && strings.Contains(err.Error(), "confidence"), "Expected a missing column error")
+	_, err = ParseRules(strings.NewReader("antecedents,consequents,confidence\nbeer,nuts,high\n"), RulesCSV)
+	assert(err != nil, "Expected an invalid confidence error")
+	_, err = ParseRules(strings.NewReader(`[{"antecedents":[],"consequents":["nuts"],"confidence":1}]`), RulesJSON)
+	assert(err != nil, "Expected an empty antecedents error")
+	_, err = ParseRules(strings.NewReader(`[{"antecedents":["beer"],"consequents":["nuts"]}]`), RulesJSON)
+	assert(err != nil, "Expected a missing confidence error")
+}
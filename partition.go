@@ -0,0 +1,166 @@
+package apriori
+
+import (
+	"bufio"
+	"errors"
+	"io"
+	"sort"
+	"strings"
+)
+
+// Separator of the items of a transaction line read by PartitionedFrequentItemsets.
+const transactionItemSeparator = ","
+
+// PartitionedFrequentItemsets finds the frequent itemsets of a dataset that doesn't fit in memory with the SON
+// algorithm: the transactions are read in chunks of chunkSize, the itemsets frequent in any chunk become the
+// candidates, and their global supports are counted in a second pass over the chunks. The reader holds one
+// transaction per line, with comma separated items, may be compressed (see Decompress) and is rewound for the
+// second pass. Only a chunk is kept in memory at a time. The negated items, minimum supports by length and target
+// itemset counts are not supported.
+func PartitionedFrequentItemsets(r io.ReadSeeker, chunkSize int, options Options) ([]SupportRecord, error) {
+	if err := options.check(); err != nil {
+		return nil, err
+	}
+	if chunkSize < 1 {
+		return nil, errors.New("chunk size must be at least 1")
+	}
+	// A target would tune a different minimum support in every chunk, dropping the candidates of the lower ones.
+	if options.NegatedItemsMinSupport > 0 || len(options.MinSupportByLength) > 0 || options.TargetItemsetCount > 0 {
+		return nil, errors.New("partitioned mining doesn't support negated items, minimum supports by length and target itemset counts")
+	}
+
+	// First pass: the candidates are the itemsets frequent in any chunk. The length and all-confidence thresholds
+	// aren't anti-monotone over the chunks, they are applied to the global results.
+	localOptions := options
+	localOptions.MinLength = 0
+	localOptions.MaxSupport = 0
+	localOptions.MinAllConfidence = 0
+	localOptions.KeepTransactionIDs = false
+	localOptions.OnItemset = nil
+	candidates := make(map[string][]string)
+	err := readChunks(r, chunkSize, func(chunk *Apriori) {
+		for _, record := range chunk.FrequentItemsets(localOptions) {
+			candidates[itemsetKey(record.items)] = record.items
+		}
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	counted := countedItemsets(candidates)
+
+	// Second pass: the global counts of the candidates.
+	if _, err := r.Seek(0, io.SeekStart); err != nil {
+		return nil, err
+	}
+	var transactionNo int64
+	counts := make(map[string]int64, len(counted))
+	transactionIDs := make(map[string][]int64)
+	err = readChunks(r, chunkSize, func(chunk *Apriori) {
+		if len(options.Taxonomy) > 0 {
+			chunk.materializeAncestors(options.Taxonomy)
+		}
+		for key, items := range counted {
+			indexes := chunk.calculateTransactionIndexes(items)
+			counts[key] += int64(len(indexes))
+			if options.KeepTransactionIDs && candidates[key] != nil {
+				for _, index := range indexes {
+					if options.MaxTransactionIDs > 0 && len(transactionIDs[key]) >= options.MaxTransactionIDs {
+						break
+					}
+					transactionIDs[key] = append(transactionIDs[key], transactionNo+index)
+				}
+			}
+		}
+		transactionNo += chunk.transactionNo
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return globalSupportRecords(candidates, counts, transactionIDs, transactionNo, options), nil
+}
+
+// Returns the itemsets to count globally: the candidates and their single items, for the all-confidence.
+func countedItemsets(candidates map[string][]string) map[string][]string {
+	counted := make(map[string][]string, len(candidates))
+	for key, items := range candidates {
+		counted[key] = items
+		for _, item := range items {
+			counted[itemsetKey([]string{item})] = []string{item}
+		}
+	}
+
+	return counted
+}
+
+// Returns the support records of the candidates with the given global counts, sorted by length and items, passing
+// the support, length and all-confidence thresholds. The counts include the ones of the single items of the
+// candidates, for the all-confidence.
+func globalSupportRecords(candidates map[string][]string, counts map[string]int64, transactionIDs map[string][]int64, transactionNo int64, options Options) []SupportRecord {
+	a := Apriori{transactionNo: transactionNo}
+	var records []SupportRecord
+	for key, items := range candidates {
+		support := a.countToSupport(counts[key])
+		if support < options.minSupport || len(items) < options.MinLength || options.aboveMaxSupport(support) {
+			continue
+		}
+		maxItemSupport := 0.0
+		for _, item := range items {
+			if itemSupport := a.countToSupport(counts[itemsetKey([]string{item})]); itemSupport > maxItemSupport {
+				maxItemSupport = itemSupport
+			}
+		}
+		allConfidence := support / maxItemSupport
+		if allConfidence < options.MinAllConfidence {
+			continue
+		}
+		records = append(records, SupportRecord{items: items, support: support, supportCount: counts[key], allConfidence: allConfidence, transactionIDs: transactionIDs[key]})
+	}
+	sort.Slice(records, func(i, j int) bool {
+		if len(records[i].items) != len(records[j].items) {
+			return len(records[i].items) < len(records[j].items)
+		}
+		return itemsetKey(records[i].items) < itemsetKey(records[j].items)
+	})
+
+	return records
+}
+
+// Reads the transactions in chunks of chunkSize and calls process with an Apriori struct for each chunk.
+func readChunks(r io.Reader, chunkSize int, process func(chunk *Apriori)) error {
+	r, err := Decompress(r)
+	if err != nil {
+		return err
+	}
+	reader := bufio.NewReader(r)
+	var chunk [][]string
+	for {
+		line, err := reader.ReadString('\n')
+		if err != nil && err != io.EOF {
+			return err
+		}
+		if line = strings.TrimRight(line, "\r\n"); line != "" {
+			chunk = append(chunk, parseTransaction(line))
+		}
+		if len(chunk) == chunkSize || (err == io.EOF && len(chunk) > 0) {
+			process(NewApriori(chunk))
+			chunk = nil
+		}
+		if err == io.EOF {
+			return nil
+		}
+	}
+}
+
+// Returns the items of a transaction line, without the surrounding spaces.
+func parseTransaction(line string) []string {
+	var items []string
+	for _, item := range strings.Split(line, transactionItemSeparator) {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+
+	return items
+}
@@ -0,0 +1,49 @@
+package apriori
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestPartitionedFrequentItemsets(t *testing.T) {
+	transactions := [][]string{
+		{"beer", "nuts", "cheese"},
+		{"beer", "nuts", "jam"},
+		{"beer", "butter"},
+		{"nuts", "cheese"},
+		{"beer", "nuts", "cheese", "jam"},
+		{"butter"},
+		{"beer", "nuts", "jam", "butter"},
+		{"jam"},
+	}
+	var lines []string
+	for _, transaction := range transactions {
+		lines = append(lines, strings.Join(transaction, ", "))
+	}
+	data := strings.Join(lines, "\n") + "\n"
+
+	format := func(records []SupportRecord) string {
+		var formatted []string
+		for _, record := range records {
+			formatted = append(formatted, formatSupportRecord(record)+fmt.Sprint(record.GetTransactionIDs()))
+		}
+		return fmt.Sprint(formatted)
+	}
+
+	for _, chunkSize := range []int{1, 3, 8} {
+		options := NewOptions(0.25, 0, 0, 0)
+		options.KeepTransactionIDs = true
+		records, err := PartitionedFrequentItemsets(strings.NewReader(data), chunkSize, options)
+		assert(err == nil, "Expected the partitioned mining to succeed")
+		expected := format(NewApriori(transactions).FrequentItemsets(options))
+		assert(format(records) == expected, fmt.Sprintf("Unexpected itemsets for chunks of %d: %s", chunkSize, format(records)))
+	}
+
+	_, err := PartitionedFrequentItemsets(strings.NewReader(data), 0, NewOptions(0.25, 0, 0, 0))
+	assert(err != nil, "Expected an error for an empty chunk size")
+
+	options, _ := NewOptionsWith(WithTargetItemsetCount(5))
+	_, err = PartitionedFrequentItemsets(strings.NewReader(data), 3, options)
+	assert(err != nil, "Expected an error for a target itemset count")
+}
@@ -0,0 +1,143 @@
+package apriori
+
+import (
+	"errors"
+	"fmt"
+)
+
+// OversizedTransactions is what happens to the transactions with more items than TransactionPolicy.MaxItems
+type OversizedTransactions int
+
+const (
+	// TruncateOversized keeps the first MaxItems distinct items of the transaction
+	TruncateOversized OversizedTransactions = iota
+	// RejectOversized fails the transaction with an error
+	RejectOversized
+)
+
+// TransactionPolicy decides which transactions are added, see NewAprioriWithPolicy. The empty transactions lower all
+// the supports, and a transaction of n items contains 2^n itemsets, so a few huge ones, e.g. of wholesale buyers,
+// can explode the number of candidates.
+type TransactionPolicy struct {
+	Normalize func(item string) string // When not nil, normalizes the items first, see NewAprioriWithNormalizer.
+	SkipEmpty bool                     // Don't add the transactions without any item.
+	MaxItems  int                      // When > 0, the maximum number of distinct items of a transaction.
+	Oversized OversizedTransactions    // TruncateOversized or RejectOversized.
+}
+
+// PolicyStats are the numbers of transactions the TransactionPolicy didn't add as they were
+type PolicyStats struct {
+	skippedEmpty int64
+	truncated    int64
+	rejected     int64
+}
+
+// GetSkippedEmpty will return the number of empty transactions skipped
+func (ps PolicyStats) GetSkippedEmpty() int64 {
+	return ps.skippedEmpty
+}
+
+// GetTruncated will return the number of oversized transactions truncated
+func (ps PolicyStats) GetTruncated() int64 {
+	return ps.truncated
+}
+
+// GetRejected will return the number of oversized transactions rejected
+func (ps PolicyStats) GetRejected() int64 {
+	return ps.rejected
+}
+
+// NewAprioriWithPolicy creates an Apriori struct whose transactions, these ones and the ones added later, are
+// skipped, truncated or rejected by the policy, after their items are normalized by its normalizer, if any. A
+// rejected transaction is returned as an error, by AddTransactionsBatch and AddTransactionWithQuantities as well,
+// while AddTransaction panics with it. The numbers of transactions affected are returned by PolicyStats, and
+// reported to the Logger and the Tracer of the mining runs.
+func NewAprioriWithPolicy(transactions [][]string, policy TransactionPolicy) (*Apriori, error) {
+	if policy.MaxItems < 0 {
+		return nil, errors.New("the maximum number of items of a transaction must be >= 0")
+	}
+	a := &Apriori{transactionIndexMap: make(map[interface{}][]int64), itemNormalizer: policy.Normalize, transactionPolicy: &policy}
+	if err := a.AddTransactionsBatch(transactions, 0); err != nil {
+		return nil, err
+	}
+
+	return a, nil
+}
+
+// PolicyStats returns the numbers of transactions skipped, truncated and rejected by the TransactionPolicy
+func (a *Apriori) PolicyStats() PolicyStats {
+	return a.policyStats
+}
+
+// Returns whether the policy rejects the transaction of distinct items.
+func (policy TransactionPolicy) rejects(transaction []string) bool {
+	return policy.MaxItems > 0 && policy.Oversized == RejectOversized && len(transaction) > policy.MaxItems
+}
+
+// Returns the error of a rejected transaction of the given number of items.
+func (policy TransactionPolicy) oversizedError(items int) error {
+	return fmt.Errorf("the transaction has %d items, more than the maximum of %d", items, policy.MaxItems)
+}
+
+// Returns the transaction to add as allowed by the policy, ok being false when it's skipped.
+func (a *Apriori) admitTransaction(transaction []string) (admitted []string, ok bool, err error) {
+	policy := a.transactionPolicy
+	if policy == nil {
+		return transaction, true, nil
+	}
+	if len(transaction) == 0 {
+		if policy.SkipEmpty {
+			a.policyStats.skippedEmpty++
+			return nil, false, nil
+		}
+		return transaction, true, nil
+	}
+	if policy.MaxItems <= 0 || len(transaction) <= policy.MaxItems {
+		return transaction, true, nil
+	}
+
+	// Only the distinct items count.
+	transaction = a.uniqueItems(transaction)
+	if len(transaction) <= policy.MaxItems {
+		return transaction, true, nil
+	}
+	if policy.rejects(transaction) {
+		a.policyStats.rejected++
+		return nil, false, policy.oversizedError(len(transaction))
+	}
+	a.policyStats.truncated++
+
+	return transaction[:policy.MaxItems], true, nil
+}
+
+// Returns the transactions to add as allowed by the policy, after normalizing their items. When a transaction is
+// rejected the error is returned and only the rejection is counted, none of the transactions being added.
+func (a *Apriori) admitTransactions(transactions [][]string) ([][]string, error) {
+	stats := a.policyStats
+	admitted := make([][]string, 0, len(transactions))
+	for i, transaction := range transactions {
+		transaction, ok, err := a.admitTransaction(a.normalizeTransaction(transaction))
+		if err != nil {
+			a.policyStats = stats
+			a.policyStats.rejected++
+			return nil, fmt.Errorf("transaction %d: %w", i, err)
+		}
+		if ok {
+			admitted = append(admitted, transaction)
+		}
+	}
+
+	return admitted, nil
+}
+
+// Reports the numbers of transactions affected by the policy at the start of a mining run.
+func (a *Apriori) reportPolicyStats(options Options, span Span) {
+	if a.transactionPolicy == nil {
+		return
+	}
+	span.SetAttribute("skippedEmptyTransactions", a.policyStats.skippedEmpty)
+	span.SetAttribute("truncatedTransactions", a.policyStats.truncated)
+	span.SetAttribute("rejectedTransactions", a.policyStats.rejected)
+	options.debug("transaction policy applied", "skippedEmpty", a.policyStats.skippedEmpty,
+		"truncated", a.policyStats.truncated, "rejected", a.policyStats.rejected)
+}
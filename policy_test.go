@@ -0,0 +1,56 @@
+package apriori
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestNewAprioriWithPolicy(t *testing.T) {
+	transactions := [][]string{
+		{"beer", "nuts"},
+		{},
+		{"beer", "nuts", "nuts", "jam", "bread", "wine"},
+		{" "},
+	}
+	apriori, err := NewAprioriWithPolicy(transactions, TransactionPolicy{Normalize: NormalizeItem, SkipEmpty: true, MaxItems: 3})
+	assert(err == nil, fmt.Sprint("Unexpected error ", err))
+	assert(apriori.TransactionCount() == 2, fmt.Sprint("Expected the empty transactions to be skipped ", apriori.TransactionCount()))
+	assert(fmt.Sprint(apriori.Items()) == "[beer jam nuts]", fmt.Sprint("Expected the oversized transaction to be truncated ", apriori.Items()))
+
+	apriori.AddTransaction(nil)
+	err = apriori.AddTransactionWithQuantities([]Item{{"a", 1}, {"b", 1}, {"c", 1}, {"d", 2}})
+	assert(err == nil, "Expected the transaction with quantities to be truncated")
+	assert(apriori.ItemFrequency("d") == 0 && apriori.quantities["d"] == nil, "Expected the truncated items to be left out")
+	stats := apriori.PolicyStats()
+	assert(stats.GetSkippedEmpty() == 3 && stats.GetTruncated() == 2 && stats.GetRejected() == 0, fmt.Sprint("Unexpected stats ", stats))
+
+	logger := &recordingLogger{}
+	options, _ := NewOptionsWith(WithMinSupport(0.5), WithLogger(logger))
+	apriori.FrequentItemsets(options)
+	logs := strings.Join(logger.logs, "\n")
+	assert(strings.Contains(logs, "transaction policy applied skippedEmpty 3 truncated 2 rejected 0"), "Expected the stats to be logged: "+logs)
+}
+
+func TestNewAprioriWithPolicy_Reject(t *testing.T) {
+	policy := TransactionPolicy{MaxItems: 2, Oversized: RejectOversized}
+	_, err := NewAprioriWithPolicy([][]string{{"a", "b"}, {"a", "b", "c"}}, policy)
+	assert(err != nil && err.Error() == "transaction 1: the transaction has 3 items, more than the maximum of 2", fmt.Sprint("Unexpected error ", err))
+
+	apriori, err := NewAprioriWithPolicy([][]string{{"a", "a", "b"}, {}}, policy)
+	assert(err == nil, "Expected the repeated items to count once")
+	assert(apriori.TransactionCount() == 2, "Expected the empty transaction to be kept")
+	err = apriori.AddTransactionWithQuantities([]Item{{"a", 1}, {"b", 1}, {"c", 1}})
+	assert(err != nil && apriori.TransactionCount() == 2, "Expected the oversized transaction to be rejected")
+	assert(apriori.PolicyStats().GetRejected() == 1, "Expected the rejected transaction to be counted")
+
+	err = apriori.AddTransactionsBatch([][]string{{"a"}, {"a", "b", "c"}, {"b"}}, 1)
+	assert(err != nil && err.Error() == "transaction 1: the transaction has 3 items, more than the maximum of 2", fmt.Sprint("Unexpected error ", err))
+	assert(apriori.TransactionCount() == 2 && apriori.ItemFrequency("a") == 1, "Expected none of the batch to be added")
+	assert(apriori.PolicyStats().GetRejected() == 2, "Expected only the rejected transaction to be counted")
+
+	defer func() {
+		assert(recover() != nil, "Expected AddTransaction to panic with a rejected transaction")
+	}()
+	apriori.AddTransaction([]string{"a", "b", "c"})
+}
@@ -0,0 +1,121 @@
+package apriori
+
+import "strings"
+
+// Confidences closer than this are considered equal, they are computed from different support ratios.
+const confidenceEpsilon = 1e-9
+
+// confidenceCache keeps the confidences of the sub-rules seen while generating rules, so the simplifications
+// of a rule (same add, base reduced to any of its non-empty subsets) don't have to be recalculated each time.
+type confidenceCache struct {
+	apriori *Apriori
+	best    map[string]float64
+}
+
+func newConfidenceCache(a *Apriori) *confidenceCache {
+	return &confidenceCache{apriori: a, best: make(map[string]float64)}
+}
+
+// Returns the best confidence of the rule and of all of its simplifications.
+func (c *confidenceCache) bestConfidence(base []string, add []string) float64 {
+	key := strings.Join(base, "\x00") + "\x01" + strings.Join(add, "\x00")
+	if confidence, ok := c.best[key]; ok {
+		return confidence
+	}
+
+	items := make([]string, 0, len(base)+len(add))
+	items = append(items, base...)
+	items = append(items, add...)
+	confidence := c.apriori.calculateSupport(items) / c.apriori.calculateSupport(base)
+	if simplification, ok := c.bestSimplificationConfidence(base, add); ok && simplification > confidence {
+		confidence = simplification
+	}
+	c.best[key] = confidence
+
+	return confidence
+}
+
+// Returns the best confidence of the simplifications of the rule, false if the base can't be reduced further.
+func (c *confidenceCache) bestSimplificationConfidence(base []string, add []string) (float64, bool) {
+	if len(base) < 2 {
+		return 0, false
+	}
+
+	best := 0.0
+	sub := make([]string, len(base)-1)
+	for i := range base {
+		copy(sub, base[:i])
+		copy(sub[i:], base[i+1:])
+		if confidence := c.bestConfidence(sub, add); confidence > best {
+			best = confidence
+		}
+	}
+
+	return best, true
+}
+
+// Drops the rules for which a more general rule, with the same add and a subset of the base, has an
+// equal or higher confidence.
+func (a *Apriori) pruneRedundantRules(orderedStatistics []OrderedStatistic, confidences *confidenceCache) []OrderedStatistic {
+	var nonRedundant []OrderedStatistic
+	for _, orderedStatistic := range orderedStatistics {
+		best, ok := confidences.bestSimplificationConfidence(orderedStatistic.base, orderedStatistic.add)
+		if ok && best >= orderedStatistic.confidence-confidenceEpsilon {
+			continue
+		}
+		nonRedundant = append(nonRedundant, orderedStatistic)
+	}
+
+	return nonRedundant
+}
+
+// Drops the rules whose confidence doesn't exceed the best confidence of any of their simplifications, the empty
+// base included, by at least minImprovement. The remaining rules are the productive rules described by Bayardo.
+func (a *Apriori) pruneUnproductiveRules(orderedStatistics []OrderedStatistic, minImprovement float64, confidences *confidenceCache) []OrderedStatistic {
+	var productive []OrderedStatistic
+	for _, orderedStatistic := range orderedStatistics {
+		if len(orderedStatistic.base) > 0 {
+			best := a.calculateSupport(orderedStatistic.add)
+			if simplification, ok := confidences.bestSimplificationConfidence(orderedStatistic.base, orderedStatistic.add); ok && simplification > best {
+				best = simplification
+			}
+			if orderedStatistic.confidence-best < minImprovement-confidenceEpsilon {
+				continue
+			}
+		}
+		productive = append(productive, orderedStatistic)
+	}
+
+	return productive
+}
+
+// Collapses the rules found in both directions, {a} => {b} and {b} => {a}, into the more confident one, which keeps
+// the confidence of the other one. The first rule is kept when both are as confident.
+func (a *Apriori) collapseSymmetricRules(orderedStatistics []OrderedStatistic) []OrderedStatistic {
+	indexes := make(map[string]int, len(orderedStatistics))
+	for i, orderedStatistic := range orderedStatistics {
+		indexes[RuleKey(orderedStatistic)] = i
+	}
+
+	var collapsed []OrderedStatistic
+	for i, orderedStatistic := range orderedStatistics {
+		if len(orderedStatistic.base) == 0 {
+			collapsed = append(collapsed, orderedStatistic)
+			continue
+		}
+		j, ok := indexes[RuleKey(OrderedStatistic{base: orderedStatistic.add, add: orderedStatistic.base})]
+		if !ok {
+			collapsed = append(collapsed, orderedStatistic)
+			continue
+		}
+		reverse := orderedStatistics[j]
+		if reverse.confidence > orderedStatistic.confidence || (reverse.confidence == orderedStatistic.confidence && j < i) {
+			continue
+		}
+		orderedStatistic.bidirectional = true
+		orderedStatistic.reverseConfidence = reverse.confidence
+		collapsed = append(collapsed, orderedStatistic)
+	}
+
+	return collapsed
+}
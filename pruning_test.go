@@ -0,0 +1,82 @@
+package apriori
+
+import (
+	"fmt"
+	"math"
+	"testing"
+)
+
+func TestApriori_CalculateWithPruneRedundantRules(t *testing.T) {
+	transactions := [][]string{
+		{"beer", "nuts", "cheese"},
+		{"beer", "nuts", "jam"},
+		{"beer", "butter"},
+		{"nuts", "cheese"},
+		{"beer", "nuts", "cheese", "jam"},
+		{"butter"},
+		{"beer", "nuts", "jam", "butter"},
+		{"jam"},
+	}
+
+	options := NewOptions(0.2, 0.6, 0.0, 0)
+	options.PruneRedundantRules = true
+	out := NewApriori(transactions).Calculate(options)
+
+	// {beer cheese} => {nuts} and {cheese nuts} => {beer} are dropped because {cheese} => {nuts}
+	// and {cheese} => {beer} have the same confidence
+	assert(formatRecords(out) == "[{{[beer] 0.625} [{[] [beer] 0.625 1}]} {{[nuts] 0.625} [{[] [nuts] 0.625 1}]} {{[beer butter] 0.25} [{[butter] [beer] 0.6666666666666666 1.0666666666666667}]} {{[beer cheese] 0.25} [{[cheese] [beer] 0.6666666666666666 1.0666666666666667}]} {{[beer jam] 0.375} [{[beer] [jam] 0.6 1.2} {[jam] [beer] 0.75 1.2}]} {{[beer nuts] 0.5} [{[beer] [nuts] 0.8 1.28} {[nuts] [beer] 0.8 1.28}]} {{[cheese nuts] 0.375} [{[cheese] [nuts] 1 1.6} {[nuts] [cheese] 0.6 1.5999999999999999}]} {{[jam nuts] 0.375} [{[jam] [nuts] 0.75 1.2} {[nuts] [jam] 0.6 1.2}]} {{[beer jam nuts] 0.375} [{[beer jam] [nuts] 1 1.6} {[beer nuts] [jam] 0.75 1.5} {[jam nuts] [beer] 1 1.6}]}]", "Expected output not equal to actual output")
+}
+
+func TestApriori_CalculateWithMinImprovement(t *testing.T) {
+	transactions := [][]string{
+		{"beer", "nuts", "cheese"},
+		{"beer", "nuts", "jam"},
+		{"beer", "butter"},
+		{"nuts", "cheese"},
+		{"beer", "nuts", "cheese", "jam"},
+		{"butter"},
+		{"beer", "nuts", "jam", "butter"},
+		{"jam"},
+	}
+
+	options := NewOptions(0.2, 0.6, 0.0, 0)
+	options.MinImprovement = 0.1
+	out := NewApriori(transactions).Calculate(options)
+
+	// {butter} => {beer} is dropped because it improves the confidence of {} => {beer} only by 0.04
+	assert(formatRecords(out) == "[{{[beer] 0.625} [{[] [beer] 0.625 1}]} {{[nuts] 0.625} [{[] [nuts] 0.625 1}]} {{[beer jam] 0.375} [{[beer] [jam] 0.6 1.2} {[jam] [beer] 0.75 1.2}]} {{[beer nuts] 0.5} [{[beer] [nuts] 0.8 1.28} {[nuts] [beer] 0.8 1.28}]} {{[cheese nuts] 0.375} [{[cheese] [nuts] 1 1.6} {[nuts] [cheese] 0.6 1.5999999999999999}]} {{[jam nuts] 0.375} [{[jam] [nuts] 0.75 1.2} {[nuts] [jam] 0.6 1.2}]} {{[beer jam nuts] 0.375} [{[beer jam] [nuts] 1 1.6} {[beer nuts] [jam] 0.75 1.5} {[jam nuts] [beer] 1 1.6}]}]", "Expected output not equal to actual output")
+}
+
+func TestApriori_CalculateWithCollapseSymmetricRules(t *testing.T) {
+	transactions := [][]string{
+		{"beer", "nuts", "cheese"},
+		{"beer", "nuts", "jam"},
+		{"beer", "butter"},
+		{"nuts", "cheese"},
+		{"beer", "nuts", "cheese", "jam"},
+		{"butter"},
+		{"beer", "nuts", "jam", "butter"},
+		{"jam"},
+	}
+
+	options := NewOptions(0.3, 0.6, 0.0, 2)
+	options.CollapseSymmetricRules = true
+	var rules []string
+	for _, record := range NewApriori(transactions).Calculate(options) {
+		for _, orderedStatistic := range record.GetOrderedStatistic() {
+			if len(orderedStatistic.GetBase()) > 0 {
+				rules = append(rules, orderedStatistic.String())
+			}
+		}
+	}
+
+	// {jam} => {beer} is more confident than {beer} => {jam}, both {beer} => {nuts} and {nuts} => {beer} are as
+	// confident.
+	expected := "[{jam} <=> {beer} conf=0.75/0.6 lift=1.2 {beer} <=> {nuts} conf=0.8/0.8 lift=1.28 " +
+		"{cheese} <=> {nuts} conf=1/0.6 lift=1.6 {jam} <=> {nuts} conf=0.75/0.6 lift=1.2]"
+	assert(fmt.Sprint(rules) == expected, "Unexpected rules: "+fmt.Sprint(rules))
+
+	options.CollapseSymmetricRules = false
+	rule := NewApriori(transactions).Calculate(options)[2].GetOrderedStatistic()[0]
+	assert(!rule.IsBidirectional() && math.IsNaN(rule.GetReverseConfidence()), "Expected a one-way rule")
+}
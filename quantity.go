@@ -0,0 +1,140 @@
+package apriori
+
+import (
+	"fmt"
+	"math"
+)
+
+// Item is an item of a transaction together with the quantity bought, e.g. {"beer", 6} for a 6-pack
+type Item struct {
+	Name string
+	Qty  float64
+}
+
+// QuantitySupport is the way the quantities of the items count in the supports
+type QuantitySupport int
+
+const (
+	// CountSupport ignores the quantities, a transaction counts once for every itemset it contains
+	CountSupport QuantitySupport = iota
+	// MinQuantitySupport counts a transaction as many times as the lowest quantity of the items of the itemset, so
+	// that the itemsets bought in bulk together get a higher support than the ones bought by the unit. The supports
+	// are then the average quantities per transaction and may exceed 1.
+	MinQuantitySupport
+)
+
+// NewAprioriWithQuantities creates an Apriori struct from transactions of items with quantities, for the
+// MinQuantitySupport mode of the options. The quantities of the same item in a transaction are summed, and must be
+// positive.
+func NewAprioriWithQuantities(transactions [][]Item) (*Apriori, error) {
+	a := &Apriori{transactionIndexMap: make(map[interface{}][]int64)}
+	for _, transaction := range transactions {
+		if err := a.AddTransactionWithQuantities(transaction); err != nil {
+			return nil, err
+		}
+	}
+
+	return a, nil
+}
+
+// NewAprioriWithMultiplicities creates an Apriori struct from transactions whose repeated items count as many times
+// as they occur, e.g. {"beer", "beer", "nuts"} for two beers: the number of occurrences is the quantity of the item,
+// counted by the MinQuantitySupport mode of the options. NewApriori counts them once instead.
+func NewAprioriWithMultiplicities(transactions [][]string) *Apriori {
+	a := &Apriori{transactionIndexMap: make(map[interface{}][]int64)}
+	for _, transaction := range transactions {
+		items := make([]Item, len(transaction))
+		for i, name := range transaction {
+			items[i] = Item{name, 1}
+		}
+		// Quantities of 1 are always valid.
+		_ = a.AddTransactionWithQuantities(items)
+	}
+
+	return a
+}
+
+// AddTransactionWithQuantities adds a transaction of items with quantities after the ones the Apriori struct was
+// created with. The transactions added without quantities have a quantity of 1 for every item.
+func (a *Apriori) AddTransactionWithQuantities(transaction []Item) error {
+	quantities := make(map[string]float64, len(transaction))
+	var names []string
+	for _, item := range transaction {
+		if a.itemNormalizer != nil {
+			if item.Name = a.itemNormalizer(item.Name); item.Name == "" {
+				continue
+			}
+		}
+		if !(item.Qty > 0) || math.IsInf(item.Qty, 1) {
+			return fmt.Errorf("quantity of %v must be a positive number", item.Name)
+		}
+		if _, ok := quantities[item.Name]; !ok {
+			names = append(names, item.Name)
+		}
+		quantities[item.Name] += item.Qty
+	}
+
+	names, ok, err := a.admitTransaction(names)
+	if err != nil || !ok {
+		return err
+	}
+
+	if a.transactionIndexMap == nil {
+		a.transactionIndexMap = make(map[interface{}][]int64)
+	}
+	index := a.transactionNo
+	if err := a.indexTransaction(names); err != nil {
+		return err
+	}
+
+	if a.quantities == nil {
+		a.quantities = make(map[string]map[int64]float64)
+	}
+	// Only the quantities other than 1 are kept.
+	for _, name := range names {
+		if quantities[name] == 1 {
+			continue
+		}
+		if a.quantities[name] == nil {
+			a.quantities[name] = make(map[int64]float64)
+		}
+		a.quantities[name][index] = quantities[name]
+	}
+
+	return nil
+}
+
+// Returns the quantity of the item in the transaction with the given index, which contains it.
+func (a *Apriori) quantity(index int64, item string) float64 {
+	if quantity, ok := a.quantities[item][index]; ok {
+		return quantity
+	}
+
+	return 1
+}
+
+// Returns the support of the items contained in the transactions with the given indexes, the sum over the
+// transactions of the lowest quantity of the items, weighted when the transactions have weights.
+func (a *Apriori) minQuantitySupport(items []string, indexes []int64) float64 {
+	total := float64(a.transactionNo)
+	if a.weights != nil {
+		total = a.totalWeight
+	}
+	if total == 0 {
+		return 0.0
+	}
+
+	sum := 0.0
+	for _, index := range indexes {
+		minQuantity := math.Inf(1)
+		for _, item := range items {
+			minQuantity = math.Min(minQuantity, a.quantity(index, item))
+		}
+		if a.weights != nil {
+			minQuantity *= a.weights[index]
+		}
+		sum += minQuantity
+	}
+
+	return sum / total
+}
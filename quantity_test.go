@@ -0,0 +1,65 @@
+package apriori
+
+import (
+	"bytes"
+	"fmt"
+	"testing"
+)
+
+func TestApriori_MinQuantitySupport(t *testing.T) {
+	a, err := NewAprioriWithQuantities([][]Item{
+		{{"beer", 6}, {"chips", 6}},
+		{{"beer", 1}, {"chips", 1}},
+		{{"beer", 1}, {"wine", 1}},
+		{{"chips", 1}, {"chips", 1}},
+	})
+	assert(err == nil, "Expected the transactions to be added")
+	assert(a.ItemFrequency("chips") == 3, "Expected the repeated item to be indexed once")
+
+	format := func(records []SupportRecord) string {
+		var formatted []string
+		for _, record := range records {
+			formatted = append(formatted, fmt.Sprintf("%v %.4g", record.GetItems(), record.GetSupport()))
+		}
+		return fmt.Sprint(formatted)
+	}
+	counted := NewOptions(0.5, 0, 0, 0)
+	assert(format(a.FrequentItemsets(counted)) == "[[beer] 0.75 [chips] 0.75 [beer chips] 0.5]", "Unexpected counted supports: "+format(a.FrequentItemsets(counted)))
+	quantified, _ := NewOptionsWith(WithMinSupport(0.5), WithQuantitySupport(MinQuantitySupport))
+	expected := "[[beer] 2 [chips] 2.25 [beer chips] 1.75]"
+	assert(format(a.FrequentItemsets(quantified)) == expected, "Unexpected quantity supports: "+format(a.FrequentItemsets(quantified)))
+	quantified.Diffsets = true
+	assert(format(a.FrequentItemsets(quantified)) == expected, "Expected the diffsets to be mined level-wise")
+
+	rules := a.Calculate(quantified)
+	rule := rules[len(rules)-1].GetOrderedStatistic()[0]
+	assert(rule.String() == "{beer} => {chips} conf=0.875 lift=0.389", "Unexpected rule: "+rule.String())
+
+	// The quantities follow the transactions.
+	clone := a.Clone()
+	clone.RemoveTransactions(1)
+	assert(format(clone.FrequentItemsets(quantified)) == "[[beer] 0.6667 [chips] 1]", "Unexpected supports after the removal: "+format(clone.FrequentItemsets(quantified)))
+	assert(format(a.FrequentItemsets(quantified)) == expected, "Expected the original quantities to be kept")
+
+	_, err = NewAprioriWithQuantities([][]Item{{{"beer", 0}}})
+	assert(err != nil, "Expected a zero quantity to be rejected")
+	assert(a.Save(&bytes.Buffer{}) != nil, "Expected the quantities not to be saved")
+	_, err = NewOptionsWith(WithQuantitySupport(QuantitySupport(5)))
+	assert(err != nil, "Expected an unknown quantity support to be rejected")
+}
+
+func TestNewAprioriWithMultiplicities(t *testing.T) {
+	a := NewAprioriWithMultiplicities([][]string{
+		{"beer", "beer", "nuts"},
+		{"beer", "nuts", "nuts", "nuts"},
+	})
+	assert(a.ItemFrequency("beer") == 2, "Expected the transactions to be indexed once per item")
+
+	options, err := NewOptionsWith(WithMinSupport(1), WithQuantitySupport(MinQuantitySupport))
+	assert(err == nil, "Expected valid options")
+	var supports []string
+	for _, record := range a.FrequentItemsets(options) {
+		supports = append(supports, fmt.Sprint(record.GetItems(), record.GetSupport()))
+	}
+	assert(fmt.Sprint(supports) == "[[beer] 1.5 [nuts] 2 [beer nuts] 1]", fmt.Sprint("Unexpected supports ", supports))
+}
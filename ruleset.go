@@ -0,0 +1,125 @@
+package apriori
+
+import (
+	"strconv"
+	"strings"
+)
+
+// RuleSet is a set of rules, identified by their base and add, that keeps the grouping by itemset of the mining
+// results. The set operations keep the statistics of the receiver's rules.
+type RuleSet struct {
+	records []RelationRecord
+	keys    map[string]bool
+}
+
+// NewRuleSet creates a RuleSet from mining results. The duplicate rules, see DuplicateRules, are dropped, the first
+// one being kept, and the records of the same itemset are merged.
+func NewRuleSet(records []RelationRecord) RuleSet {
+	rs := RuleSet{keys: make(map[string]bool)}
+	recordIndexes := make(map[string]int)
+	var a Apriori
+	for _, record := range records {
+		for _, orderedStatistic := range record.orderedStatistic {
+			key := RuleKey(orderedStatistic)
+			if rs.keys[key] {
+				continue
+			}
+			rs.keys[key] = true
+
+			itemset := itemsetKey(a.normalizeItems(record.supportRecord.items))
+			i, ok := recordIndexes[itemset]
+			if !ok {
+				i = len(rs.records)
+				recordIndexes[itemset] = i
+				rs.records = append(rs.records, RelationRecord{supportRecord: record.supportRecord})
+			}
+			rs.records[i].orderedStatistic = append(rs.records[i].orderedStatistic, orderedStatistic)
+		}
+	}
+
+	return rs
+}
+
+// GetRecords will return the rules grouped by itemset like the mining results
+func (rs RuleSet) GetRecords() []RelationRecord {
+	return rs.records
+}
+
+// Len returns the number of rules of the set
+func (rs RuleSet) Len() int {
+	return len(rs.keys)
+}
+
+// Contains returns whether the set has a rule with the same base and add
+func (rs RuleSet) Contains(orderedStatistic OrderedStatistic) bool {
+	return rs.keys[RuleKey(orderedStatistic)]
+}
+
+// Union returns the rules of either set
+func (rs RuleSet) Union(other RuleSet) RuleSet {
+	return NewRuleSet(append(append([]RelationRecord{}, rs.records...), other.records...))
+}
+
+// Intersect returns the rules of the set that the other set has as well
+func (rs RuleSet) Intersect(other RuleSet) RuleSet {
+	return rs.filter(other.Contains)
+}
+
+// Difference returns the rules of the set that the other set doesn't have
+func (rs RuleSet) Difference(other RuleSet) RuleSet {
+	return rs.filter(func(orderedStatistic OrderedStatistic) bool { return !other.Contains(orderedStatistic) })
+}
+
+// Returns the rules of the set passing keep.
+func (rs RuleSet) filter(keep func(orderedStatistic OrderedStatistic) bool) RuleSet {
+	var records []RelationRecord
+	for _, record := range rs.records {
+		var kept []OrderedStatistic
+		for _, orderedStatistic := range record.orderedStatistic {
+			if keep(orderedStatistic) {
+				kept = append(kept, orderedStatistic)
+			}
+		}
+		if len(kept) > 0 {
+			records = append(records, RelationRecord{record.supportRecord, kept})
+		}
+	}
+
+	return NewRuleSet(records)
+}
+
+// DuplicateRules returns the rules found more than once in the records, i.e. with the same base and add, every
+// repetition after the first one
+func DuplicateRules(records []RelationRecord) []OrderedStatistic {
+	seen := make(map[string]bool)
+	var duplicates []OrderedStatistic
+	for _, record := range records {
+		for _, orderedStatistic := range record.orderedStatistic {
+			key := RuleKey(orderedStatistic)
+			if seen[key] {
+				duplicates = append(duplicates, orderedStatistic)
+			}
+			seen[key] = true
+		}
+	}
+
+	return duplicates
+}
+
+// RuleKey returns the canonical key of a rule, the same whatever the order of its items, e.g.
+// {"beer","nuts"} => {"jam"}
+func RuleKey(orderedStatistic OrderedStatistic) string {
+	return ItemsetKey(orderedStatistic.base) + " => " + ItemsetKey(orderedStatistic.add)
+}
+
+// ItemsetKey returns the canonical key of an itemset, the same whatever the order of its items, e.g.
+// {"beer","nuts"}
+func ItemsetKey(items []string) string {
+	var a Apriori
+	quoted := make([]string, 0, len(items))
+	for _, item := range a.normalizeItems(items) {
+		quoted = append(quoted, strconv.Quote(item))
+	}
+
+	return "{" + strings.Join(quoted, ",") + "}"
+}
@@ -0,0 +1,51 @@
+package apriori
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestRuleSet(t *testing.T) {
+	lastWeek := NewRuleSet([]RelationRecord{
+		NewRelationRecord(NewSupportRecord([]string{"beer", "nuts"}, 0.5), []OrderedStatistic{
+			NewOrderedStatistic([]string{"beer"}, []string{"nuts"}, 0.8, 1.2),
+			NewOrderedStatistic([]string{"nuts"}, []string{"beer"}, 0.7, 1.2),
+		}),
+		NewRelationRecord(NewSupportRecord([]string{"jam", "nuts"}, 0.2), []OrderedStatistic{
+			NewOrderedStatistic([]string{"jam"}, []string{"nuts"}, 0.6, 1.1),
+		}),
+	})
+	thisWeek := NewRuleSet([]RelationRecord{
+		NewRelationRecord(NewSupportRecord([]string{"nuts", "beer"}, 0.4), []OrderedStatistic{
+			NewOrderedStatistic([]string{"beer"}, []string{"nuts"}, 0.5, 1.0),
+		}),
+		NewRelationRecord(NewSupportRecord([]string{"cheese", "nuts"}, 0.3), []OrderedStatistic{
+			NewOrderedStatistic([]string{"cheese"}, []string{"nuts"}, 0.9, 1.5),
+		}),
+	})
+
+	union := lastWeek.Union(thisWeek)
+	assert(union.Len() == 4, fmt.Sprintf("Unexpected union size %d", union.Len()))
+	assert(formatRecords(union.GetRecords()) == "[{{[beer nuts] 0.5} [{[beer] [nuts] 0.8 1.2} {[nuts] [beer] 0.7 1.2}]} {{[jam nuts] 0.2} [{[jam] [nuts] 0.6 1.1}]} {{[cheese nuts] 0.3} [{[cheese] [nuts] 0.9 1.5}]}]",
+		"Unexpected union: "+formatRecords(union.GetRecords()))
+
+	intersection := lastWeek.Intersect(thisWeek)
+	assert(formatRecords(intersection.GetRecords()) == "[{{[beer nuts] 0.5} [{[beer] [nuts] 0.8 1.2}]}]", "Unexpected intersection: "+formatRecords(intersection.GetRecords()))
+
+	difference := lastWeek.Difference(thisWeek)
+	assert(difference.Len() == 2 && !difference.Contains(NewOrderedStatistic([]string{"beer"}, []string{"nuts"}, 0, 0)), "Unexpected difference: "+formatRecords(difference.GetRecords()))
+
+	records := []RelationRecord{
+		NewRelationRecord(NewSupportRecord([]string{"beer", "nuts"}, 0.5), []OrderedStatistic{
+			NewOrderedStatistic([]string{"beer"}, []string{"nuts"}, 0.8, 1.2),
+		}),
+		NewRelationRecord(NewSupportRecord([]string{"nuts", "beer"}, 0.5), []OrderedStatistic{
+			NewOrderedStatistic([]string{"beer", "beer"}, []string{"nuts"}, 0.8, 1.2),
+		}),
+	}
+	assert(len(DuplicateRules(records)) == 1, "Expected the repeated rule to be detected")
+	assert(NewRuleSet(records).Len() == 1, "Expected the repeated rule to be dropped")
+
+	key := RuleKey(NewOrderedStatistic([]string{"nuts", "beer"}, []string{"jam"}, 0, 0))
+	assert(key == `{"beer","nuts"} => {"jam"}`, "Unexpected rule key: "+key)
+}
@@ -0,0 +1,102 @@
+package apriori
+
+import (
+	"errors"
+	"math/rand"
+	"sort"
+)
+
+// SampledFrequentItemsets finds the frequent itemsets with Toivonen's algorithm: a random sample of sampleSize
+// transactions is mined with the minimum support lowered by loweringFactor, then the itemsets frequent in the
+// sample and their negative border (the infrequent itemsets whose subsets are all frequent in the sample) are
+// counted in all the transactions. The returned itemsets are frequent in all the transactions, and the result
+// is exact, i.e. no frequent itemset was missed, when no itemset of the negative border is frequent. Otherwise
+// a new sample or a lower factor should be tried. The seed makes the sample reproducible. The consequent,
+// negated items, taxonomy and minimum all-confidence options are not supported.
+func (a *Apriori) SampledFrequentItemsets(sampleSize int, loweringFactor float64, seed int64, options Options) ([]SupportRecord, bool, error) {
+	if err := options.check(); err != nil {
+		return nil, false, err
+	}
+	if sampleSize < 1 {
+		return nil, false, errors.New("sample size must be at least 1")
+	}
+	if loweringFactor <= 0 || loweringFactor > 1 {
+		return nil, false, errors.New("lowering factor must be > 0 and <= 1")
+	}
+	if len(options.Consequent) > 0 || options.NegatedItemsMinSupport > 0 || len(options.Taxonomy) > 0 || options.MinAllConfidence > 0 {
+		return nil, false, errors.New("sampling doesn't support the consequent, negated items, taxonomy and minimum all-confidence options")
+	}
+	a = a.miningView()
+
+	sample := a.sample(sampleSize, rand.New(rand.NewSource(seed)))
+	loweredMinSupport := options.minSupport * loweringFactor
+
+	var frequentInSample, negativeBorder [][]string
+	candidates := a.initialCandidates(options)
+	length := 1
+	for len(candidates) > 0 {
+		if options.maxLength != 0 && length > options.maxLength {
+			break
+		}
+		var relations [][]string
+		for _, candidate := range candidates {
+			if sample.calculateSupport(candidate) >= loweredMinSupport {
+				relations = append(relations, candidate)
+			} else {
+				negativeBorder = append(negativeBorder, candidate)
+			}
+		}
+		frequentInSample = append(frequentInSample, relations...)
+		length++
+		candidates = a.createNextCandidates(relations, length)
+	}
+
+	var records []SupportRecord
+	for _, items := range frequentInSample {
+		indexes := a.calculateTransactionIndexes(items)
+		support := a.countToSupport(int64(len(indexes)))
+		if support < options.minSupport || len(items) < options.MinLength || options.aboveMaxSupport(support) {
+			continue
+		}
+		records = append(records, a.newSupportRecord(items, support, a.calculateAllConfidence(items, support), indexes, options))
+	}
+
+	exact := true
+	for _, items := range negativeBorder {
+		if a.calculateSupport(items) >= options.minSupport {
+			exact = false
+			break
+		}
+	}
+
+	return records, exact, nil
+}
+
+// Returns an Apriori struct over a random sample of the transactions, all of them when there are fewer.
+func (a *Apriori) sample(size int, random *rand.Rand) *Apriori {
+	indexes := random.Perm(int(a.transactionNo))
+	if size < len(indexes) {
+		indexes = indexes[:size]
+	}
+	sort.Ints(indexes)
+
+	// The sampled transactions are renumbered in order, so the index lists stay sorted.
+	renumbered := make(map[int64]int64, len(indexes))
+	for i, index := range indexes {
+		renumbered[int64(index)] = int64(i)
+	}
+
+	sample := Apriori{transactionNo: int64(len(indexes)), transactionIndexMap: make(map[interface{}][]int64)}
+	for _, item := range a.items {
+		for _, index := range a.storedItemIndexes(item) {
+			if i, ok := renumbered[index]; ok {
+				if _, found := sample.transactionIndexMap[item]; !found {
+					sample.addItem(item)
+				}
+				sample.transactionIndexMap[item] = append(sample.transactionIndexMap[item], i)
+			}
+		}
+	}
+
+	return &sample
+}
@@ -0,0 +1,42 @@
+package apriori
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestApriori_SampledFrequentItemsets(t *testing.T) {
+	var transactions [][]string
+	for i := 0; i < 100; i++ {
+		transaction := []string{"beer"}
+		if i%2 == 0 {
+			transaction = append(transaction, "nuts")
+		}
+		if i%10 == 0 {
+			transaction = append(transaction, "jam")
+		}
+		transactions = append(transactions, transaction)
+	}
+	a := NewApriori(transactions)
+	options := NewOptions(0.3, 0, 0, 0)
+
+	format := func(records []SupportRecord) string {
+		var formatted []string
+		for _, record := range records {
+			formatted = append(formatted, formatSupportRecord(record))
+		}
+		return fmt.Sprint(formatted)
+	}
+
+	records, exact, err := a.SampledFrequentItemsets(40, 0.8, 1, options)
+	assert(err == nil, "Expected the sampled mining to succeed")
+	assert(exact, "Expected an exact result")
+	assert(format(records) == format(a.FrequentItemsets(options)), "Unexpected sampled itemsets: "+format(records))
+
+	// Every transaction of the sample has jam, so nuts falls in the negative border.
+	_, exact, _ = NewApriori([][]string{{"jam"}, {"nuts"}, {"nuts"}}).SampledFrequentItemsets(1, 1, 1, NewOptions(0.5, 0, 0, 0))
+	assert(!exact, "Expected a missed itemset to be reported")
+
+	_, _, err = a.SampledFrequentItemsets(40, 0, 1, options)
+	assert(err != nil, "Expected an error for a zero lowering factor")
+}
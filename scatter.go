@@ -0,0 +1,95 @@
+package apriori
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// ScatterFormat is the format of the points written by WriteScatter
+type ScatterFormat int
+
+const (
+	// ScatterJSON is a JSON array of the points, as objects
+	ScatterJSON ScatterFormat = iota
+	// ScatterCSV is a CSV file with a header
+	ScatterCSV
+)
+
+// ScatterPoint is a rule as a point of a support-confidence scatter plot. The fields are exported so that the points
+// can be encoded, e.g. as JSON.
+type ScatterPoint struct {
+	Rule       string   `json:"rule"` // Label of the rule, e.g. {beer, diapers} => {nuts}.
+	Support    float64  `json:"support"`
+	Confidence float64  `json:"confidence"`
+	Lift       *float64 `json:"lift"` // Color of the point, null when unknown.
+	Size       int      `json:"size"` // Size of the point, the length of the antecedent.
+}
+
+// ScatterPoints returns the rules as the points of a support-confidence scatter plot, colored by lift and sized by
+// the length of their antecedent. The statistics of the single items aren't rules and are left out.
+func ScatterPoints(records []RelationRecord) []ScatterPoint {
+	points := []ScatterPoint{}
+	for _, record := range records {
+		for _, orderedStatistic := range record.orderedStatistic {
+			if len(orderedStatistic.base) == 0 {
+				continue
+			}
+			point := ScatterPoint{
+				Rule:       fmt.Sprintf("{%s} => {%s}", strings.Join(orderedStatistic.base, ", "), strings.Join(orderedStatistic.add, ", ")),
+				Support:    record.supportRecord.support,
+				Confidence: orderedStatistic.confidence,
+				Size:       len(orderedStatistic.base),
+			}
+			if lift := orderedStatistic.lift; !math.IsNaN(lift) && !math.IsInf(lift, 0) {
+				point.Lift = &lift
+			}
+			points = append(points, point)
+		}
+	}
+
+	return points
+}
+
+// WriteScatter writes the points of the rules for plotting tools, e.g. Grafana or plotly, without post-processing:
+// their label, support, confidence, lift and size (the length of the antecedent). The unknown lifts are null in JSON
+// and empty in CSV.
+func WriteScatter(w io.Writer, records []RelationRecord, format ScatterFormat) error {
+	points := ScatterPoints(records)
+	switch format {
+	case ScatterJSON:
+		encoder := json.NewEncoder(w)
+		encoder.SetEscapeHTML(false)
+		return encoder.Encode(points)
+	case ScatterCSV:
+		writer := csv.NewWriter(w)
+		if err := writer.Write([]string{"rule", "support", "confidence", "lift", "size"}); err != nil {
+			return err
+		}
+		for _, point := range points {
+			lift := ""
+			if point.Lift != nil {
+				lift = strconv.FormatFloat(*point.Lift, 'g', -1, 64)
+			}
+			err := writer.Write([]string{
+				point.Rule,
+				strconv.FormatFloat(point.Support, 'g', -1, 64),
+				strconv.FormatFloat(point.Confidence, 'g', -1, 64),
+				lift,
+				strconv.Itoa(point.Size),
+			})
+			if err != nil {
+				return err
+			}
+		}
+		writer.Flush()
+
+		return writer.Error()
+	default:
+		return fmt.Errorf("unknown scatter format %d", format)
+	}
+}
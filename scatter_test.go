@@ -0,0 +1,37 @@
+package apriori
+
+import (
+	"bytes"
+	"math"
+	"testing"
+)
+
+func TestWriteScatter(t *testing.T) {
+	transactions := [][]string{
+		{"beer", "nuts"},
+		{"beer", "nuts"},
+		{"beer"},
+		{"jam"},
+	}
+	records := NewApriori(transactions).Calculate(NewOptions(0.5, 0, 0, 0))
+
+	var buffer bytes.Buffer
+	assert(WriteScatter(&buffer, records, ScatterJSON) == nil, "Expected the JSON to be written")
+	expected := `[{"rule":"{beer} => {nuts}","support":0.5,"confidence":0.6666666666666666,"lift":1.3333333333333333,"size":1},` +
+		`{"rule":"{nuts} => {beer}","support":0.5,"confidence":1,"lift":1.3333333333333333,"size":1}]` + "\n"
+	assert(buffer.String() == expected, "Unexpected JSON: "+buffer.String())
+
+	records = append(records, NewRelationRecord(NewSupportRecord([]string{"a", "b", "c"}, 0.1),
+		[]OrderedStatistic{NewOrderedStatistic([]string{"a", "b"}, []string{"c"}, 0.5, math.NaN())}))
+	buffer.Reset()
+	assert(WriteScatter(&buffer, records, ScatterCSV) == nil, "Expected the CSV to be written")
+	expected = "rule,support,confidence,lift,size\n" +
+		"{beer} => {nuts},0.5,0.6666666666666666,1.3333333333333333,1\n" +
+		"{nuts} => {beer},0.5,1,1.3333333333333333,1\n" +
+		"\"{a, b} => {c}\",0.1,0.5,,2\n"
+	assert(buffer.String() == expected, "Unexpected CSV: "+buffer.String())
+
+	buffer.Reset()
+	assert(WriteScatter(&buffer, nil, ScatterJSON) == nil && buffer.String() == "[]\n", "Expected an empty array: "+buffer.String())
+	assert(WriteScatter(&buffer, nil, ScatterFormat(7)) != nil, "Expected an error for an unknown format")
+}
@@ -0,0 +1,77 @@
+package apriori
+
+import "math"
+
+// ScoredBasket contains a basket with the rules recommending items it doesn't have and their aggregated scores
+type ScoredBasket struct {
+	basket        []string
+	matchedRules  []OrderedStatistic
+	score         float64
+	maxConfidence float64
+	maxLift       float64
+}
+
+// GetBasket will return the scored basket
+func (sb ScoredBasket) GetBasket() []string {
+	return sb.basket
+}
+
+// GetMatchedRules will return the rules whose base the basket contains and whose add it misses at least an item of,
+// in the order of the rule set
+func (sb ScoredBasket) GetMatchedRules() []OrderedStatistic {
+	return sb.matchedRules
+}
+
+// GetScore will return the sum of the confidences of the matched rules, 0 when none matched
+func (sb ScoredBasket) GetScore() float64 {
+	return sb.score
+}
+
+// GetMaxConfidence will return the highest confidence of the matched rules, 0 when none matched
+func (sb ScoredBasket) GetMaxConfidence() float64 {
+	return sb.maxConfidence
+}
+
+// GetMaxLift will return the highest lift of the matched rules, 0 when none matched
+func (sb ScoredBasket) GetMaxLift() float64 {
+	return sb.maxLift
+}
+
+// Score matches the rules against every basket, e.g. to flag the baskets of a bulk job for cross-sell campaigns. A
+// rule matches a basket that contains its base and misses at least an item of its add, the statistics of the
+// single items, with an empty base, never match. The scored baskets are in the order of the baskets.
+func Score(rules RuleSet, baskets [][]string) []ScoredBasket {
+	scored := make([]ScoredBasket, 0, len(baskets))
+	for _, basket := range baskets {
+		items := make(map[string]bool, len(basket))
+		for _, item := range basket {
+			items[item] = true
+		}
+		scoredBasket := ScoredBasket{basket: basket}
+		for _, record := range rules.records {
+			for _, orderedStatistic := range record.orderedStatistic {
+				if len(orderedStatistic.base) == 0 || !containsAll(items, orderedStatistic.base) || containsAll(items, orderedStatistic.add) {
+					continue
+				}
+				scoredBasket.matchedRules = append(scoredBasket.matchedRules, orderedStatistic)
+				scoredBasket.score += orderedStatistic.confidence
+				scoredBasket.maxConfidence = math.Max(scoredBasket.maxConfidence, orderedStatistic.confidence)
+				scoredBasket.maxLift = math.Max(scoredBasket.maxLift, orderedStatistic.lift)
+			}
+		}
+		scored = append(scored, scoredBasket)
+	}
+
+	return scored
+}
+
+// Returns whether all the items are in the set.
+func containsAll(set map[string]bool, items []string) bool {
+	for _, item := range items {
+		if !set[item] {
+			return false
+		}
+	}
+
+	return true
+}
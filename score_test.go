@@ -0,0 +1,37 @@
+package apriori
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestScore(t *testing.T) {
+	transactions := [][]string{
+		{"beer", "nuts", "cheese"},
+		{"beer", "nuts"},
+		{"beer", "nuts", "jam"},
+		{"beer", "cheese"},
+		{"jam"},
+	}
+	rules := NewRuleSet(NewApriori(transactions).Calculate(NewOptions(0.4, 0.5, 0.0, 0)))
+
+	scored := Score(rules, [][]string{{"beer"}, {"beer", "nuts"}, {"jam"}})
+	assert(len(scored) == 3, "Expected a scored basket per basket")
+
+	// Only the rules recommending an item the basket misses match.
+	var matched []string
+	for _, rule := range scored[0].GetMatchedRules() {
+		matched = append(matched, RuleKey(rule))
+	}
+	assert(fmt.Sprint(matched) == `[{"beer"} => {"cheese"} {"beer"} => {"nuts"}]`, "Unexpected matched rules: "+fmt.Sprint(matched))
+	scores := fmt.Sprintf("%.2f %.2f %.2f", scored[0].GetScore(), scored[0].GetMaxConfidence(), scored[0].GetMaxLift())
+	assert(scores == "1.25 0.75 1.25", "Unexpected scores of the basket: "+scores)
+
+	matched = nil
+	for _, rule := range scored[1].GetMatchedRules() {
+		matched = append(matched, RuleKey(rule))
+	}
+	assert(fmt.Sprint(matched) == `[{"beer"} => {"cheese"}]`, "Unexpected matched rules: "+fmt.Sprint(matched))
+
+	assert(len(scored[2].GetMatchedRules()) == 0 && scored[2].GetScore() == 0, "Expected no rule to match the basket")
+}
@@ -0,0 +1,220 @@
+package apriori
+
+import (
+	"fmt"
+	"sort"
+)
+
+// SequenceRecord contains a sequential pattern, the ordered itemsets bought one after the other, and its support
+type SequenceRecord struct {
+	sequence     [][]string
+	support      float64
+	supportCount int64
+}
+
+// GetSequence will return the ordered itemsets of the pattern
+func (sr SequenceRecord) GetSequence() [][]string {
+	return sr.sequence
+}
+
+// GetSupport will return the fraction of the sequences that contain the pattern
+func (sr SequenceRecord) GetSupport() float64 {
+	return sr.support
+}
+
+// GetSupportCount will return the number of sequences that contain the pattern
+func (sr SequenceRecord) GetSupportCount() int64 {
+	return sr.supportCount
+}
+
+// SequenceMiner finds sequential patterns with PrefixSpan in sequences of baskets, e.g. the orders of every customer
+type SequenceMiner struct {
+	sequences [][][]string
+}
+
+// NewSequenceMiner is a quick way to create a SequenceMiner. Every sequence holds the baskets in the order they
+// were bought.
+func NewSequenceMiner(sequences [][][]string) *SequenceMiner {
+	var a Apriori
+	m := SequenceMiner{sequences: make([][][]string, len(sequences))}
+	for i, sequence := range sequences {
+		m.sequences[i] = make([][]string, len(sequence))
+		for j, basket := range sequence {
+			m.sequences[i][j] = a.normalizeItems(basket)
+		}
+	}
+
+	return &m
+}
+
+// Position of the pattern in a sequence with the earliest matching: the element that matched the last itemset
+// of the pattern and the one that matched the itemset before it.
+type sequenceProjection struct {
+	sequence int
+	previous int
+	last     int
+}
+
+// Calculate returns the sequential patterns based on provided options. The support is relative to the number of
+// sequences and the lengths count the items of all the itemsets of a pattern. The include and exclude lists
+// apply to the items, the rule thresholds are ignored.
+func (m *SequenceMiner) Calculate(options Options) []SequenceRecord {
+	if err := options.check(); err != nil {
+		panic(err)
+	}
+
+	allowed := m.allowedItems(options)
+	var records []SequenceRecord
+	var grow func(pattern [][]string, length int, projections []sequenceProjection)
+	grow = func(pattern [][]string, length int, projections []sequenceProjection) {
+		if length > 0 {
+			if support := m.support(int64(len(projections))); length >= options.MinLength && !options.aboveMaxSupport(support) {
+				records = append(records, SequenceRecord{
+					sequence:     pattern,
+					support:      support,
+					supportCount: int64(len(projections)),
+				})
+			}
+			if options.maxLength != 0 && length >= options.maxLength {
+				return
+			}
+		}
+
+		sequenceExtensions, itemsetExtensions := m.countExtensions(pattern, projections, allowed)
+		for _, item := range m.frequentItems(sequenceExtensions, options.minSupport) {
+			extended := append(append([][]string{}, pattern...), []string{item})
+			grow(extended, length+1, m.project(extended, projections, false))
+		}
+		for _, item := range m.frequentItems(itemsetExtensions, options.minSupport) {
+			extended := append([][]string{}, pattern...)
+			extended[len(extended)-1] = append(append([]string{}, pattern[len(pattern)-1]...), item)
+			grow(extended, length+1, m.project(extended, projections, true))
+		}
+	}
+
+	var initial []sequenceProjection
+	for i := range m.sequences {
+		initial = append(initial, sequenceProjection{sequence: i, previous: -1, last: -1})
+	}
+	grow(nil, 0, initial)
+
+	sort.SliceStable(records, func(i, j int) bool {
+		li, lj := sequenceLength(records[i].sequence), sequenceLength(records[j].sequence)
+		if li != lj {
+			return li < lj
+		}
+		return fmt.Sprint(records[i].sequence) < fmt.Sprint(records[j].sequence)
+	})
+
+	return records
+}
+
+// Returns the set of items that can be part of the patterns.
+func (m *SequenceMiner) allowedItems(options Options) map[string]bool {
+	var a Apriori
+	excluded := make(map[string]bool)
+	for _, item := range options.ExcludeItems {
+		excluded[item] = true
+	}
+
+	allowed := make(map[string]bool)
+	for _, sequence := range m.sequences {
+		for _, basket := range sequence {
+			for _, item := range basket {
+				allowed[item] = !excluded[item] && (len(options.IncludeItems) == 0 || a.inSlice(item, options.IncludeItems))
+			}
+		}
+	}
+
+	return allowed
+}
+
+// Counts, once per sequence, the items that can extend the pattern with a new itemset and the items that can be
+// added to its last itemset.
+func (m *SequenceMiner) countExtensions(pattern [][]string, projections []sequenceProjection, allowed map[string]bool) (map[string]int64, map[string]int64) {
+	var a Apriori
+	sequenceExtensions := make(map[string]int64)
+	itemsetExtensions := make(map[string]int64)
+	for _, projection := range projections {
+		sequence := m.sequences[projection.sequence]
+
+		seen := make(map[string]bool)
+		for _, basket := range sequence[projection.last+1:] {
+			for _, item := range basket {
+				if allowed[item] && !seen[item] {
+					seen[item] = true
+					sequenceExtensions[item]++
+				}
+			}
+		}
+
+		if len(pattern) == 0 {
+			continue
+		}
+		lastItemset := pattern[len(pattern)-1]
+		maxItem := lastItemset[len(lastItemset)-1]
+		seen = make(map[string]bool)
+		for _, basket := range sequence[projection.previous+1:] {
+			if !a.isSubset(lastItemset, [][]string{basket}) {
+				continue
+			}
+			for _, item := range basket {
+				if allowed[item] && item > maxItem && !seen[item] {
+					seen[item] = true
+					itemsetExtensions[item]++
+				}
+			}
+		}
+	}
+
+	return sequenceExtensions, itemsetExtensions
+}
+
+// Returns the projections of the sequences that contain the extended pattern.
+func (m *SequenceMiner) project(extended [][]string, projections []sequenceProjection, itemsetExtension bool) []sequenceProjection {
+	var a Apriori
+	lastItemset := extended[len(extended)-1]
+
+	var projected []sequenceProjection
+	for _, projection := range projections {
+		previous := projection.last
+		if itemsetExtension {
+			previous = projection.previous
+		}
+		sequence := m.sequences[projection.sequence]
+		for i := previous + 1; i < len(sequence); i++ {
+			if a.isSubset(lastItemset, [][]string{sequence[i]}) {
+				projected = append(projected, sequenceProjection{sequence: projection.sequence, previous: previous, last: i})
+				break
+			}
+		}
+	}
+
+	return projected
+}
+
+// Returns the sorted items whose count reaches the minimal support.
+func (m *SequenceMiner) frequentItems(counts map[string]int64, minSupport float64) []string {
+	var items []string
+	for item, count := range counts {
+		if m.support(count) >= minSupport {
+			items = append(items, item)
+		}
+	}
+	sort.Strings(items)
+
+	return items
+}
+
+func (m *SequenceMiner) support(count int64) float64 {
+	return float64(count) / float64(len(m.sequences))
+}
+
+func sequenceLength(sequence [][]string) int {
+	length := 0
+	for _, itemset := range sequence {
+		length += len(itemset)
+	}
+
+	return length
+}
@@ -0,0 +1,45 @@
+package apriori
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestSequenceMiner_Calculate(t *testing.T) {
+	sequences := [][][]string{
+		{{"phone"}, {"case", "charger"}, {"headphones"}},
+		{{"phone", "case"}, {"headphones"}},
+		{{"laptop"}, {"phone"}, {"charger", "case"}},
+		{{"case"}, {"phone"}},
+	}
+
+	formatSequenceRecords := func(records []SequenceRecord) string {
+		var formatted []string
+		for _, record := range records {
+			formatted = append(formatted, fmt.Sprintf("%v:%d", record.GetSequence(), record.GetSupportCount()))
+		}
+		return fmt.Sprint(formatted)
+	}
+
+	provider := []struct {
+		options Options
+		out     string
+	}{
+		{NewOptions(0.5, 0, 0, 0), "[[[case]]:4 [[charger]]:2 [[headphones]]:2 [[phone]]:4 [[case charger]]:2 [[case] [headphones]]:2 [[phone] [case]]:2 [[phone] [charger]]:2 [[phone] [headphones]]:2 [[phone] [case charger]]:2]"},
+		{NewOptions(0.5, 0, 0, 2), "[[[case]]:4 [[charger]]:2 [[headphones]]:2 [[phone]]:4 [[case charger]]:2 [[case] [headphones]]:2 [[phone] [case]]:2 [[phone] [charger]]:2 [[phone] [headphones]]:2]"},
+	}
+	for _, data := range provider {
+		result := formatSequenceRecords(NewSequenceMiner(sequences).Calculate(data.options))
+		assert(result == data.out, "Unexpected sequential patterns: "+result)
+	}
+
+	// The itemset of the pattern is found in a later basket than the earliest match of its first item.
+	options := NewOptions(1, 0, 0, 0)
+	options.MinLength = 2
+	options.ExcludeItems = []string{"phone"}
+	result := formatSequenceRecords(NewSequenceMiner([][][]string{
+		{{"laptop"}, {"case"}, {"case", "mouse"}},
+		{{"laptop", "phone"}, {"case", "mouse"}},
+	}).Calculate(options))
+	assert(result == "[[[case mouse]]:2 [[laptop] [case]]:2 [[laptop] [mouse]]:2 [[laptop] [case mouse]]:2]", "Unexpected sequential patterns: "+result)
+}
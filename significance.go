@@ -0,0 +1,174 @@
+package apriori
+
+import (
+	"math"
+	"sort"
+)
+
+// PValueCorrection is the multiple testing correction applied when filtering rules by p-value
+type PValueCorrection int
+
+const (
+	// NoCorrection compares the raw p-values with the maximum p-value
+	NoCorrection PValueCorrection = iota
+	// BonferroniCorrection divides the maximum p-value by the number of tested rules
+	BonferroniCorrection
+	// BenjaminiHochbergCorrection controls the false discovery rate at the maximum p-value
+	BenjaminiHochbergCorrection
+)
+
+// Returns the chi-square statistic and its p-value, with one degree of freedom, for the 2x2 contingency table
+// [[both, baseOnly], [addOnly, neither]].
+func chiSquareTest(both, baseOnly, addOnly, neither int64) (float64, float64) {
+	n := float64(both + baseOnly + addOnly + neither)
+	rows := float64(both+baseOnly) * float64(addOnly+neither)
+	columns := float64(both+addOnly) * float64(baseOnly+neither)
+	// Without variation in a margin the items can't be dependent.
+	if rows == 0 || columns == 0 {
+		return 0, 1
+	}
+
+	diff := float64(both)*float64(neither) - float64(baseOnly)*float64(addOnly)
+	chiSquare := n * diff * diff / (rows * columns)
+
+	return chiSquare, math.Erfc(math.Sqrt(chiSquare / 2))
+}
+
+// Returns the one-sided Fisher exact test p-value, the probability of observing at least `both` transactions
+// with base and add together given the margins of the 2x2 contingency table.
+func fisherExactTest(both, baseOnly, addOnly, neither int64) float64 {
+	n := both + baseOnly + addOnly + neither
+	base := both + baseOnly
+	add := both + addOnly
+	maxBoth := base
+	if add < maxBoth {
+		maxBoth = add
+	}
+
+	// Hypergeometric log probability of the observed table, the next ones follow from the ratio of consecutive
+	// terms. The terms are summed in log space, scaled by the largest one so far, as on large tables they underflow.
+	logProbability := logChoose(base, both) + logChoose(n-base, add-both) - logChoose(n, add)
+	maxLog, scaledSum := logProbability, 0.0
+	for k := both; k <= maxBoth; k++ {
+		if logProbability > maxLog {
+			scaledSum *= math.Exp(maxLog - logProbability)
+			maxLog = logProbability
+		}
+		scaledSum += math.Exp(logProbability - maxLog)
+		if k < maxBoth {
+			logProbability += math.Log(float64(base-k)*float64(add-k)) - math.Log(float64(k+1)*float64(n-base-add+k+1))
+		}
+	}
+
+	return math.Min(math.Exp(maxLog+math.Log(scaledSum)), 1)
+}
+
+func logChoose(n, k int64) float64 {
+	a, _ := math.Lgamma(float64(n + 1))
+	b, _ := math.Lgamma(float64(k + 1))
+	c, _ := math.Lgamma(float64(n - k + 1))
+
+	return a - b - c
+}
+
+// Keeps the rules whose Fisher exact test p-value passes the corrected maximum p-value. Records without
+// any rule left are dropped.
+func (a *Apriori) filterSignificantRelationRecords(relationRecords []RelationRecord, maxPValue float64, correction PValueCorrection) []RelationRecord {
+	var pValues []float64
+	for _, record := range relationRecords {
+		for _, orderedStatistic := range record.orderedStatistic {
+			pValues = append(pValues, orderedStatistic.fisherPValue)
+		}
+	}
+	threshold := a.pValueThreshold(pValues, maxPValue, correction)
+
+	var significant []RelationRecord
+	for _, record := range relationRecords {
+		var orderedStatistics []OrderedStatistic
+		for _, orderedStatistic := range record.orderedStatistic {
+			if orderedStatistic.fisherPValue <= threshold {
+				orderedStatistics = append(orderedStatistics, orderedStatistic)
+			}
+		}
+		if len(orderedStatistics) == 0 {
+			continue
+		}
+		significant = append(significant, RelationRecord{record.supportRecord, orderedStatistics})
+	}
+
+	return significant
+}
+
+// Returns the largest raw p-value that is still significant after the correction.
+func (a *Apriori) pValueThreshold(pValues []float64, maxPValue float64, correction PValueCorrection) float64 {
+	tests := float64(len(pValues))
+	switch correction {
+	case BonferroniCorrection:
+		return maxPValue / tests
+	case BenjaminiHochbergCorrection:
+		sorted := make([]float64, len(pValues))
+		copy(sorted, pValues)
+		sort.Float64s(sorted)
+		threshold := -1.0
+		for i, pValue := range sorted {
+			if pValue <= float64(i+1)/tests*maxPValue {
+				threshold = pValue
+			}
+		}
+		return threshold
+	default:
+		return maxPValue
+	}
+}
+
+// Quantile of the standard normal distribution for the 95% confidence intervals.
+const confidenceIntervalZ = 1.959963984540054
+
+// Returns the Wilson score interval of a proportion of successes among trials, for the normal quantile z. Unlike
+// the normal approximation it stays within [0, 1] and is reliable for few trials.
+func wilsonInterval(successes, trials int64, z float64) (float64, float64) {
+	if trials <= 0 {
+		return math.NaN(), math.NaN()
+	}
+
+	n := float64(trials)
+	p := float64(successes) / n
+	z2 := z * z
+	center := (p + z2/(2*n)) / (1 + z2/n)
+	margin := z / (1 + z2/n) * math.Sqrt(p*(1-p)/n+z2/(4*n*n))
+
+	return math.Max(0, center-margin), math.Min(1, center+margin)
+}
+
+// GetConfidenceInterval will return the lower and upper bounds of the 95% Wilson score interval of the confidence,
+// from the support and base counts: a rule supported by 5 transactions gets a wider interval than one supported
+// by 5,000. NaN when the counts are unknown.
+func (os OrderedStatistic) GetConfidenceInterval() (float64, float64) {
+	return wilsonInterval(os.supportCount, os.baseCount, confidenceIntervalZ)
+}
+
+// RankByConfidenceLowerBound returns the rules of the records, leaving out the statistics of the single items,
+// sorted by the lower bound of their confidence interval, the most reliable first
+func RankByConfidenceLowerBound(records []RelationRecord) []Rule {
+	return RankBy(records, func(orderedStatistic OrderedStatistic) float64 {
+		lowerBound, _ := orderedStatistic.GetConfidenceInterval()
+		return lowerBound
+	})
+}
+
+// Sorts rules by decreasing values, the NaN ones last.
+type rulesByValue struct {
+	rules  []Rule
+	values []float64
+}
+
+func (r rulesByValue) Len() int { return len(r.rules) }
+
+func (r rulesByValue) Less(i, j int) bool {
+	return r.values[i] > r.values[j] || (!math.IsNaN(r.values[i]) && math.IsNaN(r.values[j]))
+}
+
+func (r rulesByValue) Swap(i, j int) {
+	r.rules[i], r.rules[j] = r.rules[j], r.rules[i]
+	r.values[i], r.values[j] = r.values[j], r.values[i]
+}
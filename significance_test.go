@@ -0,0 +1,115 @@
+package apriori
+
+import (
+	"fmt"
+	"math"
+	"testing"
+)
+
+func TestSignificanceTests(t *testing.T) {
+	chiSquare, pValue := chiSquareTest(10, 20, 30, 40)
+	assert(math.Abs(chiSquare-0.7936507936507936) < 1e-9, "Unexpected chi-square statistic")
+	assert(math.Abs(pValue-0.3729984836134872) < 1e-9, "Unexpected chi-square p-value")
+
+	// Lady tasting tea: 3 of 4 cups guessed right
+	assert(math.Abs(fisherExactTest(3, 1, 1, 3)-17.0/70.0) < 1e-9, "Unexpected Fisher exact test p-value")
+	assert(math.Abs(fisherExactTest(4, 0, 0, 4)-1.0/70.0) < 1e-9, "Unexpected Fisher exact test p-value")
+
+	// On large tables the probability of the observed table underflows, the p-values must not.
+	assert(fisherExactTest(20000, 30000, 30000, 20000) == 1, "Expected a negative association not to be significant")
+	assert(math.Abs(fisherExactTest(25000, 25000, 25000, 25000)-0.5025231136219797) < 1e-6, "Unexpected Fisher exact test p-value of an independent large table")
+	assert(math.Abs(fisherExactTest(25300, 24700, 24700, 25300)-7.579480488695995e-05)/7.579480488695995e-05 < 1e-6, "Unexpected Fisher exact test p-value of a large table")
+}
+
+func TestApriori_pValueThreshold(t *testing.T) {
+	a := NewApriori(nil)
+	pValues := []float64{0.01, 0.035, 0.02, 0.2}
+
+	assert(a.pValueThreshold(pValues, 0.05, NoCorrection) == 0.05, "Unexpected threshold without correction")
+	assert(a.pValueThreshold(pValues, 0.05, BonferroniCorrection) == 0.0125, "Unexpected Bonferroni threshold")
+	assert(a.pValueThreshold(pValues, 0.05, BenjaminiHochbergCorrection) == 0.035, "Unexpected Benjamini-Hochberg threshold")
+}
+
+func TestApriori_CalculateWithMaxPValue(t *testing.T) {
+	transactions := [][]string{
+		{"beer", "nuts", "cheese"},
+		{"beer", "nuts", "jam"},
+		{"beer", "butter"},
+		{"nuts", "cheese"},
+		{"beer", "nuts", "cheese", "jam"},
+		{"butter"},
+		{"beer", "nuts", "jam", "butter"},
+		{"jam"},
+	}
+
+	options := NewOptions(0.2, 0.0, 0.0, 0)
+	options.MaxPValue = 0.2
+	out := NewApriori(transactions).Calculate(options)
+
+	assert(formatRecords(out) == "[{{[cheese nuts] 0.375} [{[cheese] [nuts] 1 1.6} {[nuts] [cheese] 0.6 1.5999999999999999}]} {{[beer jam nuts] 0.375} [{[beer jam] [nuts] 1 1.6} {[jam nuts] [beer] 1 1.6}]}]", "Expected output not equal to actual output")
+
+	options.PValueCorrection = BonferroniCorrection
+	assert(len(NewApriori(transactions).Calculate(options)) == 0, "Expected no rule to pass the Bonferroni correction")
+}
+
+func TestWilsonInterval(t *testing.T) {
+	lower, upper := wilsonInterval(5, 10, confidenceIntervalZ)
+	assert(math.Abs(lower-0.2366) < 1e-4 && math.Abs(upper-0.7634) < 1e-4, fmt.Sprintf("Unexpected interval [%v, %v]", lower, upper))
+	lower, upper = wilsonInterval(10, 10, confidenceIntervalZ)
+	assert(math.Abs(upper-1) < 1e-9 && math.Abs(lower-0.7225) < 1e-4, fmt.Sprintf("Unexpected interval [%v, %v]", lower, upper))
+	lower, upper = wilsonInterval(0, 0, confidenceIntervalZ)
+	assert(math.IsNaN(lower) && math.IsNaN(upper), "Expected a NaN interval without trials")
+}
+
+func TestApriori_CalculateWithMinConfidenceLowerBound(t *testing.T) {
+	var transactions [][]string
+	for i := 0; i < 40; i++ {
+		transactions = append(transactions, []string{"beer", "nuts"})
+	}
+	transactions = append(transactions, []string{"beer"}, []string{"wine", "cheese"}, []string{"wine", "cheese"})
+
+	out := NewApriori(transactions).Calculate(NewOptions(0.04, 0.9, 0.0, 0))
+	var ranked []string
+	for _, rule := range RankByConfidenceLowerBound(out) {
+		ranked = append(ranked, RuleKey(rule.GetOrderedStatistic()))
+	}
+	// Both wine and cheese always occur together, but in 2 transactions only.
+	assert(fmt.Sprint(ranked) == `[{"nuts"} => {"beer"} {"beer"} => {"nuts"} {"cheese"} => {"wine"} {"wine"} => {"cheese"}]`, "Unexpected ranking: "+fmt.Sprint(ranked))
+
+	options := NewOptions(0.04, 0.9, 0.0, 0)
+	options.MinConfidenceLowerBound = 0.8
+	var rules []string
+	for _, record := range NewApriori(transactions).Calculate(options) {
+		for _, orderedStatistic := range record.GetOrderedStatistic() {
+			rules = append(rules, RuleKey(orderedStatistic))
+		}
+	}
+	assert(fmt.Sprint(rules) == `[{} => {"beer"} {} => {"nuts"} {"beer"} => {"nuts"} {"nuts"} => {"beer"}]`, "Unexpected rules: "+fmt.Sprint(rules))
+}
+
+func TestApriori_CalculateWithMaxChiSquarePValue(t *testing.T) {
+	// Bread is in almost every transaction, the rules predicting it are confident but uncorrelated.
+	var transactions [][]string
+	for i := 0; i < 20; i++ {
+		transactions = append(transactions, []string{"beer", "nuts", "bread"}, []string{"bread"})
+	}
+	transactions = append(transactions, []string{"wine"}, []string{"wine"})
+
+	format := func(options Options) string {
+		var rules []string
+		for _, record := range NewApriori(transactions).Calculate(options) {
+			for _, orderedStatistic := range record.GetOrderedStatistic() {
+				if len(orderedStatistic.GetBase()) > 0 {
+					rules = append(rules, fmt.Sprintf("%s p=%.2g", RuleKey(orderedStatistic), orderedStatistic.GetChiSquarePValue()))
+				}
+			}
+		}
+		return fmt.Sprint(rules)
+	}
+	options := NewOptions(0.4, 0.9, 0.0, 2)
+	assert(format(options) == `[{"beer"} => {"bread"} p=0.17 {"beer"} => {"nuts"} p=9.1e-11 {"nuts"} => {"beer"} p=9.1e-11 {"nuts"} => {"bread"} p=0.17]`, "Unexpected rules: "+format(options))
+	options.MaxChiSquarePValue = 0.05
+	assert(format(options) == `[{"beer"} => {"nuts"} p=9.1e-11 {"nuts"} => {"beer"} p=9.1e-11]`, "Unexpected correlated rules: "+format(options))
+	_, err := NewOptionsWith(WithMaxChiSquarePValue(2))
+	assert(err != nil, "Expected an invalid p-value to be rejected")
+}
@@ -0,0 +1,168 @@
+package apriori
+
+import (
+	"bufio"
+	"errors"
+	"hash/fnv"
+	"io"
+	"math"
+	"sort"
+	"strings"
+)
+
+// Count-Min Sketch of the counts of the itemsets: every itemset increments a counter of each row, picked by its
+// hash, and its count is estimated by the smallest of them, never lower than the actual count.
+type countMinSketch struct {
+	width    uint64
+	counters [][]int64
+}
+
+// Creates a sketch whose estimates exceed the counts by at most epsilon times the number of additions with a
+// probability of at least 1 - delta.
+func newCountMinSketch(epsilon float64, delta float64) *countMinSketch {
+	width := uint64(math.Ceil(math.E / epsilon))
+	depth := int(math.Ceil(math.Log(1 / delta)))
+	counters := make([][]int64, depth)
+	for row := range counters {
+		counters[row] = make([]int64, width)
+	}
+
+	return &countMinSketch{width: width, counters: counters}
+}
+
+// Returns the counter of every row for the itemset, from 2 halves of its hash (Kirsch-Mitzenmacher).
+func (s *countMinSketch) columns(items []string, visit func(row int, column uint64)) {
+	hash := fnv.New64a()
+	for _, item := range items {
+		hash.Write([]byte(item))
+		hash.Write([]byte{0})
+	}
+	sum := hash.Sum64()
+	h1, h2 := sum&math.MaxUint32, sum>>32|1
+	for row := range s.counters {
+		visit(row, (h1+uint64(row)*h2)%s.width)
+	}
+}
+
+func (s *countMinSketch) add(items []string) {
+	s.columns(items, func(row int, column uint64) { s.counters[row][column]++ })
+}
+
+func (s *countMinSketch) estimate(items []string) int64 {
+	estimate := int64(math.MaxInt64)
+	s.columns(items, func(row int, column uint64) {
+		if count := s.counters[row][column]; count < estimate {
+			estimate = count
+		}
+	})
+
+	return estimate
+}
+
+// SketchFrequentItemsets approximates the frequent itemsets of a dataset in a single pass over the reader, for
+// quick exploratory runs on huge files: the items are counted exactly, and the itemsets of up to the maximum length
+// of the options, which is required, are counted by a Count-Min Sketch of constant memory. The supports are then
+// overestimated by at most epsilon with a probability of at least 1 - delta, so every frequent itemset is found,
+// along with some itemsets that are frequent only by the estimate. The reader holds one transaction per line, with
+// comma separated items, and may be compressed (see Decompress). The consequent, negated items, taxonomy,
+// transaction IDs and minimum supports by length options are not supported.
+func SketchFrequentItemsets(r io.Reader, epsilon float64, delta float64, options Options) ([]SupportRecord, error) {
+	if err := options.Validate(); err != nil {
+		return nil, err
+	}
+	if epsilon <= 0 || epsilon >= 1 || delta <= 0 || delta >= 1 {
+		return nil, errors.New("epsilon and delta must be between 0 and 1")
+	}
+	if options.maxLength == 0 {
+		return nil, errors.New("the sketch needs a maximum length")
+	}
+	if len(options.Consequent) > 0 || options.NegatedItemsMinSupport > 0 || len(options.Taxonomy) > 0 || options.KeepTransactionIDs ||
+		len(options.MinSupportByLength) > 0 {
+		return nil, errors.New("the sketch doesn't support the consequent, negated items, taxonomy, transaction IDs and minimum supports by length options")
+	}
+
+	included := make(map[string]bool)
+	for _, item := range options.IncludeItems {
+		included[item] = true
+	}
+	excluded := make(map[string]bool)
+	for _, item := range options.ExcludeItems {
+		excluded[item] = true
+	}
+
+	var a Apriori
+	itemCounts := make(map[string]int64)
+	sketch := newCountMinSketch(epsilon, delta)
+	buffer := make([]string, options.maxLength)
+	r, err := Decompress(r)
+	if err != nil {
+		return nil, err
+	}
+	reader := bufio.NewReader(r)
+	for {
+		line, err := reader.ReadString('\n')
+		if err != nil && err != io.EOF {
+			return nil, err
+		}
+		if line = strings.TrimRight(line, "\r\n"); line != "" {
+			var items []string
+			for _, item := range a.normalizeItems(parseTransaction(line)) {
+				if (len(included) == 0 || included[item]) && !excluded[item] {
+					items = append(items, item)
+					itemCounts[item]++
+				}
+			}
+			for length := 2; length <= options.maxLength && length <= len(items); length++ {
+				combinations(items, length, buffer[:length], sketch.add)
+			}
+			a.transactionNo++
+		}
+		if err == io.EOF {
+			break
+		}
+	}
+
+	// The candidates are generated level-wise from the estimated frequent itemsets, a frequent itemset having
+	// frequent subsets by the estimates too. An estimate can't exceed the counts of the items.
+	var records []SupportRecord
+	var candidates [][]string
+	for item, count := range itemCounts {
+		if a.countToSupport(count) >= options.minSupport {
+			candidates = append(candidates, []string{item})
+		}
+	}
+	sort.Slice(candidates, func(i, j int) bool { return candidates[i][0] < candidates[j][0] })
+	for length := 1; len(candidates) > 0 && length <= options.maxLength; length++ {
+		var frequent [][]string
+		for _, candidate := range candidates {
+			count := int64(math.MaxInt64)
+			maxItemCount := int64(0)
+			for _, item := range candidate {
+				if itemCounts[item] < count {
+					count = itemCounts[item]
+				}
+				if itemCounts[item] > maxItemCount {
+					maxItemCount = itemCounts[item]
+				}
+			}
+			if length > 1 {
+				if estimate := sketch.estimate(candidate); estimate < count {
+					count = estimate
+				}
+			}
+			support := a.countToSupport(count)
+			if support < options.minSupport {
+				continue
+			}
+			frequent = append(frequent, candidate)
+			allConfidence := support / a.countToSupport(maxItemCount)
+			if length < options.MinLength || allConfidence < options.MinAllConfidence || options.aboveMaxSupport(support) {
+				continue
+			}
+			records = append(records, SupportRecord{items: candidate, support: support, supportCount: count, allConfidence: allConfidence})
+		}
+		candidates = a.createNextCandidates(frequent, length+1)
+	}
+
+	return records, nil
+}
@@ -0,0 +1,51 @@
+package apriori
+
+import (
+	"fmt"
+	"math/rand"
+	"strings"
+	"testing"
+)
+
+func TestSketchFrequentItemsets(t *testing.T) {
+	random := rand.New(rand.NewSource(5))
+	items := []string{"beer", "nuts", "cheese", "jam", "wine", "butter", "bread"}
+	var transactions [][]string
+	var lines []string
+	for i := 0; i < 300; i++ {
+		var transaction []string
+		for _, item := range items {
+			if random.Float64() < 0.4 {
+				transaction = append(transaction, item)
+			}
+		}
+		if len(transaction) > 0 {
+			transactions = append(transactions, transaction)
+			lines = append(lines, strings.Join(transaction, ","))
+		}
+	}
+	options := NewOptions(0.05, 0, 0, 3)
+	exact := NewApriori(transactions).FrequentItemsets(options)
+
+	// A precise sketch finds the exact counts.
+	records, err := SketchFrequentItemsets(strings.NewReader(strings.Join(lines, "\n")), 0.0001, 0.01, options)
+	assert(err == nil, "Expected the sketch to succeed")
+	assert(len(records) == len(exact), fmt.Sprintf("Expected %d itemsets, got %d", len(exact), len(records)))
+	for i := range records {
+		assert(formatSupportRecord(records[i]) == formatSupportRecord(exact[i]), "Unexpected itemset: "+formatSupportRecord(records[i])+" expected "+formatSupportRecord(exact[i]))
+	}
+
+	// A coarse one overestimates, but finds every frequent itemset.
+	records, _ = SketchFrequentItemsets(strings.NewReader(strings.Join(lines, "\n")), 0.2, 0.1, options)
+	estimates := make(map[string]int64)
+	for _, record := range records {
+		estimates[ItemsetKey(record.GetItems())] = record.GetSupportCount()
+	}
+	for _, record := range exact {
+		estimate, ok := estimates[ItemsetKey(record.GetItems())]
+		assert(ok && estimate >= record.GetSupportCount(), fmt.Sprintf("Expected an overestimate of %v", record.GetItems()))
+	}
+
+	_, err = SketchFrequentItemsets(strings.NewReader(""), 0.01, 0.01, NewOptions(0.05, 0, 0, 0))
+	assert(err != nil, "Expected an error without a maximum length")
+}
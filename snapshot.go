@@ -0,0 +1,165 @@
+package apriori
+
+import (
+	"bufio"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+)
+
+// Magic number and version of the snapshots written by Save.
+const (
+	snapshotMagic   = "APRI"
+	snapshotVersion = 1
+)
+
+// Save writes a snapshot of the index, so that it can be loaded back with LoadApriori instead of being rebuilt from
+// the transactions, e.g. on every restart of a service. The format is versioned and little-endian: the magic number
+// "APRI", the version (uint32), the number of transactions (int64), the number of items (uint64), whether the
+// transactions are weighted (uint8) followed by their weights (float64) if so, then for every item its length
+// (uint32), its bytes, the number of transactions containing it (uint64) and their sorted indexes (int64). The
+// structs backed by a TransactionStore are snapshotted from the store, the quantities and the item normalizer
+// aren't saved.
+func (a *Apriori) Save(w io.Writer) error {
+	if len(a.quantities) > 0 {
+		return errors.New("the quantities of the items can't be saved")
+	}
+	writer := bufio.NewWriter(w)
+	write := func(data interface{}) error {
+		return binary.Write(writer, binary.LittleEndian, data)
+	}
+
+	if _, err := writer.WriteString(snapshotMagic); err != nil {
+		return err
+	}
+	if err := write(uint32(snapshotVersion)); err != nil {
+		return err
+	}
+	if err := write(a.transactionNo); err != nil {
+		return err
+	}
+	if err := write(uint64(len(a.items))); err != nil {
+		return err
+	}
+	if a.weights == nil {
+		if err := write(uint8(0)); err != nil {
+			return err
+		}
+	} else {
+		if err := write(uint8(1)); err != nil {
+			return err
+		}
+		if err := write(a.weights); err != nil {
+			return err
+		}
+	}
+
+	for _, item := range a.items {
+		indexes := a.transactionIndexMap[item]
+		if a.store != nil {
+			var err error
+			if indexes, err = a.store.ItemIndexes(item); err != nil {
+				return err
+			}
+		}
+		if err := write(uint32(len(item))); err != nil {
+			return err
+		}
+		if _, err := writer.WriteString(item); err != nil {
+			return err
+		}
+		if err := write(uint64(len(indexes))); err != nil {
+			return err
+		}
+		if err := write(indexes); err != nil {
+			return err
+		}
+	}
+
+	return writer.Flush()
+}
+
+// LoadApriori reads a snapshot written by Save. The snapshots of other versions, and the corrupted ones, are
+// rejected with an error.
+func LoadApriori(r io.Reader) (*Apriori, error) {
+	reader := bufio.NewReader(r)
+	read := func(data interface{}) error {
+		err := binary.Read(reader, binary.LittleEndian, data)
+		if err == io.EOF {
+			return io.ErrUnexpectedEOF
+		}
+		return err
+	}
+
+	magic := make([]byte, len(snapshotMagic))
+	if _, err := io.ReadFull(reader, magic); err != nil || string(magic) != snapshotMagic {
+		return nil, errors.New("not an Apriori snapshot")
+	}
+	var version uint32
+	if err := read(&version); err != nil {
+		return nil, err
+	}
+	if version != snapshotVersion {
+		return nil, fmt.Errorf("unsupported snapshot version %d", version)
+	}
+
+	a := &Apriori{transactionIndexMap: make(map[interface{}][]int64)}
+	var itemNo uint64
+	var weighted uint8
+	if err := read(&a.transactionNo); err != nil {
+		return nil, err
+	}
+	if err := read(&itemNo); err != nil {
+		return nil, err
+	}
+	if err := read(&weighted); err != nil {
+		return nil, err
+	}
+	if a.transactionNo < 0 || weighted > 1 {
+		return nil, errors.New("corrupted snapshot header")
+	}
+	if weighted == 1 {
+		a.weights = make([]float64, a.transactionNo)
+		if err := read(a.weights); err != nil {
+			return nil, err
+		}
+		for _, weight := range a.weights {
+			a.totalWeight += weight
+		}
+	}
+
+	for i := uint64(0); i < itemNo; i++ {
+		var length uint32
+		if err := read(&length); err != nil {
+			return nil, err
+		}
+		item := make([]byte, length)
+		if _, err := io.ReadFull(reader, item); err != nil {
+			return nil, io.ErrUnexpectedEOF
+		}
+		var indexNo uint64
+		if err := read(&indexNo); err != nil {
+			return nil, err
+		}
+		if indexNo > uint64(a.transactionNo) {
+			return nil, fmt.Errorf("corrupted snapshot: item %q is in more transactions than there are", item)
+		}
+		indexes := make([]int64, indexNo)
+		if err := read(indexes); err != nil {
+			return nil, err
+		}
+		for j, index := range indexes {
+			if index < 0 || index >= a.transactionNo || (j > 0 && index < indexes[j-1]) {
+				return nil, fmt.Errorf("corrupted snapshot: invalid transaction indexes of item %q", item)
+			}
+		}
+		if _, ok := a.transactionIndexMap[string(item)]; ok {
+			return nil, fmt.Errorf("corrupted snapshot: duplicate item %q", item)
+		}
+		a.addItem(string(item))
+		a.transactionIndexMap[string(item)] = indexes
+	}
+
+	return a, nil
+}
@@ -0,0 +1,57 @@
+package apriori
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestApriori_SaveLoad(t *testing.T) {
+	transactions := [][]string{
+		{"beer", "nuts", "cheese"},
+		{"beer", "nuts", "jam"},
+		{"beer", "jam"},
+		{"nuts", "cheese"},
+	}
+	a := NewApriori(transactions)
+	options := NewOptions(0.25, 0, 0, 0)
+
+	var buffer bytes.Buffer
+	assert(a.Save(&buffer) == nil, "Expected the snapshot to be written")
+	snapshot := buffer.Bytes()
+	loaded, err := LoadApriori(bytes.NewReader(snapshot))
+	assert(err == nil, "Expected the snapshot to be loaded")
+	assert(loaded.TransactionCount() == 4, "Expected 4 transactions")
+	assert(formatRecords(loaded.Calculate(options)) == formatRecords(a.Calculate(options)), "Expected the same results")
+
+	// The loaded index can grow.
+	loaded.AddTransaction([]string{"beer", "wine"})
+	assert(loaded.ItemFrequency("beer") == 4 && loaded.ItemFrequency("wine") == 1, "Expected the added transaction")
+
+	// The weights are kept.
+	a.weights = []float64{1, 2, 1, 1}
+	a.totalWeight = 5
+	buffer.Reset()
+	assert(a.Save(&buffer) == nil, "Expected the snapshot to be written")
+	loaded, err = LoadApriori(&buffer)
+	assert(err == nil, "Expected the snapshot to be loaded")
+	assert(formatRecords(loaded.Calculate(options)) == formatRecords(a.Calculate(options)), "Expected the same weighted results")
+
+	// The structs backed by a store are snapshotted from it.
+	store := NewMemoryTransactionStore()
+	for _, transaction := range transactions {
+		store.AddTransaction(transaction)
+	}
+	stored, _ := NewAprioriFromStore(store)
+	buffer.Reset()
+	assert(stored.Save(&buffer) == nil, "Expected the snapshot to be written")
+	assert(bytes.Equal(buffer.Bytes(), snapshot), "Expected the same snapshot as from memory")
+
+	_, err = LoadApriori(bytes.NewReader([]byte("not a snapshot")))
+	assert(err != nil, "Expected an error for a wrong magic number")
+	_, err = LoadApriori(bytes.NewReader(snapshot[:len(snapshot)-3]))
+	assert(err != nil, "Expected an error for a truncated snapshot")
+	future := append([]byte(nil), snapshot...)
+	future[4] = 2
+	_, err = LoadApriori(bytes.NewReader(future))
+	assert(err != nil && err.Error() == "unsupported snapshot version 2", "Expected an error for another version")
+}
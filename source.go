@@ -0,0 +1,86 @@
+package apriori
+
+import (
+	"context"
+	"errors"
+	"io"
+)
+
+// TransactionSource is a stream of transactions, e.g. a message queue consumer. The transactions are pulled, so
+// the source is only read as fast as they are mined, which gives backpressure to the producers.
+type TransactionSource interface {
+	// Next blocks until a transaction is available and returns it, or io.EOF once the source is exhausted
+	Next(ctx context.Context) ([]string, error)
+	// Commit acknowledges the transactions returned so far, e.g. by committing the offsets of a consumer
+	Commit(ctx context.Context) error
+}
+
+// Consume adds the transactions of the source to the window until the source is exhausted or the context is
+// done. Every checkpointEvery transactions, and once the source is exhausted, the checkpoint function is called,
+// e.g. to mine or persist the results, and only then the source is committed, so a transaction is acknowledged
+// after it was checkpointed. With checkpointEvery 0 the checkpoint happens only at the end; checkpoint may be nil.
+func (s *StreamMiner) Consume(ctx context.Context, source TransactionSource, checkpointEvery int, checkpoint func() error) error {
+	if checkpointEvery < 0 {
+		return errors.New("checkpoint interval must be >= 0")
+	}
+
+	uncommitted := 0
+	commit := func() error {
+		if checkpoint != nil {
+			if err := checkpoint(); err != nil {
+				return err
+			}
+		}
+		uncommitted = 0
+		return source.Commit(ctx)
+	}
+
+	for {
+		transaction, err := source.Next(ctx)
+		if err == io.EOF {
+			if uncommitted > 0 {
+				return commit()
+			}
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		s.Add(transaction)
+		uncommitted++
+		if checkpointEvery > 0 && uncommitted == checkpointEvery {
+			if err := commit(); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// ChannelSource is a TransactionSource reading from a channel, a buffered channel bounding how far the producers
+// can get ahead of the miner. Closing the channel exhausts the source.
+type ChannelSource struct {
+	transactions <-chan []string
+}
+
+// NewChannelSource creates a ChannelSource reading from the given channel
+func NewChannelSource(transactions <-chan []string) *ChannelSource {
+	return &ChannelSource{transactions: transactions}
+}
+
+// Next returns the next transaction of the channel, io.EOF once it is closed
+func (cs *ChannelSource) Next(ctx context.Context) ([]string, error) {
+	select {
+	case transaction, ok := <-cs.transactions:
+		if !ok {
+			return nil, io.EOF
+		}
+		return transaction, nil
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+// Commit does nothing, a channel has nothing to acknowledge
+func (cs *ChannelSource) Commit(ctx context.Context) error {
+	return nil
+}
@@ -0,0 +1,50 @@
+package apriori
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+)
+
+type countingSource struct {
+	*ChannelSource
+	commits int
+}
+
+func (cs *countingSource) Commit(ctx context.Context) error {
+	cs.commits++
+	return nil
+}
+
+func TestStreamMiner_Consume(t *testing.T) {
+	transactions := make(chan []string, 5)
+	for i := 0; i < 5; i++ {
+		transactions <- []string{"beer", fmt.Sprint(i)}
+	}
+	close(transactions)
+	source := &countingSource{ChannelSource: NewChannelSource(transactions)}
+
+	s, _ := NewStreamMiner(10)
+	var checkpoints []int64
+	err := s.Consume(context.Background(), source, 2, func() error {
+		checkpoints = append(checkpoints, s.SeenCount())
+		return nil
+	})
+	assert(err == nil, "Expected the source to be consumed")
+	assert(s.TransactionCount() == 5, "Expected all the transactions to be added")
+	result := fmt.Sprint(checkpoints)
+	assert(result == "[2 4 5]", "Unexpected checkpoints: "+result)
+	assert(source.commits == 3, "Expected a commit after every checkpoint")
+
+	transactions = make(chan []string, 1)
+	transactions <- []string{"beer"}
+	source = &countingSource{ChannelSource: NewChannelSource(transactions)}
+	err = s.Consume(context.Background(), source, 1, func() error { return errors.New("full disk") })
+	assert(err != nil && source.commits == 0, "Expected a failed checkpoint not to be committed")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	err = s.Consume(ctx, NewChannelSource(make(chan []string)), 0, nil)
+	assert(err == context.Canceled, "Expected the consumption to stop with the context")
+}
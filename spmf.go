@@ -0,0 +1,221 @@
+package apriori
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+const (
+	spmfItemPrefix      = "@ITEM="
+	spmfRuleArrow       = "==>"
+	spmfSupportField    = "SUP:"
+	spmfConfidenceField = "CONF:"
+	spmfLiftField       = "LIFT:"
+)
+
+// ReadSPMF reads transactions in the SPMF format: a transaction per line, as space separated integer item ids.
+// The "@ITEM=id=name" lines of the files converted from text map the ids back to the item names, the other
+// metadata and comment lines, starting with @, # or %, are skipped.
+func ReadSPMF(r io.Reader) ([][]string, error) {
+	r, err := Decompress(r)
+	if err != nil {
+		return nil, err
+	}
+	scanner := newLineScanner(r)
+	names := make(map[string]string)
+	var transactions [][]string
+	lineNo := 0
+	for scanner.Scan() {
+		lineNo++
+		line := strings.TrimSpace(scanner.Text())
+		if strings.HasPrefix(line, spmfItemPrefix) {
+			parts := strings.SplitN(strings.TrimPrefix(line, spmfItemPrefix), "=", 2)
+			if len(parts) != 2 {
+				return nil, fmt.Errorf("line %d: invalid item definition %q", lineNo, line)
+			}
+			names[parts[0]] = parts[1]
+			continue
+		}
+		if line == "" || strings.ContainsAny(line[:1], "@#%") {
+			continue
+		}
+
+		var transaction []string
+		for _, id := range strings.Fields(line) {
+			if _, err := strconv.Atoi(id); err != nil {
+				return nil, fmt.Errorf("line %d: item %q is not an integer", lineNo, id)
+			}
+			transaction = append(transaction, id)
+		}
+		transactions = append(transactions, transaction)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	if len(names) > 0 {
+		for _, transaction := range transactions {
+			for i, id := range transaction {
+				if name, ok := names[id]; ok {
+					transaction[i] = name
+				}
+			}
+		}
+	}
+
+	return transactions, nil
+}
+
+// WriteSPMF writes transactions in the SPMF format. The items are given integer ids in the order they first
+// appear, written as "@ITEM=id=name" lines, and every transaction is written as its sorted, unique item ids.
+func WriteSPMF(w io.Writer, transactions [][]string) error {
+	writer := bufio.NewWriter(w)
+	ids := make(map[string]int)
+	var items []string
+	for _, transaction := range transactions {
+		for _, item := range transaction {
+			if _, ok := ids[item]; !ok {
+				items = append(items, item)
+				ids[item] = len(items)
+			}
+		}
+	}
+
+	fmt.Fprintln(writer, "@CONVERTED_FROM_TEXT")
+	for _, item := range items {
+		fmt.Fprintf(writer, "%s%d=%s\n", spmfItemPrefix, ids[item], item)
+	}
+	for _, transaction := range transactions {
+		seen := make(map[int]bool, len(transaction))
+		var transactionIDs []int
+		for _, item := range transaction {
+			if !seen[ids[item]] {
+				seen[ids[item]] = true
+				transactionIDs = append(transactionIDs, ids[item])
+			}
+		}
+		sort.Ints(transactionIDs)
+		formatted := make([]string, len(transactionIDs))
+		for i, id := range transactionIDs {
+			formatted[i] = strconv.Itoa(id)
+		}
+		fmt.Fprintln(writer, strings.Join(formatted, " "))
+	}
+
+	return writer.Flush()
+}
+
+// WriteSPMFRules writes the rules in the SPMF output format, a rule per line, e.g.
+// "beer ==> nuts #SUP: 3 #CONF: 0.75 #LIFT: 1.2", the support being the number of transactions. The statistics of
+// the single items, with an empty base, aren't rules and are skipped. The items must not contain whitespace.
+func WriteSPMFRules(w io.Writer, records []RelationRecord) error {
+	writer := bufio.NewWriter(w)
+	for _, record := range records {
+		for _, orderedStatistic := range record.orderedStatistic {
+			if len(orderedStatistic.base) == 0 {
+				continue
+			}
+			base, err := formatSPMFItems(orderedStatistic.base)
+			if err != nil {
+				return err
+			}
+			add, err := formatSPMFItems(orderedStatistic.add)
+			if err != nil {
+				return err
+			}
+			fmt.Fprintf(writer, "%s %s %s #%s %d #%s %s #%s %s\n", base, spmfRuleArrow, add,
+				spmfSupportField, record.supportRecord.supportCount,
+				spmfConfidenceField, strconv.FormatFloat(orderedStatistic.confidence, 'g', -1, 64),
+				spmfLiftField, strconv.FormatFloat(orderedStatistic.lift, 'g', -1, 64))
+		}
+	}
+
+	return writer.Flush()
+}
+
+// ReadSPMFRules reads rules in the SPMF output format, e.g. to compare them with DiffRuleSets. The supports are
+// derived from the support counts and the number of transactions of the mined dataset, the rules of the same
+// itemset are grouped in a record and the lift is NaN when it isn't given.
+func ReadSPMFRules(r io.Reader, transactionNo int64) ([]RelationRecord, error) {
+	if transactionNo < 1 {
+		return nil, errors.New("transaction number must be at least 1")
+	}
+
+	var a Apriori
+	r, err := Decompress(r)
+	if err != nil {
+		return nil, err
+	}
+	scanner := newLineScanner(r)
+	recordIndexes := make(map[string]int)
+	var records []RelationRecord
+	lineNo := 0
+	for scanner.Scan() {
+		lineNo++
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+
+		fields := strings.Split(line, "#")
+		rule := strings.SplitN(fields[0], spmfRuleArrow, 2)
+		if len(rule) != 2 {
+			return nil, fmt.Errorf("line %d: %q is not a rule", lineNo, line)
+		}
+		base, add := strings.Fields(rule[0]), strings.Fields(rule[1])
+		count, confidence, lift := int64(-1), math.NaN(), math.NaN()
+		for _, field := range fields[1:] {
+			field = strings.TrimSpace(field)
+			var err error
+			switch {
+			case strings.HasPrefix(field, spmfSupportField):
+				count, err = strconv.ParseInt(strings.TrimSpace(strings.TrimPrefix(field, spmfSupportField)), 10, 64)
+			case strings.HasPrefix(field, spmfConfidenceField):
+				confidence, err = strconv.ParseFloat(strings.TrimSpace(strings.TrimPrefix(field, spmfConfidenceField)), 64)
+			case strings.HasPrefix(field, spmfLiftField):
+				lift, err = strconv.ParseFloat(strings.TrimSpace(strings.TrimPrefix(field, spmfLiftField)), 64)
+			}
+			if err != nil {
+				return nil, fmt.Errorf("line %d: %w", lineNo, err)
+			}
+		}
+		if len(base) == 0 || len(add) == 0 || count < 0 || math.IsNaN(confidence) {
+			return nil, fmt.Errorf("line %d: the rule needs a base, an add, a support and a confidence", lineNo)
+		}
+
+		orderedStatistic := NewOrderedStatistic(base, add, confidence, lift)
+		orderedStatistic.supportCount = count
+		items := a.normalizeItems(append(append([]string{}, base...), add...))
+		key := itemsetKey(items)
+		if i, ok := recordIndexes[key]; ok {
+			records[i].orderedStatistic = append(records[i].orderedStatistic, orderedStatistic)
+			continue
+		}
+		recordIndexes[key] = len(records)
+		supportRecord := NewSupportRecord(items, float64(count)/float64(transactionNo))
+		supportRecord.supportCount = count
+		records = append(records, NewRelationRecord(supportRecord, []OrderedStatistic{orderedStatistic}))
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	return records, nil
+}
+
+// Returns the items separated by spaces, an error when an item contains whitespace.
+func formatSPMFItems(items []string) (string, error) {
+	for _, item := range items {
+		if item == "" || strings.ContainsAny(item, " \t\r\n") {
+			return "", fmt.Errorf("item %q can't be written in the SPMF format", item)
+		}
+	}
+
+	return strings.Join(items, " "), nil
+}
@@ -0,0 +1,74 @@
+package apriori
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestReadSPMF(t *testing.T) {
+	transactions, err := ReadSPMF(strings.NewReader("# contextPasquier99\n1 3 4\n2 3 5\n\n1 2 3 5\n"))
+	assert(err == nil, "Expected the transactions to be read")
+	result := fmt.Sprint(transactions)
+	assert(result == "[[1 3 4] [2 3 5] [1 2 3 5]]", "Unexpected transactions: "+result)
+
+	// A wide basket, longer than the default buffer of a scanner.
+	var wide []string
+	for item := 0; item < 30000; item++ {
+		wide = append(wide, fmt.Sprint(item))
+	}
+	transactions, err = ReadSPMF(strings.NewReader(strings.Join(wide, " ") + "\n1 2\n"))
+	assert(err == nil && len(transactions) == 2 && len(transactions[0]) == len(wide), fmt.Sprint("Expected the long line to be read ", err))
+
+	_, err = ReadSPMF(strings.NewReader("1 beer\n"))
+	assert(err != nil, "Expected an error for an item that isn't an integer")
+}
+
+func TestWriteSPMF(t *testing.T) {
+	transactions := [][]string{{"beer", "nuts", "beer"}, {"jam", "beer"}}
+	var buffer bytes.Buffer
+	assert(WriteSPMF(&buffer, transactions) == nil, "Expected the transactions to be written")
+	expected := "@CONVERTED_FROM_TEXT\n@ITEM=1=beer\n@ITEM=2=nuts\n@ITEM=3=jam\n1 2\n1 3\n"
+	assert(buffer.String() == expected, "Unexpected SPMF file: "+buffer.String())
+
+	read, _ := ReadSPMF(&buffer)
+	result := fmt.Sprint(read)
+	assert(result == "[[beer nuts] [beer jam]]", "Expected the item names to be read back: "+result)
+}
+
+func TestSPMFRules(t *testing.T) {
+	transactions := [][]string{
+		{"beer", "nuts", "cheese"},
+		{"beer", "nuts", "jam"},
+		{"beer", "butter"},
+		{"nuts", "cheese"},
+	}
+	records := NewApriori(transactions).Calculate(NewOptions(0.5, 0.5, 0, 0))
+
+	var buffer bytes.Buffer
+	assert(WriteSPMFRules(&buffer, records) == nil, "Expected the rules to be written")
+	assert(strings.HasPrefix(buffer.String(), "beer ==> nuts #SUP: 2 #CONF: 0.6666666666666666 #LIFT: 0.8888888888888888\n"), "Unexpected SPMF rules: "+buffer.String())
+
+	read, err := ReadSPMFRules(&buffer, 4)
+	assert(err == nil, "Expected the rules to be read")
+	var rules []RelationRecord
+	for _, record := range records {
+		if len(record.GetSupportRecord().GetItems()) > 1 {
+			rules = append(rules, record)
+		}
+	}
+	diff := DiffRuleSets(rules, read)
+	assert(len(diff.GetAdded()) == 0 && len(diff.GetRemoved()) == 0, "Expected the same rules to be read back")
+	for _, change := range diff.GetChanged() {
+		assert(change.GetConfidenceDelta() == 0 && change.GetSupportDelta() == 0, "Expected the same statistics to be read back")
+	}
+
+	read, err = ReadSPMFRules(strings.NewReader("1 ==> 3 #SUP: 2 #CONF: 0.5\n"), 4)
+	assert(err == nil, "Expected a rule without lift to be read")
+	assert(formatRecords(read) == "[{{[1 3] 0.5} [{[1] [3] 0.5 NaN}]}]", "Unexpected rules: "+formatRecords(read))
+
+	_, err = ReadSPMFRules(strings.NewReader("1 3 #SUP: 2\n"), 4)
+	assert(err != nil, "Expected an error for an itemset line")
+	assert(WriteSPMFRules(&buffer, NewApriori([][]string{{"ice cream", "cone"}}).Calculate(NewOptions(1, 1, 0, 0))) != nil, "Expected an error for an item with a space")
+}
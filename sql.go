@@ -0,0 +1,99 @@
+package apriori
+
+import (
+	"database/sql"
+	"fmt"
+	"sort"
+)
+
+// NewAprioriFromSQL builds an Apriori struct from the long format rows (e.g. order_id, item) returned by the query,
+// streaming them into the index instead of materializing the transactions first. The rows of a transaction don't
+// need to be consecutive; the transactions are indexed in the order their first row was read, and rows with a
+// NULL item only count the transaction.
+func NewAprioriFromSQL(db *sql.DB, query string, groupCol, itemCol string, args ...interface{}) (*Apriori, error) {
+	rows, err := db.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	columns, err := rows.Columns()
+	if err != nil {
+		return nil, err
+	}
+	groupIndex, itemIndex := -1, -1
+	for i, column := range columns {
+		switch column {
+		case groupCol:
+			groupIndex = i
+		case itemCol:
+			itemIndex = i
+		}
+	}
+	if groupIndex == -1 || itemIndex == -1 {
+		return nil, fmt.Errorf("the query must return the %q and %q columns", groupCol, itemCol)
+	}
+
+	var group, item sql.NullString
+	dest := make([]interface{}, len(columns))
+	for i := range dest {
+		dest[i] = new(sql.RawBytes)
+	}
+	dest[groupIndex], dest[itemIndex] = &group, &item
+
+	index := newLongFormatIndex()
+	for rows.Next() {
+		if err := rows.Scan(dest...); err != nil {
+			return nil, err
+		}
+		index.add(group.String, item.String, item.Valid)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return index.apriori(), nil
+}
+
+// Builds an Apriori struct from long format rows, see NewAprioriFromSQL.
+type longFormatIndex struct {
+	a            *Apriori
+	transactions map[string]int64
+}
+
+func newLongFormatIndex() *longFormatIndex {
+	return &longFormatIndex{a: NewApriori(nil), transactions: make(map[string]int64)}
+}
+
+// Adds the item to the transaction of the group, a row without item only counts the transaction.
+func (l *longFormatIndex) add(group string, item string, hasItem bool) {
+	index, ok := l.transactions[group]
+	if !ok {
+		index = l.a.transactionNo
+		l.transactions[group] = index
+		l.a.transactionNo++
+	}
+	if !hasItem {
+		return
+	}
+	if _, ok := l.a.transactionIndexMap[item]; !ok {
+		l.a.addItem(item)
+	}
+	l.a.transactionIndexMap[item] = append(l.a.transactionIndexMap[item], index)
+}
+
+func (l *longFormatIndex) apriori() *Apriori {
+	// The rows of a transaction may be spread, so the index lists are sorted and the repeated rows dropped.
+	for item, indexes := range l.a.transactionIndexMap {
+		sort.Slice(indexes, func(i, j int) bool { return indexes[i] < indexes[j] })
+		unique := indexes[:0]
+		for i, index := range indexes {
+			if i == 0 || index != indexes[i-1] {
+				unique = append(unique, index)
+			}
+		}
+		l.a.transactionIndexMap[item] = unique
+	}
+
+	return l.a
+}
@@ -0,0 +1,71 @@
+package apriori
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"io"
+	"testing"
+)
+
+// Driver returning fixed rows for any query, enough to exercise NewAprioriFromSQL without a database.
+type fakeDriver struct {
+	columns []string
+	rows    [][]driver.Value
+}
+
+func (d fakeDriver) Open(string) (driver.Conn, error) { return fakeConn{d}, nil }
+
+type fakeConn struct{ driver fakeDriver }
+
+func (c fakeConn) Prepare(string) (driver.Stmt, error) { return fakeStmt{c.driver}, nil }
+func (c fakeConn) Close() error                        { return nil }
+func (c fakeConn) Begin() (driver.Tx, error)           { return nil, driver.ErrSkip }
+
+type fakeStmt struct{ driver fakeDriver }
+
+func (s fakeStmt) Close() error                               { return nil }
+func (s fakeStmt) NumInput() int                              { return -1 }
+func (s fakeStmt) Exec([]driver.Value) (driver.Result, error) { return nil, driver.ErrSkip }
+func (s fakeStmt) Query([]driver.Value) (driver.Rows, error) {
+	return &fakeRows{columns: s.driver.columns, rows: s.driver.rows}, nil
+}
+
+type fakeRows struct {
+	columns []string
+	rows    [][]driver.Value
+}
+
+func (r *fakeRows) Columns() []string { return r.columns }
+func (r *fakeRows) Close() error      { return nil }
+func (r *fakeRows) Next(dest []driver.Value) error {
+	if len(r.rows) == 0 {
+		return io.EOF
+	}
+	copy(dest, r.rows[0])
+	r.rows = r.rows[1:]
+	return nil
+}
+
+func TestNewAprioriFromSQL(t *testing.T) {
+	sql.Register("apriori-fake", fakeDriver{
+		columns: []string{"order_id", "item", "price"},
+		rows: [][]driver.Value{
+			{int64(1), "beer", 1.5},
+			{int64(2), "beer", 1.5},
+			{int64(1), "nuts", 2.0},
+			{int64(3), nil, nil},
+			{int64(2), "beer", 1.5},
+		},
+	})
+	db, err := sql.Open("apriori-fake", "")
+	assert(err == nil, "Expected the fake database to be opened")
+	defer db.Close()
+
+	a, err := NewAprioriFromSQL(db, "SELECT order_id, item, price FROM order_items", "order_id", "item")
+	assert(err == nil, "Expected the apriori struct to be built")
+	assert(a.TransactionCount() == 3, "Expected a transaction per order")
+	assert(a.ItemFrequency("beer") == 2 && a.ItemFrequency("nuts") == 1, "Unexpected item frequencies")
+
+	_, err = NewAprioriFromSQL(db, "SELECT order_id, item, price FROM order_items", "order_id", "sku")
+	assert(err != nil, "Expected an error for a missing column")
+}
@@ -0,0 +1,66 @@
+package apriori
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+)
+
+// SQLOptions are the options of ExportSQL
+type SQLOptions struct {
+	HasItem string // Predicate of a basket containing an item, %s being the quoted item, "%s = ANY(items)" when empty.
+	Alias   string // Alias of the expression, "recommendation" when empty.
+}
+
+// ExportSQL writes the rules as a SQL CASE expression recommending the add of the first rule whose base the basket
+// contains, e.g. "WHEN 'a' = ANY(items) AND 'b' = ANY(items) THEN 'c'", so the rules can be deployed in SQL
+// pipelines: SELECT order_id, <expression> FROM baskets. The rules are ordered by confidence, then lift, so the
+// most confident rule wins, and the items of an add are joined with ", ". The HasItem predicate adapts it to the
+// schema and dialect, e.g. "array_contains(items, %s)". The statistics of the single items aren't rules and are
+// skipped.
+func ExportSQL(records []RelationRecord, w io.Writer, opts SQLOptions) error {
+	hasItem := opts.HasItem
+	if hasItem == "" {
+		hasItem = "%s = ANY(items)"
+	}
+	alias := opts.Alias
+	if alias == "" {
+		alias = "recommendation"
+	}
+
+	var rules []OrderedStatistic
+	for _, record := range records {
+		for _, orderedStatistic := range record.orderedStatistic {
+			if len(orderedStatistic.base) > 0 {
+				rules = append(rules, orderedStatistic)
+			}
+		}
+	}
+	sort.SliceStable(rules, func(i, j int) bool {
+		if rules[i].confidence != rules[j].confidence {
+			return rules[i].confidence > rules[j].confidence
+		}
+		return rules[i].lift > rules[j].lift
+	})
+
+	writer := bufio.NewWriter(w)
+	fmt.Fprintln(writer, "CASE")
+	for _, rule := range rules {
+		conditions := make([]string, len(rule.base))
+		for i, item := range rule.base {
+			conditions[i] = fmt.Sprintf(hasItem, sqlQuote(item))
+		}
+		fmt.Fprintf(writer, "    WHEN %s THEN %s -- confidence %.3g, lift %.3g\n", strings.Join(conditions, " AND "),
+			sqlQuote(strings.Join(rule.add, ", ")), rule.confidence, rule.lift)
+	}
+	fmt.Fprintf(writer, "    ELSE NULL\nEND AS %s\n", alias)
+
+	return writer.Flush()
+}
+
+// Returns the SQL string literal of the value.
+func sqlQuote(value string) string {
+	return "'" + strings.ReplaceAll(value, "'", "''") + "'"
+}
@@ -0,0 +1,35 @@
+package apriori
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestExportSQL(t *testing.T) {
+	records := []RelationRecord{
+		NewRelationRecord(NewSupportRecord([]string{"beer", "nuts"}, 0.5), []OrderedStatistic{
+			NewOrderedStatistic(nil, []string{"beer"}, 0.5, 1),
+			NewOrderedStatistic([]string{"beer"}, []string{"nuts"}, 0.6, 1.2),
+		}),
+		NewRelationRecord(NewSupportRecord([]string{"beer", "jam", "kid's snack"}, 0.25), []OrderedStatistic{
+			NewOrderedStatistic([]string{"beer", "kid's snack"}, []string{"jam"}, 0.9, 2),
+		}),
+	}
+
+	var buffer bytes.Buffer
+	err := ExportSQL(records, &buffer, SQLOptions{})
+	expected := "CASE\n" +
+		"    WHEN 'beer' = ANY(items) AND 'kid''s snack' = ANY(items) THEN 'jam' -- confidence 0.9, lift 2\n" +
+		"    WHEN 'beer' = ANY(items) THEN 'nuts' -- confidence 0.6, lift 1.2\n" +
+		"    ELSE NULL\n" +
+		"END AS recommendation\n"
+	assert(err == nil && buffer.String() == expected, "Unexpected SQL: "+buffer.String())
+
+	buffer.Reset()
+	_ = ExportSQL(records[:1], &buffer, SQLOptions{HasItem: "array_contains(basket, %s)", Alias: "next_item"})
+	expected = "CASE\n" +
+		"    WHEN array_contains(basket, 'beer') THEN 'nuts' -- confidence 0.6, lift 1.2\n" +
+		"    ELSE NULL\n" +
+		"END AS next_item\n"
+	assert(buffer.String() == expected, "Unexpected SQL: "+buffer.String())
+}
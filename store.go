@@ -0,0 +1,125 @@
+package apriori
+
+import "sync"
+
+// TransactionStore keeps the vertical index of the transactions, the indexes of the transactions containing each
+// item, so that it can live outside of memory, e.g. on disk. The transactions are indexed from 0 in the order they
+// were added and the indexes of an item must be returned sorted.
+type TransactionStore interface {
+	// AddTransaction appends a transaction to the store
+	AddTransaction(transaction []string) error
+	// TransactionCount returns the number of transactions in the store
+	TransactionCount() (int64, error)
+	// Items returns the distinct items of the transactions
+	Items() ([]string, error)
+	// ItemIndexes returns the sorted indexes of the transactions containing the item, nil for an unknown item
+	ItemIndexes(item string) ([]int64, error)
+}
+
+// NewAprioriFromStore creates an Apriori struct that reads the index from the store while mining, instead of
+// keeping it in memory. Only the distinct items are loaded. The store errors met while mining are returned by
+// CalculateContext and AddTransactionsBatch, the other methods panic with them like they do with invalid options.
+// Transactions can't be removed from a store.
+func NewAprioriFromStore(store TransactionStore) (*Apriori, error) {
+	transactionNo, err := store.TransactionCount()
+	if err != nil {
+		return nil, err
+	}
+	items, err := store.Items()
+	if err != nil {
+		return nil, err
+	}
+
+	storeItems := make(map[string]struct{}, len(items))
+	for _, item := range items {
+		storeItems[item] = struct{}{}
+	}
+
+	return &Apriori{transactionNo: transactionNo, items: items, store: store, storeItems: storeItems}, nil
+}
+
+// storeFailure records the first error of the store met by a mining run, and stops the run like a done context.
+type storeFailure struct {
+	mu     sync.Mutex
+	err    error
+	cancel func()
+}
+
+// Records the error of the store, only the first one is kept.
+func (f *storeFailure) fail(err error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if f.err == nil {
+		f.err = err
+		f.cancel()
+	}
+}
+
+// Returns the error of the store, nil when there was none or when the failures aren't recorded.
+func (f *storeFailure) error() error {
+	if f == nil {
+		return nil
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	return f.err
+}
+
+// MemoryTransactionStore is a TransactionStore that keeps the index in memory, a reference for the other
+// implementations. It is safe for concurrent use.
+type MemoryTransactionStore struct {
+	mu            sync.RWMutex
+	transactionNo int64
+	items         []string
+	indexes       map[string][]int64
+}
+
+// NewMemoryTransactionStore creates an empty MemoryTransactionStore
+func NewMemoryTransactionStore() *MemoryTransactionStore {
+	return &MemoryTransactionStore{indexes: make(map[string][]int64)}
+}
+
+// AddTransaction appends a transaction to the store
+func (s *MemoryTransactionStore) AddTransaction(transaction []string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, item := range transaction {
+		indexes, ok := s.indexes[item]
+		if !ok {
+			s.items = append(s.items, item)
+		}
+		if len(indexes) == 0 || indexes[len(indexes)-1] != s.transactionNo {
+			s.indexes[item] = append(indexes, s.transactionNo)
+		}
+	}
+	s.transactionNo++
+
+	return nil
+}
+
+// TransactionCount returns the number of transactions in the store
+func (s *MemoryTransactionStore) TransactionCount() (int64, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return s.transactionNo, nil
+}
+
+// Items returns the distinct items of the transactions
+func (s *MemoryTransactionStore) Items() ([]string, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return append([]string{}, s.items...), nil
+}
+
+// ItemIndexes returns the sorted indexes of the transactions containing the item, nil for an unknown item
+func (s *MemoryTransactionStore) ItemIndexes(item string) ([]int64, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return s.indexes[item], nil
+}
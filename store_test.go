@@ -0,0 +1,71 @@
+package apriori
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+func TestNewAprioriFromStore(t *testing.T) {
+	transactions := [][]string{
+		{"beer", "nuts", "cheese"},
+		{"beer", "nuts", "jam"},
+		{"beer", "butter"},
+		{"nuts", "cheese"},
+	}
+	store := NewMemoryTransactionStore()
+	for _, transaction := range transactions[:3] {
+		assert(store.AddTransaction(transaction) == nil, "Expected the transaction to be stored")
+	}
+
+	a, err := NewAprioriFromStore(store)
+	assert(err == nil, "Expected the apriori struct to be created")
+	a.addTransaction(transactions[3])
+	assert(a.TransactionCount() == 4 && a.ItemFrequency("nuts") == 3, "Expected the store to be used")
+	a.AddTransaction([]string{"wine", "beer", "wine"})
+	assert(len(a.Items()) == 6 && a.Items()[5] == "wine", "Expected the new item to be added once")
+	transactions = append(transactions, []string{"wine", "beer"})
+
+	options := NewOptions(0.25, 0.5, 0, 0)
+	expected := formatRecords(NewApriori(transactions).Calculate(options))
+	assert(formatRecords(a.Calculate(options)) == expected, "Expected the same rules as mining from memory: "+formatRecords(a.Calculate(options)))
+}
+
+// failingStore is a TransactionStore whose reads and writes fail once broken, like a full or unreadable disk.
+type failingStore struct {
+	*MemoryTransactionStore
+	broken bool
+}
+
+func (s *failingStore) AddTransaction(transaction []string) error {
+	if s.broken {
+		return errors.New("disk full")
+	}
+	return s.MemoryTransactionStore.AddTransaction(transaction)
+}
+
+func (s *failingStore) ItemIndexes(item string) ([]int64, error) {
+	if s.broken {
+		return nil, errors.New("read error")
+	}
+	return s.MemoryTransactionStore.ItemIndexes(item)
+}
+
+func TestNewAprioriFromStore_errors(t *testing.T) {
+	store := &failingStore{MemoryTransactionStore: NewMemoryTransactionStore()}
+	a, _ := NewAprioriFromStore(store)
+	assert(a.AddTransactionsBatch([][]string{{"beer", "nuts"}, {"beer"}}, 0) == nil, "Expected the transactions to be stored")
+
+	store.broken = true
+	err := a.AddTransactionsBatch([][]string{{"jam"}}, 0)
+	assert(err != nil && err.Error() == "disk full", "Expected the error of the store to be returned")
+	assert(a.TransactionCount() == 2 && len(a.Items()) == 2, "Expected the failed transaction to be left out")
+
+	_, err = a.CalculateContext(context.Background(), NewOptions(0.5, 0, 0, 0))
+	assert(err != nil && err.Error() == "read error", "Expected the read error of the store to be returned")
+
+	defer func() {
+		assert(recover() != nil, "Expected AddTransaction to panic with the error of the store")
+	}()
+	a.AddTransaction([]string{"jam"})
+}
@@ -0,0 +1,159 @@
+package apriori
+
+import (
+	"context"
+	"errors"
+	"math"
+	"sync"
+	"time"
+)
+
+// StreamMiner keeps the last transactions of a continuous stream in a sliding window and mines them on demand,
+// so the results follow the stream without recomputing over its whole history. With a decay the older
+// transactions of the window matter less. It is safe for concurrent use.
+type StreamMiner struct {
+	mu         sync.Mutex
+	windowSize int
+	index      *Apriori    // Index of the transactions of the window, oldest first, updated as they enter and leave it.
+	times      []time.Time // Times of the transactions of the window, oldest first.
+	snapshot   *Apriori    // Copy of the index handed to the mining runs, nil when the window changed since.
+	seen       int64
+	damping    float64
+	timeUnit   time.Duration
+}
+
+// NewStreamMiner creates a StreamMiner that keeps the last windowSize transactions
+func NewStreamMiner(windowSize int) (*StreamMiner, error) {
+	if windowSize < 1 {
+		return nil, errors.New("window size must be at least 1")
+	}
+
+	return &StreamMiner{windowSize: windowSize, index: NewApriori(nil), times: make([]time.Time, 0, windowSize)}, nil
+}
+
+// NewDecayedStreamMiner creates a StreamMiner whose supports are exponentially decayed: a transaction weighs
+// damping^age, where the age is the number of transactions added after it or, when timeUnit is > 0, the time
+// elapsed between it and the newest transaction in time units. The counts and the significance statistics of
+// the rules are then derived from the decayed supports.
+func NewDecayedStreamMiner(windowSize int, damping float64, timeUnit time.Duration) (*StreamMiner, error) {
+	if damping <= 0 || damping > 1 {
+		return nil, errors.New("damping factor must be > 0 and <= 1")
+	}
+	if timeUnit < 0 {
+		return nil, errors.New("time unit must be >= 0")
+	}
+	s, err := NewStreamMiner(windowSize)
+	if err != nil {
+		return nil, err
+	}
+	s.damping = damping
+	s.timeUnit = timeUnit
+
+	return s, nil
+}
+
+// Add appends transactions to the window, evicting the oldest ones once it is full. The transactions are
+// timestamped with the current time.
+func (s *StreamMiner) Add(transactions ...[]string) {
+	s.AddAt(time.Now(), transactions...)
+}
+
+// AddAt appends transactions that happened at the given time to the window, evicting the oldest ones once it is full
+func (s *StreamMiner) AddAt(at time.Time, transactions ...[]string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	// Only the last windowSize transactions can stay in the window.
+	if len(transactions) > s.windowSize {
+		s.seen += int64(len(transactions) - s.windowSize)
+		transactions = transactions[len(transactions)-s.windowSize:]
+	}
+	if evicted := len(s.times) + len(transactions) - s.windowSize; evicted > 0 {
+		s.index.RemoveTransactions(int64(evicted))
+		s.times = s.times[evicted:]
+	}
+	for _, transaction := range transactions {
+		s.index.AddTransaction(transaction)
+		s.times = append(s.times, at)
+		s.seen++
+	}
+	s.snapshot = nil
+}
+
+// TransactionCount returns the number of transactions currently in the window
+func (s *StreamMiner) TransactionCount() int64 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return int64(len(s.times))
+}
+
+// SeenCount returns the number of transactions added since the StreamMiner was created
+func (s *StreamMiner) SeenCount() int64 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.seen
+}
+
+// Calculate returns the rules of the transactions currently in the window, see Apriori.Calculate
+func (s *StreamMiner) Calculate(options Options) []RelationRecord {
+	return s.mining().Calculate(options)
+}
+
+// CalculateContext returns the rules of the transactions currently in the window, see Apriori.CalculateContext
+func (s *StreamMiner) CalculateContext(ctx context.Context, options Options) ([]RelationRecord, error) {
+	return s.mining().CalculateContext(ctx, options)
+}
+
+// AddTransaction appends a transaction to the window, see Add
+func (s *StreamMiner) AddTransaction(transaction []string) {
+	s.Add(transaction)
+}
+
+// FrequentItemsets returns the frequent itemsets of the transactions currently in the window, see
+// Apriori.FrequentItemsets
+func (s *StreamMiner) FrequentItemsets(options Options) []SupportRecord {
+	return s.mining().FrequentItemsets(options)
+}
+
+// Returns an Apriori struct over the transactions of the window, oldest first, so the transaction indexes of the
+// results follow the order of the stream. The index keeps changing with the stream, so the runs get a copy of it,
+// shared by all of them until the window changes.
+func (s *StreamMiner) mining() *Apriori {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.snapshot != nil {
+		return s.snapshot
+	}
+	a := s.index.Clone()
+	if s.damping > 0 {
+		a.weights = make([]float64, len(s.times))
+		a.totalWeight = 0
+		latest := s.latest(s.times)
+		for i := range s.times {
+			age := float64(len(s.times) - 1 - i)
+			if s.timeUnit > 0 {
+				age = math.Max(float64(latest.Sub(s.times[i]))/float64(s.timeUnit), 0)
+			}
+			a.weights[i] = math.Pow(s.damping, age)
+			a.totalWeight += a.weights[i]
+		}
+	}
+	s.snapshot = a
+
+	return a
+}
+
+// Returns the newest of the times, the transactions may be added out of order.
+func (s *StreamMiner) latest(times []time.Time) time.Time {
+	var latest time.Time
+	for _, t := range times {
+		if t.After(latest) {
+			latest = t
+		}
+	}
+
+	return latest
+}
@@ -0,0 +1,101 @@
+package apriori
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestStreamMiner(t *testing.T) {
+	_, err := NewStreamMiner(0)
+	assert(err != nil, "Expected an error for an empty window")
+
+	s, err := NewStreamMiner(3)
+	assert(err == nil, "Expected the stream miner to be created")
+
+	s.Add([]string{"beer", "nuts"}, []string{"beer", "nuts"})
+	result := fmt.Sprint(s.FrequentItemsets(NewOptions(1, 0, 0, 0))[2].GetItems())
+	assert(result == "[beer nuts]", "Unexpected frequent itemset: "+result)
+
+	s.Add([]string{"jam"}, []string{"jam", "nuts"}, []string{"jam"})
+	assert(s.TransactionCount() == 3 && s.SeenCount() == 5, "Expected the window to keep the last transactions")
+
+	var formatted []string
+	for _, record := range s.FrequentItemsets(NewOptions(0.3, 0, 0, 0)) {
+		formatted = append(formatted, formatSupportRecord(record))
+	}
+	result = fmt.Sprint(formatted)
+	assert(result == "[{[jam] 1 3 1} {[nuts] 0.3333333333333333 1 1} {[jam nuts] 0.3333333333333333 1 0.3333333333333333}]", "Unexpected frequent itemsets: "+result)
+
+	options := NewOptions(0.3, 0, 0, 0)
+	options.KeepTransactionIDs = true
+	result = fmt.Sprint(s.FrequentItemsets(options)[1].GetTransactionIDs())
+	assert(result == "[1]", "Expected the transaction indexes to follow the stream order: "+result)
+}
+
+func TestStreamMiner_incrementalIndex(t *testing.T) {
+	transactions := [][]string{
+		{"beer", "nuts"}, {"beer", "jam"}, {"jam", "nuts", "cheese"}, {"beer", "nuts"},
+		{"cheese"}, {"jam", "nuts"}, {"beer", "cheese", "nuts"}, {"butter"},
+	}
+	s, _ := NewStreamMiner(4)
+	options := NewOptions(0.25, 0.5, 0, 0)
+	seen := 0
+	for _, batch := range [][][]string{transactions[:3], transactions[3:4], transactions[4:6], transactions[6:]} {
+		s.Add(batch...)
+		seen += len(batch)
+		window := transactions[:seen]
+		if len(window) > 4 {
+			window = window[len(window)-4:]
+		}
+		expected := formatRecords(NewApriori(window).Calculate(options))
+		assert(formatRecords(s.Calculate(options)) == expected, "Expected the same rules as mining the window: "+formatRecords(s.Calculate(options)))
+	}
+	assert(s.mining() == s.mining(), "Expected the index to be copied once until the window changes")
+
+	s.Add(transactions...)
+	assert(s.TransactionCount() == 4 && s.SeenCount() == 2*int64(len(transactions)), "Expected a batch larger than the window to keep its last transactions")
+	expected := formatRecords(NewApriori(transactions[4:]).Calculate(options))
+	assert(formatRecords(s.Calculate(options)) == expected, "Expected the rules of the last transactions of the batch")
+
+	// Run with -race: the mining runs read their copy of the index while it is updated.
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(2)
+		go func(transaction []string) {
+			defer wg.Done()
+			s.Add(transaction)
+		}(transactions[i])
+		go func() {
+			defer wg.Done()
+			s.Calculate(options)
+		}()
+	}
+	wg.Wait()
+}
+
+func TestDecayedStreamMiner(t *testing.T) {
+	_, err := NewDecayedStreamMiner(10, 1.5, 0)
+	assert(err != nil, "Expected an error for a damping factor above 1")
+
+	s, _ := NewDecayedStreamMiner(10, 0.5, 0)
+	s.Add([]string{"beer"}, []string{"jam"}, []string{"jam"})
+	var formatted []string
+	for _, record := range s.FrequentItemsets(NewOptions(0.1, 0, 0, 0)) {
+		formatted = append(formatted, fmt.Sprintf("%v:%.4f", record.GetItems(), record.GetSupport()))
+	}
+	result := fmt.Sprint(formatted)
+	assert(result == "[[beer]:0.1429 [jam]:0.8571]", "Unexpected decayed supports per transaction: "+result)
+
+	s, _ = NewDecayedStreamMiner(10, 0.5, time.Hour)
+	now := time.Now()
+	s.AddAt(now.Add(-2*time.Hour), []string{"beer"})
+	s.AddAt(now, []string{"jam"})
+	formatted = nil
+	for _, record := range s.FrequentItemsets(NewOptions(0.1, 0, 0, 0)) {
+		formatted = append(formatted, fmt.Sprintf("%v:%.4f", record.GetItems(), record.GetSupport()))
+	}
+	result = fmt.Sprint(formatted)
+	assert(result == "[[beer]:0.2000 [jam]:0.8000]", "Unexpected decayed supports per time unit: "+result)
+}
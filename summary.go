@@ -0,0 +1,77 @@
+package apriori
+
+import (
+	"math"
+	"sort"
+)
+
+// LevelSummary contains the statistics of the frequent itemsets of a length
+type LevelSummary struct {
+	length     int
+	count      int
+	minSupport float64
+	avgSupport float64
+	maxSupport float64
+}
+
+// GetLength will return the length of the itemsets
+func (ls LevelSummary) GetLength() int {
+	return ls.length
+}
+
+// GetCount will return the number of itemsets of the length
+func (ls LevelSummary) GetCount() int {
+	return ls.count
+}
+
+// GetMinSupport will return the lowest support of the itemsets of the length
+func (ls LevelSummary) GetMinSupport() float64 {
+	return ls.minSupport
+}
+
+// GetAvgSupport will return the average support of the itemsets of the length
+func (ls LevelSummary) GetAvgSupport() float64 {
+	return ls.avgSupport
+}
+
+// GetMaxSupport will return the highest support of the itemsets of the length
+func (ls LevelSummary) GetMaxSupport() float64 {
+	return ls.maxSupport
+}
+
+// SummarizeLevels returns the number of frequent itemsets and their lowest, average and highest support for every
+// length, shortest first, to tune the maximum length and minimum support without dumping every record
+func SummarizeLevels(itemsets []SupportRecord) []LevelSummary {
+	levels := make(map[int]*LevelSummary)
+	for _, itemset := range itemsets {
+		length := len(itemset.items)
+		level, ok := levels[length]
+		if !ok {
+			level = &LevelSummary{length: length, minSupport: math.Inf(1), maxSupport: math.Inf(-1)}
+			levels[length] = level
+		}
+		level.count++
+		level.avgSupport += itemset.support
+		level.minSupport = math.Min(level.minSupport, itemset.support)
+		level.maxSupport = math.Max(level.maxSupport, itemset.support)
+	}
+
+	summaries := make([]LevelSummary, 0, len(levels))
+	for _, level := range levels {
+		level.avgSupport /= float64(level.count)
+		summaries = append(summaries, *level)
+	}
+	sort.Slice(summaries, func(i, j int) bool { return summaries[i].length < summaries[j].length })
+
+	return summaries
+}
+
+// Itemsets returns the support records of the records of rules, e.g. to summarize the itemsets of Calculate
+func Itemsets(records []RelationRecord) []SupportRecord {
+	itemsets := make([]SupportRecord, len(records))
+	for i, record := range records {
+		itemsets[i] = record.supportRecord
+	}
+
+	return itemsets
+}
@@ -0,0 +1,27 @@
+package apriori
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestSummarizeLevels(t *testing.T) {
+	transactions := [][]string{
+		{"beer", "nuts", "cheese"},
+		{"beer", "nuts"},
+		{"beer", "nuts", "jam"},
+		{"beer", "cheese"},
+	}
+	apriori := NewApriori(transactions)
+
+	var levels []string
+	for _, level := range SummarizeLevels(apriori.FrequentItemsets(NewOptions(0.5, 0, 0, 0))) {
+		levels = append(levels, fmt.Sprintf("%d:%d %.2f %.2f %.2f", level.GetLength(), level.GetCount(), level.GetMinSupport(), level.GetAvgSupport(), level.GetMaxSupport()))
+	}
+	assert(strings.Join(levels, " ") == "1:3 0.50 0.75 1.00 2:2 0.50 0.62 0.75", "Unexpected levels: "+strings.Join(levels, " "))
+
+	records := apriori.Calculate(NewOptions(0.5, 0, 0, 0))
+	assert(len(SummarizeLevels(Itemsets(records))) == 2, "Expected the levels of the itemsets of the rules")
+	assert(len(SummarizeLevels(nil)) == 0, "Expected no level without itemsets")
+}
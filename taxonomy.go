@@ -0,0 +1,127 @@
+package apriori
+
+import (
+	"errors"
+	"sort"
+)
+
+// Returns the ancestors of the item in the taxonomy, closest first.
+func ancestors(item string, taxonomy map[string]string) []string {
+	var result []string
+	for parent, ok := taxonomy[item]; ok; parent, ok = taxonomy[parent] {
+		result = append(result, parent)
+	}
+
+	return result
+}
+
+// Returns an error when following the parents of an item leads back to it.
+func checkTaxonomy(taxonomy map[string]string) error {
+	for item := range taxonomy {
+		visited := map[string]bool{item: true}
+		for parent, ok := taxonomy[item]; ok; parent, ok = taxonomy[parent] {
+			if visited[parent] {
+				return errors.New("taxonomy must not contain cycles")
+			}
+			visited[parent] = true
+		}
+	}
+
+	return nil
+}
+
+// Builds the transaction indexes of the ancestors of the items, an ancestor is supported by the transactions
+// containing itself or any of its descendants. Returns the sorted ancestors that aren't items of the transactions.
+func (a *Apriori) materializeAncestors(taxonomy map[string]string) []string {
+	a.ancestorIndexMap = make(map[string][]int64)
+	for _, item := range a.items {
+		for _, ancestor := range ancestors(item, taxonomy) {
+			indexes, ok := a.ancestorIndexMap[ancestor]
+			if !ok {
+				indexes = a.storedItemIndexes(ancestor)
+			}
+			a.ancestorIndexMap[ancestor] = a.transactionUnion(indexes, a.storedItemIndexes(item))
+		}
+	}
+
+	var result []string
+	for ancestor := range a.ancestorIndexMap {
+		if !a.hasItem(ancestor) {
+			result = append(result, ancestor)
+		}
+	}
+	sort.Strings(result)
+
+	return result
+}
+
+// Returns the sorted union of two sorted transaction index lists.
+func (a *Apriori) transactionUnion(first, second []int64) []int64 {
+	union := make([]int64, 0, len(first)+len(second))
+	i, j := 0, 0
+	for i < len(first) || j < len(second) {
+		switch {
+		case j == len(second) || (i < len(first) && first[i] < second[j]):
+			union = append(union, first[i])
+			i++
+		case i == len(first) || second[j] < first[i]:
+			union = append(union, second[j])
+			j++
+		default:
+			union = append(union, first[i])
+			i++
+			j++
+		}
+	}
+
+	return union
+}
+
+// Returns whether the items contain both an item and one of its ancestors, such itemsets have the same support
+// as the itemsets without the ancestor.
+func (a *Apriori) containsAncestorPair(items []string, taxonomy map[string]string) bool {
+	for _, item := range items {
+		for _, ancestor := range ancestors(item, taxonomy) {
+			if a.inSlice(ancestor, items) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// Drops the rules for which an ancestor rule, with one of the base items replaced by one of its ancestors, has an
+// equal or higher confidence, e.g. {ipa} => {nachos} when {beer} => {nachos} is at least as confident. The adds
+// are not generalized, a more general add is always at least as confident.
+func (a *Apriori) pruneAncestorRedundantRules(orderedStatistics []OrderedStatistic, taxonomy map[string]string) []OrderedStatistic {
+	var nonRedundant []OrderedStatistic
+	for _, orderedStatistic := range orderedStatistics {
+		if !a.hasConfidentAncestorRule(orderedStatistic, taxonomy) {
+			nonRedundant = append(nonRedundant, orderedStatistic)
+		}
+	}
+
+	return nonRedundant
+}
+
+func (a *Apriori) hasConfidentAncestorRule(orderedStatistic OrderedStatistic, taxonomy map[string]string) bool {
+	add := orderedStatistic.add
+	for i, item := range orderedStatistic.base {
+		for _, ancestor := range ancestors(item, taxonomy) {
+			base := append([]string{}, orderedStatistic.base...)
+			base[i] = ancestor
+			base = a.normalizeItems(base)
+			items := append(append([]string{}, base...), add...)
+			if len(a.normalizeItems(items)) < len(items) || a.containsAncestorPair(items, taxonomy) {
+				continue
+			}
+			supportForBase := a.calculateSupport(base)
+			if supportForBase > 0 && a.calculateSupport(items)/supportForBase >= orderedStatistic.confidence-confidenceEpsilon {
+				return true
+			}
+		}
+	}
+
+	return false
+}
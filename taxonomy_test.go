@@ -0,0 +1,45 @@
+package apriori
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestApriori_CalculateWithTaxonomy(t *testing.T) {
+	a := NewApriori([][]string{
+		{"ipa", "nachos"},
+		{"lager", "nachos"},
+		{"stout", "nachos"},
+		{"ipa"},
+		{"cola"},
+	})
+	taxonomy := map[string]string{"ipa": "beer", "lager": "beer", "stout": "beer", "beer": "drinks", "cola": "drinks"}
+
+	options := NewOptions(0.4, 0.7, 0, 2)
+	options.Taxonomy = taxonomy
+	result := formatRecords(a.Calculate(options))
+	assert(result == "[{{[beer] 0.8} [{[] [beer] 0.8 1}]} {{[drinks] 1} [{[] [drinks] 1 1}]} {{[beer nachos] 0.6} [{[beer] [nachos] 0.7499999999999999 1.2499999999999998} {[nachos] [beer] 1 1.25}]} {{[drinks nachos] 0.6} [{[nachos] [drinks] 1 1}]}]", "Unexpected multi-level rules: "+result)
+
+	options = NewOptions(0.2, 0.5, 0, 2)
+	options.Taxonomy = taxonomy
+	options.IncludeItems = []string{"ipa", "beer", "nachos"}
+	// {ipa} => {nachos} is less confident than {beer} => {nachos}.
+	options.PruneAncestorRedundantRules = true
+	result = formatRecords(a.Calculate(options))
+	assert(result == "[{{[beer] 0.8} [{[] [beer] 0.8 1}]} {{[nachos] 0.6} [{[] [nachos] 0.6 1}]} {{[beer nachos] 0.6} [{[beer] [nachos] 0.7499999999999999 1.2499999999999998} {[nachos] [beer] 1 1.25}]}]", "Unexpected ancestor pruned rules: "+result)
+
+	// The ancestors found in the transactions aren't new items, with the index in memory or in a store.
+	transactions := [][]string{{"ipa", "nachos"}, {"beer"}}
+	store := NewMemoryTransactionStore()
+	for _, transaction := range transactions {
+		_ = store.AddTransaction(transaction)
+	}
+	stored, _ := NewAprioriFromStore(store)
+	for _, b := range []*Apriori{NewApriori(transactions), stored} {
+		added := fmt.Sprint(b.miningView().materializeAncestors(taxonomy))
+		assert(added == "[drinks]", "Unexpected ancestors added to the items: "+added)
+	}
+
+	options.Taxonomy = map[string]string{"a": "b", "b": "a"}
+	assert(options.Validate() != nil, "Expected an error for a taxonomy with cycles")
+}
@@ -0,0 +1,46 @@
+package apriori
+
+// RuleTemplate constrains the categories of the items of the rules, e.g. the bases from the "snacks" and "drinks"
+// departments and the adds from the "dairy" one, for cross-selling between departments. An empty list of
+// categories doesn't constrain its side of the rules.
+type RuleTemplate struct {
+	BaseCategories []string
+	AddCategories  []string
+}
+
+// Returns whether the categories of all the items are in the list, or the list is empty. The items without a
+// category only match an empty list.
+func (rt RuleTemplate) itemsIn(items []string, categories []string, itemCategories map[string]string) bool {
+	if len(categories) == 0 {
+		return true
+	}
+	var a Apriori
+	for _, item := range items {
+		category, ok := itemCategories[item]
+		if !ok || !a.inSlice(category, categories) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Returns whether the rule matches the template.
+func (rt RuleTemplate) matches(orderedStatistic OrderedStatistic, itemCategories map[string]string) bool {
+	return rt.itemsIn(orderedStatistic.base, rt.BaseCategories, itemCategories) &&
+		rt.itemsIn(orderedStatistic.add, rt.AddCategories, itemCategories)
+}
+
+// Returns whether the rule matches any of the templates of the options, or there are none.
+func (options Options) matchesRuleTemplates(orderedStatistic OrderedStatistic) bool {
+	if len(options.RuleTemplates) == 0 {
+		return true
+	}
+	for _, template := range options.RuleTemplates {
+		if template.matches(orderedStatistic, options.ItemCategories) {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,43 @@
+package apriori
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestApriori_CalculateWithRuleTemplates(t *testing.T) {
+	transactions := [][]string{
+		{"chips", "beer", "cheese"},
+		{"chips", "beer", "cheese"},
+		{"chips", "milk"},
+		{"beer", "cheese", "milk"},
+	}
+	categories := map[string]string{"chips": "snacks", "beer": "drinks", "cheese": "dairy", "milk": "dairy"}
+	options, err := NewOptionsWith(WithMinSupport(0.5), WithRuleTemplates(categories, RuleTemplate{
+		BaseCategories: []string{"snacks", "drinks"},
+		AddCategories:  []string{"dairy"},
+	}))
+	assert(err == nil, "Expected valid options")
+
+	var rules []string
+	for _, record := range NewApriori(transactions).Calculate(options) {
+		for _, orderedStatistic := range record.GetOrderedStatistic() {
+			if len(orderedStatistic.GetBase()) > 0 {
+				rules = append(rules, RuleKey(orderedStatistic))
+			}
+		}
+	}
+	expected := `{"beer"} => {"cheese"}, {"chips"} => {"cheese"}, {"beer","chips"} => {"cheese"}`
+	assert(strings.Join(rules, ", ") == expected, "Unexpected rules: "+strings.Join(rules, ", "))
+
+	// A side without categories isn't constrained, the items without a category match no other side.
+	options, _ = NewOptionsWith(WithMinSupport(0.5), WithRuleTemplates(map[string]string{"cheese": "dairy"}, RuleTemplate{AddCategories: []string{"dairy"}}))
+	for _, record := range NewApriori(transactions).Calculate(options) {
+		for _, orderedStatistic := range record.GetOrderedStatistic() {
+			assert(orderedStatistic.GetAdd()[0] == "cheese", "Unexpected rule: "+RuleKey(orderedStatistic))
+		}
+	}
+
+	_, err = NewOptionsWith(WithRuleTemplates(nil, RuleTemplate{}))
+	assert(err != nil, "Expected templates without categories to be rejected")
+}
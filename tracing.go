@@ -0,0 +1,34 @@
+package apriori
+
+import "context"
+
+// Tracer starts the spans of the mining phases, e.g. to diagnose slow runs in distributed traces with the
+// aprioriotel module, see Options.Tracer. Like a MetricsCollector it must be safe for concurrent use.
+type Tracer interface {
+	// Start starts a span, child of the span of the context if any, and returns a context derived from ctx with it
+	Start(ctx context.Context, name string) (context.Context, Span)
+}
+
+// Span is a mining phase started by a Tracer
+type Span interface {
+	// SetAttribute annotates the span, e.g. with the number of candidates of a level
+	SetAttribute(key string, value int64)
+	// End ends the span
+	End()
+}
+
+// Span of the runs without a Tracer.
+type noopSpan struct{}
+
+func (noopSpan) SetAttribute(string, int64) {}
+
+func (noopSpan) End() {}
+
+// Starts a span with the Tracer of the options, if any.
+func (options Options) startSpan(ctx context.Context, name string) (context.Context, Span) {
+	if options.Tracer == nil {
+		return ctx, noopSpan{}
+	}
+
+	return options.Tracer.Start(ctx, name)
+}
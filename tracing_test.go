@@ -0,0 +1,76 @@
+package apriori
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"testing"
+)
+
+type recordingTracer struct {
+	mu    sync.Mutex
+	spans []*recordingSpan
+}
+
+type recordingSpan struct {
+	tracer     *recordingTracer
+	name       string
+	attributes map[string]int64
+	ended      bool
+}
+
+func (t *recordingTracer) Start(ctx context.Context, name string) (context.Context, Span) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	span := &recordingSpan{tracer: t, name: name, attributes: make(map[string]int64)}
+	t.spans = append(t.spans, span)
+	return ctx, span
+}
+
+func (s *recordingSpan) SetAttribute(key string, value int64) {
+	s.tracer.mu.Lock()
+	defer s.tracer.mu.Unlock()
+	s.attributes[key] = value
+}
+
+func (s *recordingSpan) End() {
+	s.tracer.mu.Lock()
+	defer s.tracer.mu.Unlock()
+	s.ended = true
+}
+
+func (t *recordingTracer) format() string {
+	var spans []string
+	for _, span := range t.spans {
+		if !span.ended {
+			return "unended span " + span.name
+		}
+		spans = append(spans, fmt.Sprintf("%s %v", span.name, span.attributes))
+	}
+	sort.Strings(spans)
+	return fmt.Sprint(spans)
+}
+
+func TestApriori_CalculateWithTracer(t *testing.T) {
+	transactions := [][]string{
+		{"beer", "nuts", "cheese"},
+		{"beer", "nuts"},
+		{"beer", "nuts", "jam"},
+		{"beer", "cheese"},
+	}
+	tracer := &recordingTracer{}
+	options, _ := NewOptionsWith(WithMinSupport(0.5), WithMinConfidence(0.5), WithMaxPValue(1, NoCorrection), WithTracer(tracer))
+	NewApriori(transactions).Calculate(options)
+
+	spans := tracer.format()
+	assert(spans == "[apriori.Calculate map[rules:7 transactions:4] apriori.level map[candidates:3 frequent:2 length:2] "+
+		"apriori.level map[candidates:4 frequent:3 length:1] apriori.rules map[] apriori.significance map[]]", "Unexpected spans: "+spans)
+
+	tracer = &recordingTracer{}
+	options, _ = NewOptionsWith(WithMinSupport(0.5), WithTracer(tracer))
+	NewApriori(transactions).FrequentItemsets(options)
+	spans = tracer.format()
+	assert(spans == "[apriori.FrequentItemsets map[transactions:4] apriori.level map[candidates:3 frequent:2 length:2] "+
+		"apriori.level map[candidates:4 frequent:3 length:1]]", "Unexpected spans: "+spans)
+}
@@ -0,0 +1,51 @@
+package apriori
+
+import "sort"
+
+// Prefix tree of the sorted itemsets of a level, so checking whether an itemset is one of them takes a lookup per
+// item instead of a scan of all of them.
+type itemsetTrie struct {
+	children map[string]*itemsetTrie
+	end      bool
+}
+
+func newItemsetTrie(itemsets [][]string) *itemsetTrie {
+	t := &itemsetTrie{}
+	for _, items := range itemsets {
+		t.insert(items)
+	}
+
+	return t
+}
+
+func (t *itemsetTrie) insert(items []string) {
+	if !sort.StringsAreSorted(items) {
+		items = append([]string{}, items...)
+		sort.Strings(items)
+	}
+	node := t
+	for _, item := range items {
+		if node.children == nil {
+			node.children = make(map[string]*itemsetTrie)
+		}
+		child, ok := node.children[item]
+		if !ok {
+			child = &itemsetTrie{}
+			node.children[item] = child
+		}
+		node = child
+	}
+	node.end = true
+}
+
+// Returns whether the sorted items are one of the itemsets.
+func (t *itemsetTrie) contains(items []string) bool {
+	node := t
+	for _, item := range items {
+		if node = node.children[item]; node == nil {
+			return false
+		}
+	}
+
+	return node.end
+}
@@ -0,0 +1,13 @@
+package apriori
+
+import "testing"
+
+func TestItemsetTrie(t *testing.T) {
+	trie := newItemsetTrie([][]string{{"beer", "nuts"}, {"jam", "beer"}})
+
+	assert(trie.contains([]string{"beer", "nuts"}), "Expected an inserted itemset to be found")
+	assert(trie.contains([]string{"beer", "jam"}), "Expected the itemsets to be inserted sorted")
+	assert(!trie.contains([]string{"beer"}), "Expected a prefix not to be found")
+	assert(!trie.contains([]string{"beer", "nuts", "jam"}), "Expected a longer itemset not to be found")
+	assert(!trie.contains([]string{"nuts"}), "Expected a missing itemset not to be found")
+}
@@ -0,0 +1,106 @@
+package apriori
+
+import (
+	"context"
+	"sort"
+)
+
+// Maximum number of probes of the binary search of TuneMinSupport.
+const maxTuningProbes = 24
+
+// TuneMinSupport returns the lowest minimum support producing at most options.TargetItemsetCount frequent itemsets
+// with the other options, or the minimum support of the options without a target. It binary searches between 0 and
+// the highest support of an item, mining at every probe until the target is exceeded, and the thresholds letting
+// more items through than the target are rejected from the supports of the items, without mining. Calculate and
+// FrequentItemsets use it when the options have a target.
+func (a *Apriori) TuneMinSupport(options Options) float64 {
+	if err := options.check(); err != nil {
+		panic(err)
+	}
+	a = a.miningView()
+
+	minSupport, err := a.tuneMinSupport(context.Background(), options)
+	if err != nil {
+		panic(err)
+	}
+
+	return minSupport
+}
+
+// Returns the lowest minimum support producing at most options.TargetItemsetCount frequent itemsets, see
+// TuneMinSupport, or the error of the context.
+func (a *Apriori) tuneMinSupport(ctx context.Context, options Options) (float64, error) {
+	if options.TargetItemsetCount == 0 {
+		return options.minSupport, nil
+	}
+	options.Consequent = a.normalizeItems(options.Consequent)
+	options.MetricsCollector, options.Tracer, options.Logger = nil, nil, nil
+
+	var itemSupports []float64
+	for _, candidate := range a.initialCandidates(options) {
+		itemSupports = append(itemSupports, a.indexesToSupport(candidate, a.itemIndexes(candidate[0])))
+	}
+	sort.Float64s(itemSupports)
+	if len(itemSupports) == 0 {
+		return options.minSupport, nil
+	}
+	// The single items are all emitted when nothing else filters them.
+	_, singleItemsMinSupport := options.MinSupportByLength[1]
+	singleItemsCounted := len(options.Consequent) == 0 && options.MinLength <= 1 && !singleItemsMinSupport
+
+	// count(hi) <= target < count(lo), with count(0) taken as more than the target.
+	lo, hi := 0.0, itemSupports[len(itemSupports)-1]
+	for probe := 0; probe < maxTuningProbes && hi-lo > 1/float64(2*a.transactionNo); probe++ {
+		mid := (lo + hi) / 2
+		exceeded := false
+		if items := len(itemSupports) - sort.SearchFloat64s(itemSupports, mid); singleItemsCounted && items > options.TargetItemsetCount {
+			exceeded = true
+		} else {
+			options.minSupport = mid
+			count, err := a.countItemsets(ctx, options, options.TargetItemsetCount)
+			if err != nil {
+				return 0, err
+			}
+			exceeded = count > options.TargetItemsetCount
+		}
+		if exceeded {
+			lo = mid
+		} else {
+			hi = mid
+		}
+	}
+
+	return hi, nil
+}
+
+// Returns the number of frequent itemsets with the options, counting no further than limit + 1. The generator is
+// stopped before returning, as the next probe mines the same view.
+func (a *Apriori) countItemsets(ctx context.Context, options Options, limit int) (int, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	supportRecords := make(chan SupportRecord)
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		a.generateSupportRecords(ctx, supportRecords, options)
+	}()
+	// Once the context is canceled the generator drops its records instead of blocking, so it stops promptly.
+	defer func() {
+		cancel()
+		<-done
+	}()
+
+	count := 0
+	for count <= limit {
+		select {
+		case supportRecord := <-supportRecords:
+			if supportRecord.support == -1 {
+				return count, ctx.Err()
+			}
+			count++
+		case <-ctx.Done():
+			return 0, ctx.Err()
+		}
+	}
+
+	return count, nil
+}
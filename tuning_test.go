@@ -0,0 +1,78 @@
+package apriori
+
+import (
+	"fmt"
+	"math/rand"
+	"sync"
+	"testing"
+)
+
+func TestApriori_TuneMinSupport(t *testing.T) {
+	random := rand.New(rand.NewSource(3))
+	items := []string{"beer", "nuts", "cheese", "jam", "wine", "butter", "bread", "milk"}
+	var transactions [][]string
+	for i := 0; i < 400; i++ {
+		var transaction []string
+		for j, item := range items {
+			if random.Float64() < 0.6/float64(j+1) {
+				transaction = append(transaction, item)
+			}
+		}
+		transactions = append(transactions, transaction)
+	}
+	apriori := NewApriori(transactions)
+
+	for _, target := range []int{1, 5, 20, 60} {
+		options, _ := NewOptionsWith(WithTargetItemsetCount(target))
+		minSupport := apriori.TuneMinSupport(options)
+		itemsets := apriori.FrequentItemsets(NewOptions(minSupport, 0, 0, 0))
+		assert(len(itemsets) <= target, fmt.Sprintf("Expected at most %d itemsets, got %d", target, len(itemsets)))
+		// One transaction less is enough to exceed the target.
+		lower := apriori.FrequentItemsets(NewOptions(minSupport-1.0/float64(len(transactions)), 0, 0, 0))
+		assert(len(lower) > target, fmt.Sprintf("Expected the lowest minimum support for %d itemsets", target))
+
+		tuned := apriori.FrequentItemsets(options)
+		assert(len(tuned) == len(itemsets), "Expected FrequentItemsets to use the tuned minimum support")
+	}
+
+	options, _ := NewOptionsWith(WithTargetItemsetCount(10), WithMinLength(2))
+	records := apriori.Calculate(options)
+	assert(len(records) > 0 && len(records) <= 10, fmt.Sprintf("Expected at most 10 records, got %d", len(records)))
+}
+
+// Run with -race: the probes stopped early must be finished before the next one materializes the negated items and
+// the ancestors of the same view.
+func TestApriori_TuneMinSupportConcurrently(t *testing.T) {
+	random := rand.New(rand.NewSource(5))
+	items := []string{"beer", "nuts", "cheese", "jam", "wine", "butter", "bread", "milk"}
+	var transactions [][]string
+	for i := 0; i < 300; i++ {
+		var transaction []string
+		for j, item := range items {
+			if random.Float64() < 0.7/float64(j+1) {
+				transaction = append(transaction, item)
+			}
+		}
+		transactions = append(transactions, transaction)
+	}
+	apriori := NewApriori(transactions)
+
+	options, _ := NewOptionsWith(WithTargetItemsetCount(8), WithNegatedItems(0.2),
+		WithTaxonomy(map[string]string{"beer": "drinks", "wine": "drinks", "nuts": "snacks", "cheese": "snacks"}))
+	expected := formatRecords(apriori.Calculate(options))
+
+	var wg sync.WaitGroup
+	results := make([]string, 8)
+	for i := range results {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			results[i] = formatRecords(apriori.Calculate(options))
+		}(i)
+	}
+	wg.Wait()
+
+	for _, result := range results {
+		assert(result == expected, "Expected the concurrent tuned runs to find the same rules")
+	}
+}
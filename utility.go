@@ -0,0 +1,110 @@
+package apriori
+
+import (
+	"errors"
+	"fmt"
+)
+
+// UtilityRecord contains an itemset, its support and the total utility it brought over all the transactions
+type UtilityRecord struct {
+	items   []string
+	support float64
+	utility float64
+}
+
+// GetItems will return the items of the utility record
+func (ur UtilityRecord) GetItems() []string {
+	return ur.items
+}
+
+// GetSupport will return the support of the items
+func (ur UtilityRecord) GetSupport() float64 {
+	return ur.support
+}
+
+// GetUtility will return the sum of quantity times unit utility of the items over the transactions containing them
+func (ur UtilityRecord) GetUtility() float64 {
+	return ur.utility
+}
+
+// HighUtilityItemsets returns the itemsets whose total utility reaches minUtility, e.g. the profit they brought.
+// The quantities are given per transaction, in the order the transactions were added, and default to 1 for the
+// missing transactions or items; the items without a unit utility are worth 0. The candidates are pruned by their
+// transaction-weighted utility (Two-Phase), so the minimum support doesn't apply, only the length limits and the
+// include/exclude items of the options do, e.g. NewOptionsWith(WithMaxLength(3)). The other options are ignored.
+func (a *Apriori) HighUtilityItemsets(utilities map[string]float64, quantities []map[string]float64, minUtility float64, options Options) ([]UtilityRecord, error) {
+	if options.MinLength < 0 || options.maxLength < 0 {
+		return nil, errors.New("minimum and maximum lengths must be >= 0")
+	}
+	if options.maxLength > 0 && options.MinLength > options.maxLength {
+		return nil, errors.New("minimum length must be <= maximum length")
+	}
+	if minUtility <= 0 {
+		return nil, errors.New("min utility must be a positive number")
+	}
+	if int64(len(quantities)) > a.transactionNo {
+		return nil, fmt.Errorf("quantities are given for %d transactions but there are only %d", len(quantities), a.transactionNo)
+	}
+	for item, utility := range utilities {
+		if utility < 0 {
+			return nil, fmt.Errorf("utility of %v must not be negative", item)
+		}
+	}
+	for _, transactionQuantities := range quantities {
+		for item, quantity := range transactionQuantities {
+			if quantity < 0 {
+				return nil, fmt.Errorf("quantity of %v must not be negative", item)
+			}
+		}
+	}
+
+	quantity := func(index int64, item string) float64 {
+		if index < int64(len(quantities)) {
+			if q, ok := quantities[index][item]; ok {
+				return q
+			}
+		}
+		return 1
+	}
+
+	// The transaction utility bounds the utility of every itemset of the transaction, and summed over the
+	// transactions containing an itemset it is anti-monotone, unlike the utility itself.
+	transactionUtilities := make([]float64, a.transactionNo)
+	for _, item := range a.items {
+		for _, index := range a.storedItemIndexes(item) {
+			transactionUtilities[index] += quantity(index, item) * utilities[item]
+		}
+	}
+
+	var records []UtilityRecord
+	a = a.miningView()
+	candidates := a.initialCandidates(Options{IncludeItems: options.IncludeItems, ExcludeItems: options.ExcludeItems})
+	length := 1
+	for len(candidates) > 0 {
+		if options.maxLength != 0 && length > options.maxLength {
+			break
+		}
+		var relations [][]string
+		for _, candidate := range candidates {
+			indexes := a.calculateTransactionIndexes(candidate)
+			weightedUtility, utility := 0.0, 0.0
+			for _, index := range indexes {
+				weightedUtility += transactionUtilities[index]
+				for _, item := range candidate {
+					utility += quantity(index, item) * utilities[item]
+				}
+			}
+			if weightedUtility < minUtility {
+				continue
+			}
+			relations = append(relations, candidate)
+			if utility >= minUtility && length >= options.MinLength {
+				records = append(records, UtilityRecord{candidate, a.countToSupport(int64(len(indexes))), utility})
+			}
+		}
+		length++
+		candidates = a.createNextCandidates(relations, length)
+	}
+
+	return records, nil
+}
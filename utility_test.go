@@ -0,0 +1,40 @@
+package apriori
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestApriori_HighUtilityItemsets(t *testing.T) {
+	a := NewApriori([][]string{
+		{"bread", "milk"},
+		{"bread", "milk"},
+		{"bread", "milk", "wine"},
+		{"bread"},
+		{"wine", "cheese"},
+	})
+	utilities := map[string]float64{"bread": 1, "milk": 2, "wine": 20, "cheese": 5}
+	quantities := []map[string]float64{nil, {"milk": 3}, {"wine": 2}}
+
+	records, err := a.HighUtilityItemsets(utilities, quantities, 25, NewOptions(0.1, 0, 0, 0))
+	assert(err == nil, "Expected the high utility itemsets to be mined")
+
+	var formatted []string
+	for _, record := range records {
+		formatted = append(formatted, fmt.Sprintf("%v:%v:%v", record.GetItems(), record.GetSupport(), record.GetUtility()))
+	}
+	result := fmt.Sprint(formatted)
+	assert(result == "[[wine]:0.4:60 [bread wine]:0.2:41 [cheese wine]:0.2:25 [milk wine]:0.2:42 [bread milk wine]:0.2:43]", "Unexpected high utility itemsets: "+result)
+
+	supportless, err := a.HighUtilityItemsets(utilities, quantities, 25, Options{})
+	assert(err == nil && len(supportless) == len(records), "Expected the high utility itemsets to be mined without a minimum support")
+
+	_, err = a.HighUtilityItemsets(utilities, quantities, 25, NewOptions(0, 0, 0, -1))
+	assert(err != nil, "Expected an error for a negative maximum length")
+
+	_, err = a.HighUtilityItemsets(utilities, quantities, 0, NewOptions(0.1, 0, 0, 0))
+	assert(err != nil, "Expected an error for zero min utility")
+
+	_, err = a.HighUtilityItemsets(map[string]float64{"bread": -1}, nil, 1, NewOptions(0.1, 0, 0, 0))
+	assert(err != nil, "Expected an error for a negative utility")
+}
@@ -0,0 +1,216 @@
+package apriori
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// Styles of the cells of the workbooks, indexes in the cellXfs of xlsxStyles.
+const (
+	xlsxDefaultStyle = iota
+	xlsxHeaderStyle
+	xlsxPercentStyle
+	xlsxDecimalStyle
+)
+
+// Styles of the workbooks: a bold header on a grey fill, percentages (built-in format 10) and decimals (built-in
+// format 2).
+const xlsxStyles = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<styleSheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main">
+<fonts count="2"><font><sz val="11"/><name val="Calibri"/></font><font><b/><sz val="11"/><name val="Calibri"/></font></fonts>
+<fills count="3"><fill><patternFill patternType="none"/></fill><fill><patternFill patternType="gray125"/></fill><fill><patternFill patternType="solid"><fgColor rgb="FFD9D9D9"/></patternFill></fill></fills>
+<borders count="1"><border><left/><right/><top/><bottom/><diagonal/></border></borders>
+<cellStyleXfs count="1"><xf numFmtId="0" fontId="0" fillId="0" borderId="0"/></cellStyleXfs>
+<cellXfs count="4"><xf numFmtId="0" fontId="0" fillId="0" borderId="0" xfId="0"/><xf numFmtId="0" fontId="1" fillId="2" borderId="0" xfId="0" applyFont="1" applyFill="1"/><xf numFmtId="10" fontId="0" fillId="0" borderId="0" xfId="0" applyNumberFormat="1"/><xf numFmtId="2" fontId="0" fillId="0" borderId="0" xfId="0" applyNumberFormat="1"/></cellXfs>
+</styleSheet>`
+
+// xlsxCell is a cell of a worksheet, a number when text is empty.
+type xlsxCell struct {
+	text   string
+	number float64
+	style  int
+}
+
+// xlsxSheet is a worksheet of the workbook.
+type xlsxSheet struct {
+	name   string
+	widths []float64 // Widths of the columns, in characters.
+	rows   [][]xlsxCell
+	table  bool // Whether the first row is a header, frozen and with an auto filter.
+}
+
+// WriteXLSX writes the rules and the itemsets as an Excel workbook, for the stakeholders reading the results in a
+// spreadsheet: a Rules sheet with the antecedents, consequents, support, confidence, lift and count of every rule, an
+// Itemsets sheet with the items, length, support and count of every itemset, and a Summary sheet with their numbers
+// and the statistics of the itemsets of every length. The headers are frozen and the rules and itemsets can be
+// filtered. The statistics of the single items aren't rules and are left out, the unknown statistics are empty.
+func WriteXLSX(w io.Writer, records []RelationRecord, itemsets []SupportRecord) error {
+	rules := xlsxSheet{name: "Rules", widths: []float64{40, 30, 12, 12, 10, 10}, table: true}
+	rules.rows = append(rules.rows, xlsxHeader("Antecedents", "Consequents", "Support", "Confidence", "Lift", "Count"))
+	for _, record := range records {
+		for _, orderedStatistic := range record.orderedStatistic {
+			if len(orderedStatistic.base) == 0 {
+				continue
+			}
+			rules.rows = append(rules.rows, []xlsxCell{
+				{text: strings.Join(orderedStatistic.base, ", ")},
+				{text: strings.Join(orderedStatistic.add, ", ")},
+				{number: record.supportRecord.support, style: xlsxPercentStyle},
+				{number: orderedStatistic.confidence, style: xlsxPercentStyle},
+				{number: orderedStatistic.lift, style: xlsxDecimalStyle},
+				{number: float64(orderedStatistic.supportCount)},
+			})
+		}
+	}
+
+	itemsetSheet := xlsxSheet{name: "Itemsets", widths: []float64{50, 10, 12, 10}, table: true}
+	itemsetSheet.rows = append(itemsetSheet.rows, xlsxHeader("Items", "Length", "Support", "Count"))
+	for _, itemset := range itemsets {
+		itemsetSheet.rows = append(itemsetSheet.rows, []xlsxCell{
+			{text: strings.Join(itemset.items, ", ")},
+			{number: float64(len(itemset.items))},
+			{number: itemset.support, style: xlsxPercentStyle},
+			{number: float64(itemset.supportCount)},
+		})
+	}
+
+	summary := xlsxSheet{name: "Summary", widths: []float64{14, 12, 14, 14, 14}}
+	summary.rows = append(summary.rows,
+		[]xlsxCell{{text: "Rules", style: xlsxHeaderStyle}, {number: float64(len(rules.rows) - 1)}},
+		[]xlsxCell{{text: "Itemsets", style: xlsxHeaderStyle}, {number: float64(len(itemsets))}},
+		nil,
+		xlsxHeader("Length", "Itemsets", "Min support", "Avg support", "Max support"),
+	)
+	for _, level := range SummarizeLevels(itemsets) {
+		summary.rows = append(summary.rows, []xlsxCell{
+			{number: float64(level.length)},
+			{number: float64(level.count)},
+			{number: level.minSupport, style: xlsxPercentStyle},
+			{number: level.avgSupport, style: xlsxPercentStyle},
+			{number: level.maxSupport, style: xlsxPercentStyle},
+		})
+	}
+
+	return writeWorkbook(w, []xlsxSheet{rules, itemsetSheet, summary})
+}
+
+// Returns a header row of the columns.
+func xlsxHeader(columns ...string) []xlsxCell {
+	row := make([]xlsxCell, len(columns))
+	for i, column := range columns {
+		row[i] = xlsxCell{text: column, style: xlsxHeaderStyle}
+	}
+
+	return row
+}
+
+// Writes the sheets as an Office Open XML workbook, a zip of XML parts.
+func writeWorkbook(w io.Writer, sheets []xlsxSheet) error {
+	var contentTypes, workbook, workbookRels strings.Builder
+	contentTypes.WriteString(`<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Types xmlns="http://schemas.openxmlformats.org/package/2006/content-types"><Default Extension="rels" ContentType="application/vnd.openxmlformats-package.relationships+xml"/><Default Extension="xml" ContentType="application/xml"/><Override PartName="/xl/workbook.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.sheet.main+xml"/><Override PartName="/xl/styles.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.styles+xml"/>`)
+	workbook.WriteString(`<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<workbook xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main" xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships"><sheets>`)
+	workbookRels.WriteString(`<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">`)
+	for i, sheet := range sheets {
+		fmt.Fprintf(&contentTypes, `<Override PartName="/xl/worksheets/sheet%d.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.worksheet+xml"/>`, i+1)
+		fmt.Fprintf(&workbook, `<sheet name="%s" sheetId="%d" r:id="rId%d"/>`, xmlEscape(sheet.name), i+1, i+1)
+		fmt.Fprintf(&workbookRels, `<Relationship Id="rId%d" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/worksheet" Target="worksheets/sheet%d.xml"/>`, i+1, i+1)
+	}
+	contentTypes.WriteString(`</Types>`)
+	workbook.WriteString(`</sheets></workbook>`)
+	fmt.Fprintf(&workbookRels, `<Relationship Id="rId%d" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/styles" Target="styles.xml"/></Relationships>`, len(sheets)+1)
+
+	parts := []struct {
+		name    string
+		content string
+	}{
+		{"[Content_Types].xml", contentTypes.String()},
+		{"_rels/.rels", `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships"><Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/officeDocument" Target="xl/workbook.xml"/></Relationships>`},
+		{"xl/workbook.xml", workbook.String()},
+		{"xl/_rels/workbook.xml.rels", workbookRels.String()},
+		{"xl/styles.xml", xlsxStyles},
+	}
+	for i, sheet := range sheets {
+		parts = append(parts, struct {
+			name    string
+			content string
+		}{fmt.Sprintf("xl/worksheets/sheet%d.xml", i+1), sheet.xml()})
+	}
+
+	archive := zip.NewWriter(w)
+	for _, part := range parts {
+		file, err := archive.Create(part.name)
+		if err != nil {
+			return err
+		}
+		if _, err := io.WriteString(file, part.content); err != nil {
+			return err
+		}
+	}
+
+	return archive.Close()
+}
+
+// Returns the XML of the worksheet.
+func (sheet xlsxSheet) xml() string {
+	var b strings.Builder
+	b.WriteString(`<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<worksheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main">`)
+	if sheet.table {
+		b.WriteString(`<sheetViews><sheetView workbookViewId="0"><pane ySplit="1" topLeftCell="A2" activePane="bottomLeft" state="frozen"/></sheetView></sheetViews>`)
+	}
+	b.WriteString(`<cols>`)
+	for i, width := range sheet.widths {
+		fmt.Fprintf(&b, `<col min="%d" max="%d" width="%g" customWidth="1"/>`, i+1, i+1, width)
+	}
+	b.WriteString(`</cols><sheetData>`)
+	for r, row := range sheet.rows {
+		fmt.Fprintf(&b, `<row r="%d">`, r+1)
+		for c, cell := range row {
+			ref := xlsxColumn(c) + strconv.Itoa(r+1)
+			switch {
+			case cell.text != "":
+				fmt.Fprintf(&b, `<c r="%s" s="%d" t="inlineStr"><is><t xml:space="preserve">%s</t></is></c>`, ref, cell.style, xmlEscape(cell.text))
+			case math.IsNaN(cell.number) || math.IsInf(cell.number, 0):
+				// Excel has no NaN or infinite numbers, the unknown statistics are left empty.
+			default:
+				fmt.Fprintf(&b, `<c r="%s" s="%d"><v>%s</v></c>`, ref, cell.style, strconv.FormatFloat(cell.number, 'g', -1, 64))
+			}
+		}
+		b.WriteString(`</row>`)
+	}
+	b.WriteString(`</sheetData>`)
+	if sheet.table && len(sheet.rows) > 0 {
+		fmt.Fprintf(&b, `<autoFilter ref="A1:%s%d"/>`, xlsxColumn(len(sheet.rows[0])-1), len(sheet.rows))
+	}
+	b.WriteString(`</worksheet>`)
+
+	return b.String()
+}
+
+// Returns the letters of the column with the given index, A for 0.
+func xlsxColumn(index int) string {
+	name := ""
+	for index++; index > 0; index = (index - 1) / 26 {
+		name = string(rune('A'+(index-1)%26)) + name
+	}
+
+	return name
+}
+
+// Returns s escaped for the text and the attributes of an XML element.
+func xmlEscape(s string) string {
+	var b bytes.Buffer
+	_ = xml.EscapeText(&b, []byte(s))
+
+	return b.String()
+}
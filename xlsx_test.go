@@ -0,0 +1,65 @@
+package apriori
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/xml"
+	"io"
+	"strings"
+	"testing"
+)
+
+func TestWriteXLSX(t *testing.T) {
+	transactions := [][]string{
+		{"beer", "nuts"},
+		{"beer", "nuts"},
+		{"beer"},
+		{"jam & bread"},
+	}
+	apriori := NewApriori(transactions)
+	records := apriori.Calculate(NewOptions(0.25, 0, 0, 0))
+	itemsets := apriori.FrequentItemsets(NewOptions(0.25, 0, 0, 0))
+
+	var buffer bytes.Buffer
+	assert(WriteXLSX(&buffer, records, itemsets) == nil, "Expected the workbook to be written")
+	archive, err := zip.NewReader(bytes.NewReader(buffer.Bytes()), int64(buffer.Len()))
+	assert(err == nil, "Expected a zip archive")
+
+	parts := make(map[string]string)
+	for _, file := range archive.File {
+		reader, err := file.Open()
+		assert(err == nil, "Expected the part to be readable")
+		content, _ := io.ReadAll(reader)
+		reader.Close()
+		parts[file.Name] = string(content)
+
+		// Every part is well-formed XML.
+		decoder := xml.NewDecoder(bytes.NewReader(content))
+		for {
+			_, err := decoder.Token()
+			if err == io.EOF {
+				break
+			}
+			assert(err == nil, "Expected well-formed XML in "+file.Name)
+		}
+	}
+	for _, name := range []string{"[Content_Types].xml", "_rels/.rels", "xl/workbook.xml", "xl/_rels/workbook.xml.rels", "xl/styles.xml",
+		"xl/worksheets/sheet1.xml", "xl/worksheets/sheet2.xml", "xl/worksheets/sheet3.xml"} {
+		_, ok := parts[name]
+		assert(ok, "Expected the part "+name)
+	}
+	assert(strings.Contains(parts["xl/workbook.xml"], `<sheet name="Rules" sheetId="1" r:id="rId1"/><sheet name="Itemsets" sheetId="2" r:id="rId2"/><sheet name="Summary" sheetId="3" r:id="rId3"/>`), "Unexpected sheets: "+parts["xl/workbook.xml"])
+
+	rules := parts["xl/worksheets/sheet1.xml"]
+	assert(strings.Contains(rules, `<row r="2"><c r="A2" s="0" t="inlineStr"><is><t xml:space="preserve">beer</t></is></c><c r="B2" s="0" t="inlineStr"><is><t xml:space="preserve">nuts</t></is></c><c r="C2" s="2"><v>0.5</v></c><c r="D2" s="2"><v>0.6666666666666666</v></c><c r="E2" s="3"><v>1.3333333333333333</v></c><c r="F2" s="0"><v>2</v></c></row>`), "Unexpected rule row: "+rules)
+	assert(strings.Contains(rules, `<autoFilter ref="A1:F3"/>`), "Expected the rules to be filterable: "+rules)
+	assert(strings.Contains(rules, `state="frozen"`), "Expected a frozen header")
+
+	itemsetSheet := parts["xl/worksheets/sheet2.xml"]
+	assert(strings.Contains(itemsetSheet, "jam &amp; bread"), "Expected the items to be escaped: "+itemsetSheet)
+	assert(strings.Contains(parts["xl/worksheets/sheet3.xml"], `<c r="A1" s="1" t="inlineStr"><is><t xml:space="preserve">Rules</t></is></c><c r="B1" s="0"><v>2</v></c>`), "Unexpected summary: "+parts["xl/worksheets/sheet3.xml"])
+}
+
+func TestXLSXColumn(t *testing.T) {
+	assert(xlsxColumn(0) == "A" && xlsxColumn(25) == "Z" && xlsxColumn(26) == "AA" && xlsxColumn(701) == "ZZ" && xlsxColumn(702) == "AAA", "Unexpected column names")
+}